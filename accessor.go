@@ -0,0 +1,61 @@
+package errors
+
+import stderrors "errors"
+
+// Code returns the code of the first *Error in err's chain. Other errors get the code Wrap would
+// give them: 500, or 504 and 499 for context.DeadlineExceeded and context.Canceled. Code(nil)
+// returns 0.
+func Code(err error) int64 {
+	if e := describe(err); e != nil {
+		return e.Code
+	}
+	return 0
+}
+
+// Type returns the type of the first *Error in err's chain, or the type Wrap would give other
+// errors, e.g. "INTERNAL_SERVER_ERROR". Type(nil) returns "".
+func Type(err error) string {
+	if e := describe(err); e != nil {
+		return e.Type
+	}
+	return ""
+}
+
+// Message returns the client-facing message of the first *Error in err's chain, or the message
+// Wrap would give other errors, so the text of unknown errors is never exposed. Message(nil)
+// returns "".
+func Message(err error) string {
+	if e := describe(err); e != nil {
+		return e.Message
+	}
+	return ""
+}
+
+// BusinessCode returns the business code of the first *Error in err's chain that has one, so
+// the code survives wrapping in errors that do not set it. It returns "" when none has one.
+func BusinessCode(err error) string {
+	for _, link := range Chain(err) {
+		if e, ok := link.(*Error); ok && e != nil {
+			if code := e.snapshot().BusinessCode; code != "" {
+				return code
+			}
+		}
+	}
+	return ""
+}
+
+// describe returns a snapshot of the first *Error in err's chain, the classification of Wrap
+// for other errors, or nil for a nil error. Unlike Wrap it captures no stack trace and calls no
+// hooks.
+func describe(err error) *Error {
+	if isNil(err) {
+		return nil
+	}
+
+	var e *Error
+	if stderrors.As(err, &e) && e != nil {
+		s := e.snapshot()
+		return &s
+	}
+	return classifyCause(err)
+}
@@ -0,0 +1,50 @@
+package errors
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"testing"
+)
+
+func TestAccessors(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		code    int64
+		typ     string
+		message string
+	}{
+		{"nil", nil, 0, "", ""},
+		{"wrapped error", fmt.Errorf("loading user: %w", ErrorNotFound().WithMessage("User not found")), 404, "NOT_FOUND", "User not found"},
+		{"plain error", stderrors.New("dial tcp: connection refused"), 500, "INTERNAL_SERVER_ERROR", "An internal server error occurred"},
+		{"deadline", fmt.Errorf("query: %w", context.DeadlineExceeded), 504, "GATEWAY_TIMEOUT", "Gateway Timeout"},
+		{"canceled", context.Canceled, 499, "CLIENT_CLOSED_REQUEST", "Client Closed Request"},
+		{"nil *Error", (*Error)(nil), 0, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Code(tt.err); got != tt.code {
+				t.Errorf("Code() = %d, want %d", got, tt.code)
+			}
+			if got := Type(tt.err); got != tt.typ {
+				t.Errorf("Type() = %q, want %q", got, tt.typ)
+			}
+			if got := Message(tt.err); got != tt.message {
+				t.Errorf("Message() = %q, want %q", got, tt.message)
+			}
+		})
+	}
+}
+
+func TestAccessorsDoNotNotify(t *testing.T) {
+	calls := 0
+	handle := RegisterHook(func(*Error) { calls++ })
+	defer handle.Remove()
+
+	_ = Code(stderrors.New("boom"))
+	if calls != 0 {
+		t.Errorf("Accessors should not call hooks, got %d calls", calls)
+	}
+}
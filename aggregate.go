@@ -0,0 +1,125 @@
+package errors
+
+import (
+	stderrors "errors"
+)
+
+// Combine merges multiple errors into a single aggregate *Error, skipping nil values.
+// Violations, fields and stack traces of every *Error are preserved, and the errors are
+// joined with errors.Join so errors.Is and errors.As match any of them.
+// Combine returns nil when every error is nil, including nil *Error values.
+// The aggregate is a new error: it gets its own timestamp and is reported to the created hooks.
+func Combine(errs ...error) *Error {
+	collected := make([]error, 0, len(errs))
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if appErr, ok := err.(*Error); ok {
+			// A nil *Error stored in an error interface is still nil
+			if appErr == nil {
+				continue
+			}
+			if appErr.isAggregate() {
+				collected = append(collected, appErr.Errors()...)
+				continue
+			}
+		}
+		collected = append(collected, err)
+	}
+
+	if len(collected) == 0 {
+		return nil
+	}
+
+	e := &Error{
+		Err: stderrors.Join(collected...),
+	}
+	e.classifyAggregate(collected)
+
+	for _, err := range collected {
+		var appErr *Error
+		if !stderrors.As(err, &appErr) {
+			continue
+		}
+
+		member := appErr.snapshot()
+		e.Violations = append(e.Violations, member.Violations...)
+		e.StackTraces = append(e.StackTraces, member.StackTraces...)
+		for key, value := range member.Fields {
+			e.WithField(key, value)
+		}
+	}
+
+	if len(e.StackTraces) == 0 {
+		e.StackTraces = captureStackTrace(1)
+	}
+
+	return created(e)
+}
+
+// Append adds errs to err and returns the combined aggregate.
+// If err is already an aggregate its errors are flattened rather than nested.
+func Append(err error, errs ...error) *Error {
+	return Combine(append([]error{err}, errs...)...)
+}
+
+// Errors returns the errors joined in an aggregate created by Combine or Append.
+// For any other error it returns a single-element slice containing e, or nil for a nil error.
+func (e *Error) Errors() []error {
+	if e == nil {
+		return nil
+	}
+
+	if joined, ok := e.cause().(interface{ Unwrap() []error }); ok {
+		return joined.Unwrap()
+	}
+
+	return []error{e}
+}
+
+// isAggregate reports whether the error wraps a multi-error
+func (e *Error) isAggregate() bool {
+	return isJoined(e.cause())
+}
+
+// isJoined reports whether err is a multi-error
+func isJoined(err error) bool {
+	_, ok := err.(interface{ Unwrap() []error })
+	return ok
+}
+
+// classifyAggregate sets the type, code and message of an aggregate.
+// Errors sharing one type keep it, client errors collapse to BAD_REQUEST,
+// and anything else is reported as an internal server error.
+func (e *Error) classifyAggregate(errs []error) {
+	var first *Error
+	sameType, clientOnly := true, true
+
+	for _, err := range errs {
+		var appErr *Error
+		if !stderrors.As(err, &appErr) {
+			sameType, clientOnly = false, false
+			break
+		}
+
+		member := appErr.snapshot()
+		if first == nil {
+			first = &member
+		} else if member.Type != first.Type || member.Code != first.Code {
+			sameType = false
+		}
+		if member.Code < 400 || member.Code >= 500 {
+			clientOnly = false
+		}
+	}
+
+	switch {
+	case sameType:
+		e.Type, e.Code, e.Message = first.Type, first.Code, first.Message
+	case clientOnly:
+		e.Type, e.Code, e.Message = "BAD_REQUEST", 400, "Multiple errors occurred"
+	default:
+		e.Type, e.Code, e.Message = "INTERNAL_SERVER_ERROR", 500, "Multiple errors occurred"
+	}
+}
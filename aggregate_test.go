@@ -0,0 +1,100 @@
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+	"testing"
+)
+
+func TestCombine(t *testing.T) {
+	sentinel := stderrors.New("disk full")
+	notFound := ErrorNotFound().WithField("id", 1)
+	invalid := Violations([]ValidationError{
+		{Type: ViolationErrorTypeRequired, Field: "email", Message: "Email is required"},
+	})
+
+	err := Combine(nil, notFound, invalid, fmt.Errorf("writing: %w", sentinel))
+	if err == nil {
+		t.Fatal("Combine should return an aggregate")
+	}
+
+	if err.Code != 500 {
+		t.Errorf("Mixed aggregate should be 500, got %d", err.Code)
+	}
+	if len(err.Errors()) != 3 {
+		t.Errorf("Expected 3 joined errors, got %d", len(err.Errors()))
+	}
+	if len(err.Violations) != 1 {
+		t.Errorf("Expected violations to be preserved, got %d", len(err.Violations))
+	}
+	if err.Fields["id"] != 1 {
+		t.Error("Expected fields to be preserved")
+	}
+	if len(err.StackTraces) != len(notFound.StackTraces)+len(invalid.StackTraces) {
+		t.Error("Expected stack traces of every *Error to be preserved")
+	}
+
+	if !stderrors.Is(err, sentinel) {
+		t.Error("errors.Is should match a joined plain error")
+	}
+	if !stderrors.Is(err, ErrorNotFound()) {
+		t.Error("errors.Is should match a joined *Error")
+	}
+}
+
+func TestCombineClassification(t *testing.T) {
+	if Combine(nil, nil) != nil {
+		t.Error("Combine of nil errors should be nil")
+	}
+
+	same := Combine(ErrorNotFound(), ErrorNotFound())
+	if same.Type != "NOT_FOUND" || same.Code != 404 {
+		t.Errorf("Aggregate of same type should keep it, got %s/%d", same.Type, same.Code)
+	}
+
+	client := Combine(ErrorNotFound(), ErrorConflict())
+	if client.Type != "BAD_REQUEST" || client.Code != 400 {
+		t.Errorf("Aggregate of client errors should be BAD_REQUEST, got %s/%d", client.Type, client.Code)
+	}
+}
+
+func TestAppendFlattens(t *testing.T) {
+	var err *Error
+	for i := 0; i < 3; i++ {
+		err = Append(err, fmt.Errorf("item %d failed", i))
+	}
+
+	if len(err.Errors()) != 3 {
+		t.Errorf("Append should flatten aggregates, got %d errors", len(err.Errors()))
+	}
+}
+
+func TestCombineReportsCreated(t *testing.T) {
+	notFound, conflict := ErrorNotFound(), ErrorConflict()
+
+	hook := &recordingHook{}
+	remove := OnError(hook)
+	defer remove()
+
+	err := Combine(notFound, conflict)
+	if got := hook.recorded(); len(got) != 1 || got[0] != "created:BAD_REQUEST" {
+		t.Errorf("The aggregate should be reported once, got %v", got)
+	}
+	if err.Timestamp.IsZero() || err.Timestamp.Before(conflict.Timestamp) {
+		t.Errorf("The aggregate should get its own timestamp, got %v", err.Timestamp)
+	}
+}
+
+func TestAggregateIsMatchesMembersOnly(t *testing.T) {
+	err := Combine(ErrorNotFound(), ErrorConflict())
+
+	if stderrors.Is(err, ErrorBadRequest()) || IsBadRequest(err) || IsCode(err, 400) {
+		t.Error("The aggregate's derived type should not match")
+	}
+	if !stderrors.Is(err, ErrorNotFound()) || !IsConflict(err) {
+		t.Error("Members should match")
+	}
+	if !stderrors.Is(Combine(ErrorNotFound(), ErrorNotFound()), ErrorNotFound()) {
+		t.Error("Aggregates of one type should match that type through their members")
+	}
+}
@@ -0,0 +1,75 @@
+package errors
+
+import (
+	"time"
+)
+
+// Conventional field keys set by the metadata helpers and emitted by observability integrations
+const (
+	FieldAttempt          = "attempt"
+	FieldMaxAttempts      = "max_attempts"
+	FieldLatencyMs        = "latency_ms"
+	FieldUpstream         = "upstream"
+	FieldUpstreamEndpoint = "upstream_endpoint"
+	FieldDependency       = "dependency"
+)
+
+// WithAttempt records which attempt failed and how many were allowed, and returns the error for chaining
+func (e *Error) WithAttempt(n, max int) *Error {
+	return e.WithField(FieldAttempt, n).WithField(FieldMaxAttempts, max)
+}
+
+// WithLatency records how long the failed operation took, in milliseconds, and returns the error for chaining
+func (e *Error) WithLatency(d time.Duration) *Error {
+	return e.WithField(FieldLatencyMs, float64(d)/float64(time.Millisecond))
+}
+
+// WithUpstream records the upstream dependency and endpoint that failed, and returns the error for chaining
+func (e *Error) WithUpstream(name, endpoint string) *Error {
+	e.WithField(FieldUpstream, name)
+	if endpoint != "" {
+		e.WithField(FieldUpstreamEndpoint, endpoint)
+	}
+	return e
+}
+
+// WithDependency records the downstream service responsible for the error, e.g. "payments-api",
+// and returns the error for chaining. Stats and errorsmetrics break errors down by dependency.
+func (e *Error) WithDependency(name string) *Error {
+	return e.WithField(FieldDependency, name)
+}
+
+// Dependency returns the downstream service responsible for the error: the one set by
+// WithDependency, otherwise the upstream set by WithUpstream, otherwise ""
+func (e *Error) Dependency() string {
+	if e == nil {
+		return ""
+	}
+
+	s := e.snapshot()
+	if name, ok := s.Fields[FieldDependency].(string); ok {
+		return name
+	}
+	name, _ := s.Fields[FieldUpstream].(string)
+	return name
+}
+
+// Attributes returns a flat key/value view of the error for logs, metrics and traces:
+// "error.type", "error.code" and "error.message" plus every field.
+// The returned map is a copy and may be modified.
+func (e *Error) Attributes() map[string]any {
+	if e == nil {
+		return nil
+	}
+
+	s := e.snapshot()
+	attrs := make(map[string]any, len(s.Fields)+3)
+	for key, value := range s.Fields {
+		attrs[key] = value
+	}
+	attrs["error.type"] = s.Type
+	attrs["error.code"] = s.Code
+	attrs["error.message"] = s.Message
+
+	return attrs
+}
@@ -0,0 +1,49 @@
+package errors
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUpstreamMetadata(t *testing.T) {
+	err := Wrap(ErrorInternalServerError()).
+		WithAttempt(3, 3).
+		WithLatency(1500*time.Millisecond).
+		WithUpstream("payments-api", "POST /v1/charges")
+
+	attrs := err.Attributes()
+	expected := map[string]any{
+		FieldAttempt:          3,
+		FieldMaxAttempts:      3,
+		FieldLatencyMs:        1500.0,
+		FieldUpstream:         "payments-api",
+		FieldUpstreamEndpoint: "POST /v1/charges",
+		"error.type":          "INTERNAL_SERVER_ERROR",
+		"error.code":          int64(500),
+	}
+	for key, want := range expected {
+		if attrs[key] != want {
+			t.Errorf("Attribute %s: expected %v, got %v", key, want, attrs[key])
+		}
+	}
+
+	attrs["extra"] = true
+	if _, ok := err.Fields["extra"]; ok {
+		t.Error("Attributes should return a copy")
+	}
+}
+
+func TestDependency(t *testing.T) {
+	if got := ErrorBadGateway().WithDependency("payments-api").Dependency(); got != "payments-api" {
+		t.Errorf("Expected payments-api, got %q", got)
+	}
+	if got := ErrorBadGateway().WithUpstream("inventory", "").Dependency(); got != "inventory" {
+		t.Errorf("The upstream should be the fallback, got %q", got)
+	}
+	if got := ErrorBadGateway().WithUpstream("inventory", "").WithDependency("payments-api").Dependency(); got != "payments-api" {
+		t.Errorf("WithDependency should take precedence, got %q", got)
+	}
+	if ErrorBadGateway().Dependency() != "" || (*Error)(nil).Dependency() != "" {
+		t.Error("Errors without a dependency should report none")
+	}
+}
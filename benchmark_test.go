@@ -6,6 +6,7 @@ import (
 )
 
 func BenchmarkNew(b *testing.B) {
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		_ = New(404, "Not found", "NOT_FOUND")
@@ -13,6 +14,7 @@ func BenchmarkNew(b *testing.B) {
 }
 
 func BenchmarkWrap(b *testing.B) {
+	b.ReportAllocs()
 	originalErr := fmt.Errorf("original error")
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -21,6 +23,7 @@ func BenchmarkWrap(b *testing.B) {
 }
 
 func BenchmarkValidations(b *testing.B) {
+	b.ReportAllocs()
 	violations := []ValidationError{
 		{Type: ViolationErrorTypeRequired, Field: "email", Message: "Email is required"},
 		{Type: ViolationErrorTypeEmail, Field: "email", Message: "Invalid email format"},
@@ -56,8 +59,32 @@ func BenchmarkCaptureStackTrace(b *testing.B) {
 }
 
 func BenchmarkDefaultError(b *testing.B) {
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		_ = DefaultError()
 	}
 }
+
+func BenchmarkNewStackSampled(b *testing.B) {
+	SetStackSampler(NewStackSampler(100, 0))
+	defer SetStackSampler(nil)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = New(404, "Not found", "NOT_FOUND")
+	}
+}
+
+func BenchmarkAcquire(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Release(Acquire(404, "Not found", "NOT_FOUND"))
+	}
+}
+
+func BenchmarkNewf(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = Newf(404, "User %d not found", "NOT_FOUND", i)
+	}
+}
@@ -0,0 +1,96 @@
+package errors
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// FieldBreadcrumbs is the field key under which WithBreadcrumbs stores the recent breadcrumbs
+const FieldBreadcrumbs = "breadcrumbs"
+
+// DefaultBreadcrumbCapacity is the number of breadcrumbs kept per context unless set otherwise
+const DefaultBreadcrumbCapacity = 32
+
+// Breadcrumb records something that happened before a failure, e.g. "cache miss" or "calling payments"
+type Breadcrumb struct {
+	Time    time.Time      `json:"time"`
+	Message string         `json:"message"`
+	Data    map[string]any `json:"data,omitempty"`
+}
+
+// breadcrumbs is a ring buffer of the most recent breadcrumbs
+type breadcrumbs struct {
+	mu      sync.Mutex
+	entries []Breadcrumb
+	next    int
+	full    bool
+}
+
+type breadcrumbsKey struct{}
+
+// NewBreadcrumbContext returns a context recording the last capacity breadcrumbs added with
+// AddBreadcrumb, typically created once per request by middleware. A capacity below 1 uses
+// DefaultBreadcrumbCapacity.
+func NewBreadcrumbContext(ctx context.Context, capacity int) context.Context {
+	if capacity < 1 {
+		capacity = DefaultBreadcrumbCapacity
+	}
+	return context.WithValue(ctx, breadcrumbsKey{}, &breadcrumbs{entries: make([]Breadcrumb, capacity)})
+}
+
+// AddBreadcrumb records msg with alternating key/value pairs in the context's ring buffer,
+// dropping the oldest breadcrumb when it is full. It does nothing when ctx was not created with
+// NewBreadcrumbContext. It is safe for concurrent use.
+func AddBreadcrumb(ctx context.Context, msg string, kv ...any) {
+	buffer, _ := ctx.Value(breadcrumbsKey{}).(*breadcrumbs)
+	if buffer == nil {
+		return
+	}
+
+	crumb := Breadcrumb{Time: time.Now(), Message: msg}
+	if len(kv) > 0 {
+		crumb.Data = make(map[string]any, (len(kv)+1)/2)
+		for i := 0; i < len(kv); i += 2 {
+			key := fmt.Sprint(kv[i])
+			if i+1 < len(kv) {
+				crumb.Data[key] = kv[i+1]
+			} else {
+				crumb.Data[key] = nil
+			}
+		}
+	}
+
+	buffer.mu.Lock()
+	defer buffer.mu.Unlock()
+	buffer.entries[buffer.next] = crumb
+	buffer.next = (buffer.next + 1) % len(buffer.entries)
+	if buffer.next == 0 {
+		buffer.full = true
+	}
+}
+
+// Breadcrumbs returns the breadcrumbs recorded in ctx, oldest first
+func Breadcrumbs(ctx context.Context) []Breadcrumb {
+	buffer, _ := ctx.Value(breadcrumbsKey{}).(*breadcrumbs)
+	if buffer == nil {
+		return nil
+	}
+
+	buffer.mu.Lock()
+	defer buffer.mu.Unlock()
+	if !buffer.full {
+		return append([]Breadcrumb(nil), buffer.entries[:buffer.next]...)
+	}
+	return append(append([]Breadcrumb(nil), buffer.entries[buffer.next:]...), buffer.entries[:buffer.next]...)
+}
+
+// WithBreadcrumbs attaches the breadcrumbs recorded in ctx under FieldBreadcrumbs and returns
+// the error for chaining. Nothing is attached when ctx has none.
+func (e *Error) WithBreadcrumbs(ctx context.Context) *Error {
+	if crumbs := Breadcrumbs(ctx); len(crumbs) > 0 {
+		e.WithField(FieldBreadcrumbs, crumbs)
+	}
+	return e
+}
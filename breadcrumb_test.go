@@ -0,0 +1,64 @@
+package errors
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestBreadcrumbs(t *testing.T) {
+	ctx := NewBreadcrumbContext(context.Background(), 3)
+	for i := 1; i <= 4; i++ {
+		AddBreadcrumb(ctx, "step "+strconv.Itoa(i), "attempt", i, "dangling")
+	}
+
+	crumbs := Breadcrumbs(ctx)
+	if len(crumbs) != 3 || crumbs[0].Message != "step 2" || crumbs[2].Message != "step 4" {
+		t.Fatalf("Expected the last three breadcrumbs oldest first, got %v", crumbs)
+	}
+	if crumbs[2].Data["attempt"] != 4 || crumbs[2].Data["dangling"] != nil || crumbs[2].Time.IsZero() {
+		t.Errorf("Unexpected breadcrumb %+v", crumbs[2])
+	}
+
+	err := ErrorInternalServerError().WithBreadcrumbs(ctx)
+	if got, _ := err.Fields[FieldBreadcrumbs].([]Breadcrumb); len(got) != 3 {
+		t.Errorf("Expected the breadcrumbs to be attached, got %v", err.Fields)
+	}
+}
+
+func TestBreadcrumbsWithoutContext(t *testing.T) {
+	ctx := context.Background()
+	AddBreadcrumb(ctx, "ignored")
+
+	if Breadcrumbs(ctx) != nil || ErrorNotFound().WithBreadcrumbs(ctx).Fields != nil {
+		t.Error("Contexts without a buffer should record nothing")
+	}
+
+	ctx = NewBreadcrumbContext(ctx, 0)
+	AddBreadcrumb(ctx, "one")
+	if crumbs := Breadcrumbs(ctx); len(crumbs) != 1 || crumbs[0].Data != nil {
+		t.Errorf("Expected a single breadcrumb, got %v", crumbs)
+	}
+}
+
+func TestBreadcrumbsConcurrent(t *testing.T) {
+	ctx := NewBreadcrumbContext(context.Background(), DefaultBreadcrumbCapacity)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				AddBreadcrumb(ctx, "work", "j", j)
+				_ = Breadcrumbs(ctx)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(Breadcrumbs(ctx)) != DefaultBreadcrumbCapacity {
+		t.Errorf("Expected a full buffer, got %d", len(Breadcrumbs(ctx)))
+	}
+}
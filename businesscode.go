@@ -0,0 +1,20 @@
+package errors
+
+// NewBusiness creates an error with a stable application-level business code, e.g. "USR-0042",
+// and the HTTP status it responds with, which becomes both Code and Status. The business code is
+// what clients should match on; the status may change with the transport.
+func NewBusiness(businessCode string, status int, message, errorType string) *Error {
+	return created(&Error{
+		Type:         errorType,
+		Code:         int64(status),
+		BusinessCode: businessCode,
+		Status:       status,
+		Message:      message,
+		StackTraces:  captureStackTrace(1),
+	})
+}
+
+// WithBusinessCode sets the application-level business code and returns the error for chaining
+func (e *Error) WithBusinessCode(code string) *Error {
+	return e.update(func(e *Error) { e.BusinessCode = code })
+}
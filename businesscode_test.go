@@ -0,0 +1,81 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNewBusiness(t *testing.T) {
+	err := NewBusiness("USR-0042", http.StatusConflict, "Username taken", "USERNAME_TAKEN")
+
+	if err.BusinessCode != "USR-0042" || err.Code != 409 || err.HTTPStatus() != 409 {
+		t.Errorf("Unexpected codes %q/%d/%d", err.BusinessCode, err.Code, err.HTTPStatus())
+	}
+	if len(err.StackTraces) == 0 || !strings.Contains(err.StackTraces[0], "TestNewBusiness") {
+		t.Error("Stack trace should start at the caller of NewBusiness")
+	}
+
+	data, _ := json.Marshal(err)
+	if !strings.Contains(string(data), `"code":409,"business_code":"USR-0042"`) {
+		t.Errorf("JSON should carry both codes, got %s", data)
+	}
+}
+
+func TestBusinessCodeSurvivesTransport(t *testing.T) {
+	original := New(10423, "Insufficient funds", "INSUFFICIENT_FUNDS").WithBusinessCode("PAY-0007")
+
+	rec := httptest.NewRecorder()
+	WriteJSON(rec, original)
+	parsed, _ := ParseResponse(rec.Result())
+	if parsed.BusinessCode != "PAY-0007" {
+		t.Errorf("ParseResponse should keep the business code, got %q", parsed.BusinessCode)
+	}
+
+	header := httptest.NewRecorder()
+	WriteJSON(header, original)
+	resp := header.Result()
+	resp.Body = response(502, "<html>Bad Gateway</html>").Body
+	if parsed, _ := ParseResponse(resp); parsed.BusinessCode != "PAY-0007" {
+		t.Errorf("Envelope header should keep the business code, got %q", parsed.BusinessCode)
+	}
+
+	text, _ := original.MarshalText()
+	var decoded Error
+	if err := decoded.UnmarshalText(text); err != nil || decoded.BusinessCode != "PAY-0007" {
+		t.Errorf("MarshalText should keep the business code, got %q (%v)", decoded.BusinessCode, err)
+	}
+
+	if Summarize(original).BusinessCode != "PAY-0007" {
+		t.Error("Summary should carry the business code")
+	}
+}
+
+func TestBusinessCodeAccessor(t *testing.T) {
+	inner := NewBusiness("USR-0042", http.StatusNotFound, "User not found", "USER_NOT_FOUND")
+
+	if got := BusinessCode(fmt.Errorf("loading: %w", Wrap(inner))); got != "USR-0042" {
+		t.Errorf("BusinessCode should look past wrappers without one, got %q", got)
+	}
+	if got := BusinessCode(Wrap(inner).WithBusinessCode("API-0001")); got != "API-0001" {
+		t.Errorf("The outermost business code should win, got %q", got)
+	}
+	if got := BusinessCode(fmt.Errorf("plain")); got != "" {
+		t.Errorf("Plain errors have no business code, got %q", got)
+	}
+	if BusinessCode(nil) != "" {
+		t.Error("BusinessCode(nil) should be empty")
+	}
+}
+
+func TestRegistryBusinessCode(t *testing.T) {
+	r := NewRegistry()
+	r.MustRegister(Definition{Type: "USERNAME_TAKEN", Code: 409, BusinessCode: "USR-0042", Message: "Username taken"})
+
+	if err := r.New("USERNAME_TAKEN"); err.BusinessCode != "USR-0042" {
+		t.Errorf("Definition business code should be used, got %q", err.BusinessCode)
+	}
+}
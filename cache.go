@@ -0,0 +1,57 @@
+package errors
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// CachePolicy decides the Cache-Control header of error responses. Every error response is
+// "no-store" unless the policy says otherwise, so CDNs never cache transient failures or
+// responses that depend on credentials.
+type CachePolicy struct {
+	// NotFoundMaxAge lets caches keep 404 and 410 responses for this long, absorbing storms of
+	// requests for missing resources; 0 disables caching them
+	NotFoundMaxAge time.Duration
+	// Statuses sets the Cache-Control value for specific HTTP statuses, taking precedence
+	Statuses map[int]string
+}
+
+// DefaultCachePolicy caches 404 and 410 responses for a minute
+var DefaultCachePolicy = CachePolicy{NotFoundMaxAge: time.Minute}
+
+var (
+	cacheMu     sync.RWMutex
+	cachePolicy = DefaultCachePolicy
+)
+
+// SetCachePolicy replaces the policy used by CacheControl and the HTTP writers
+func SetCachePolicy(policy CachePolicy) {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	cachePolicy = policy
+}
+
+// CacheControl returns the Cache-Control value for the error response of err under the policy
+// set with SetCachePolicy
+func CacheControl(err *Error) string {
+	cacheMu.RLock()
+	policy := cachePolicy
+	cacheMu.RUnlock()
+
+	return policy.CacheControl(err)
+}
+
+// CacheControl returns the Cache-Control value for the error response of err
+func (p CachePolicy) CacheControl(err *Error) string {
+	status := HTTPStatus(err)
+	if value, ok := p.Statuses[status]; ok {
+		return value
+	}
+
+	if (status == http.StatusNotFound || status == http.StatusGone) && p.NotFoundMaxAge >= time.Second {
+		return "max-age=" + strconv.FormatInt(int64(p.NotFoundMaxAge/time.Second), 10)
+	}
+	return "no-store"
+}
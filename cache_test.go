@@ -0,0 +1,50 @@
+package errors
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCacheControl(t *testing.T) {
+	tests := []struct {
+		err  *Error
+		want string
+	}{
+		{ErrorNotFound(), "max-age=60"},
+		{ErrorGone(), "max-age=60"},
+		{ErrorUnauthorized(), "no-store"},
+		{ErrorForbidden(), "no-store"},
+		{ErrorBadRequest(), "no-store"},
+		{ErrorServiceUnavailable(), "no-store"},
+		{ErrorInternalServerError(), "no-store"},
+	}
+	for _, tt := range tests {
+		if got := CacheControl(tt.err); got != tt.want {
+			t.Errorf("CacheControl(%s) = %q, want %q", tt.err.Type, got, tt.want)
+		}
+	}
+}
+
+func TestSetCachePolicy(t *testing.T) {
+	t.Cleanup(func() { SetCachePolicy(DefaultCachePolicy) })
+
+	SetCachePolicy(CachePolicy{NotFoundMaxAge: 5 * time.Second, Statuses: map[int]string{410: "max-age=86400", 429: "max-age=1"}})
+
+	rec := httptest.NewRecorder()
+	WriteJSON(rec, ErrorNotFound())
+	if got := rec.Header().Get("Cache-Control"); got != "max-age=5" {
+		t.Errorf("Expected the configured max-age, got %q", got)
+	}
+	if got := CacheControl(ErrorGone()); got != "max-age=86400" {
+		t.Errorf("Statuses should take precedence, got %q", got)
+	}
+	if got := CacheControl(ErrorTooManyRequests()); got != "max-age=1" {
+		t.Errorf("Expected the status override, got %q", got)
+	}
+
+	SetCachePolicy(CachePolicy{})
+	if got := CacheControl(ErrorNotFound()); got != "no-store" {
+		t.Errorf("A zero max-age should disable caching, got %q", got)
+	}
+}
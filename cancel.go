@@ -0,0 +1,40 @@
+package errors
+
+import (
+	"context"
+	stderrors "errors"
+)
+
+// Canceled returns a CANCELED error (code 499) for work aborted on purpose, e.g. by the user or
+// during shutdown, rather than failing. reason becomes the message. The error matches
+// context.Canceled with errors.Is. Canceled errors are counted apart from failures by Stats and
+// are skipped by errorsmetrics and SkipCanceled reporters, so they do not inflate error rates.
+func Canceled(reason string) *Error {
+	if reason == "" {
+		reason = "Canceled"
+	}
+	return created(&Error{
+		Type:        "CANCELED",
+		Code:        499,
+		Message:     reason,
+		StackTraces: captureStackTrace(1),
+		Err:         context.Canceled,
+	})
+}
+
+// IsCanceled reports whether err's chain contains a CANCELED or CLIENT_CLOSED_REQUEST error, or
+// context.Canceled
+func IsCanceled(err error) bool {
+	return stderrors.Is(err, context.Canceled) ||
+		matchAny(err, func(e *Error) bool { return e.Type == "CANCELED" || e.Type == "CLIENT_CLOSED_REQUEST" })
+}
+
+// SkipCanceled returns a Reporter passing errors other than cancellations (see IsCanceled) to
+// reporter, for alerting sinks
+func SkipCanceled(reporter Reporter) Reporter {
+	return ReporterFunc(func(ctx context.Context, err *Error) {
+		if !IsCanceled(err) {
+			reporter.Report(ctx, err)
+		}
+	})
+}
@@ -0,0 +1,72 @@
+package errors
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestCanceled(t *testing.T) {
+	e := Canceled("upload aborted by user")
+	if e.Type != "CANCELED" || e.Code != 499 || e.Message != "upload aborted by user" || HTTPStatus(e) != 499 {
+		t.Errorf("Unexpected error %+v", e)
+	}
+	if !stderrors.Is(e, context.Canceled) {
+		t.Error("Canceled errors should match context.Canceled")
+	}
+	if Canceled("").Message != "Canceled" {
+		t.Error("An empty reason should use the default message")
+	}
+	if e.LogSeverity() != SeverityInfo {
+		t.Errorf("Cancellations should be informational, got %v", e.LogSeverity())
+	}
+}
+
+func TestIsCanceled(t *testing.T) {
+	cases := []struct {
+		err  error
+		want bool
+	}{
+		{Canceled("shutdown"), true},
+		{Wrap(context.Canceled), true},
+		{fmt.Errorf("query: %w", context.Canceled), true},
+		{WrapOp("orders.List", Canceled("shutdown")), true},
+		{ErrorGatewayTimeout(), false},
+		{Wrap(context.DeadlineExceeded), false},
+		{nil, false},
+	}
+	for _, c := range cases {
+		if got := IsCanceled(c.err); got != c.want {
+			t.Errorf("IsCanceled(%v) = %v, want %v", c.err, got, c.want)
+		}
+	}
+}
+
+func TestSkipCanceled(t *testing.T) {
+	var reported []string
+	reporter := SkipCanceled(ReporterFunc(func(ctx context.Context, err *Error) {
+		reported = append(reported, err.Type)
+	}))
+
+	reporter.Report(context.Background(), Canceled("shutdown"))
+	reporter.Report(context.Background(), Wrap(context.Canceled))
+	reporter.Report(context.Background(), ErrorBadGateway())
+
+	if len(reported) != 1 || reported[0] != "BAD_GATEWAY" {
+		t.Errorf("Only failures should be reported, got %v", reported)
+	}
+}
+
+func TestStatsCanceled(t *testing.T) {
+	withStatsClock(t, time.Minute)
+
+	Record(Canceled("shutdown"))
+	Record(Wrap(context.Canceled).WithDependency("payments-api"))
+	Record(ErrorBadGateway())
+
+	if stats := Stats(); stats.Occurrences != 1 || stats.Canceled != 2 || stats.DistinctFingerprints != 1 || len(stats.ByDependency) != 0 {
+		t.Errorf("Cancellations should be counted apart from failures, got %+v", stats)
+	}
+}
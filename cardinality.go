@@ -0,0 +1,74 @@
+package errors
+
+import (
+	"hash/fnv"
+	"math"
+	"math/bits"
+)
+
+// sketchPrecision is the number of hash bits selecting a register: 2^12 registers estimate
+// cardinalities with a standard error of about 1.6% in 4KB
+const sketchPrecision = 12
+
+// sketch is a HyperLogLog cardinality estimator. Adding the same value twice does not change
+// the estimate, so sampled or repeated occurrences of a fingerprint are counted once.
+type sketch struct {
+	registers [1 << sketchPrecision]uint8
+}
+
+// add records value in the sketch
+func (s *sketch) add(value string) {
+	h := fnv.New64a()
+	h.Write([]byte(value))
+	hash := mix64(h.Sum64())
+
+	index := hash >> (64 - sketchPrecision)
+	rank := uint8(bits.LeadingZeros64(hash<<sketchPrecision|1<<(sketchPrecision-1)) + 1)
+	if rank > s.registers[index] {
+		s.registers[index] = rank
+	}
+}
+
+// merge folds other into s so s estimates the union of both
+func (s *sketch) merge(other *sketch) {
+	for i, rank := range other.registers {
+		if rank > s.registers[i] {
+			s.registers[i] = rank
+		}
+	}
+}
+
+// reset empties the sketch
+func (s *sketch) reset() {
+	s.registers = [1 << sketchPrecision]uint8{}
+}
+
+// estimate returns the approximate number of distinct values added
+func (s *sketch) estimate() uint64 {
+	const m = float64(len(s.registers))
+
+	sum, zeros := 0.0, 0
+	for _, rank := range s.registers {
+		sum += math.Ldexp(1, -int(rank))
+		if rank == 0 {
+			zeros++
+		}
+	}
+
+	estimate := 0.7213 / (1 + 1.079/m) * m * m / sum
+	// Linear counting is more accurate for small cardinalities
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return uint64(math.Round(estimate))
+}
+
+// mix64 spreads the bits of h so that similar fingerprints land in different registers
+func mix64(h uint64) uint64 {
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
@@ -0,0 +1,67 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// CatalogDecoder decodes a catalog document from reader into v.
+// Decoders should reject unknown keys so typos in reviewed catalog files fail at startup.
+type CatalogDecoder func(reader io.Reader, v any) error
+
+// catalogFile is the document layout of an error catalog file
+type catalogFile struct {
+	Errors []Definition `json:"errors" yaml:"errors"`
+}
+
+// DecodeCatalogJSON is the CatalogDecoder for JSON catalog files
+func DecodeCatalogJSON(reader io.Reader, v any) error {
+	decoder := json.NewDecoder(reader)
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(v)
+}
+
+// LoadRegistry reads a JSON error catalog file and returns a registry holding its definitions.
+// Other formats are loaded with LoadRegistryWith, e.g. YAML through the errorsyaml subpackage.
+func LoadRegistry(path string) (*Registry, error) {
+	return LoadRegistryWith(path, DecodeCatalogJSON)
+}
+
+// LoadRegistryWith reads an error catalog file with decode and returns a registry holding its definitions
+func LoadRegistryWith(path string, decode CatalogDecoder) (*Registry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := NewRegistry()
+	if err := r.Load(f, decode); err != nil {
+		return nil, fmt.Errorf("errors: loading catalog %s: %w", path, err)
+	}
+
+	return r, nil
+}
+
+// Load parses a catalog document with decode and registers every definition in it.
+// A nil decode reads JSON.
+func (r *Registry) Load(reader io.Reader, decode CatalogDecoder) error {
+	if decode == nil {
+		decode = DecodeCatalogJSON
+	}
+
+	var file catalogFile
+	if err := decode(reader, &file); err != nil {
+		return err
+	}
+
+	for i, def := range file.Errors {
+		if err := r.Register(def); err != nil {
+			return fmt.Errorf("entry %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
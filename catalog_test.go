@@ -0,0 +1,77 @@
+package errors
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testCatalogJSON = `{
+  "errors": [
+    {
+      "type": "USER_NOT_FOUND",
+      "code": 404,
+      "message": "User {id} not found",
+      "grpc_code": 5,
+      "messages": {"id": "Pengguna {id} tidak ditemukan"}
+    },
+    {
+      "type": "PAYMENT_GATEWAY_UNAVAILABLE",
+      "code": 503,
+      "message": "Payment gateway unavailable",
+      "retryable": true
+    }
+  ]
+}`
+
+func TestLoadRegistry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errors.json")
+	if err := os.WriteFile(path, []byte(testCatalogJSON), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := LoadRegistry(path)
+	if err != nil {
+		t.Fatalf("LoadRegistry returned error: %v", err)
+	}
+
+	def, ok := r.Lookup("PAYMENT_GATEWAY_UNAVAILABLE")
+	if !ok || !def.Retryable || def.HTTPStatus != 503 {
+		t.Errorf("Unexpected definition %+v", def)
+	}
+
+	userNotFound := r.MustConstructor("USER_NOT_FOUND")
+	if got := userNotFound(map[string]any{"id": 42}).Message; got != "User 42 not found" {
+		t.Errorf("Unexpected message %q", got)
+	}
+
+	localized := r.NewLocalized("USER_NOT_FOUND", "id", map[string]any{"id": 42})
+	if localized.Message != "Pengguna 42 tidak ditemukan" {
+		t.Errorf("Unexpected localized message %q", localized.Message)
+	}
+	if fallback := r.NewLocalized("USER_NOT_FOUND", "fr", nil); fallback.Message != "User {id} not found" {
+		t.Errorf("Unknown locale should fall back to the default message, got %q", fallback.Message)
+	}
+}
+
+func TestRegistryLoadJSON(t *testing.T) {
+	r := NewRegistry()
+	err := r.Load(strings.NewReader(`{"errors":[{"type":"CONFLICT","code":409,"message":"Conflict"}]}`), nil)
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if _, ok := r.Lookup("CONFLICT"); !ok {
+		t.Error("Definition should be registered")
+	}
+
+	if err := NewRegistry().Load(strings.NewReader(`{"errors":[{"type":"X","cod":1}]}`), DecodeCatalogJSON); err == nil {
+		t.Error("Unknown keys should be rejected")
+	}
+	if _, err := r.Constructor("MISSING"); err == nil {
+		t.Error("Constructor should fail for an unregistered type")
+	}
+	if _, err := LoadRegistry(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("Missing files should be reported")
+	}
+}
@@ -0,0 +1,42 @@
+package errors
+
+import "strings"
+
+// WithCause attaches err as the cause and returns the error for chaining. Unlike Wrap, which
+// classifies the cause as a 500, the error keeps its own type, code and message, so a domain
+// error can record the failure behind it. As with WrapWith, Error() then returns the cause's
+// text while Message stays what clients see.
+func (e *Error) WithCause(err error) *Error {
+	return e.update(func(e *Error) { e.Err = err })
+}
+
+// CauseChain renders the error's message followed by each cause down to the root, e.g.
+// "Payment declined: charging card: card expired". *Error causes contribute their Message,
+// other wrappers only their own prefix, and repeated messages are listed once.
+func (e *Error) CauseChain() string {
+	if e == nil {
+		return ""
+	}
+
+	path := causePath(e)
+	parts := make([]string, 0, len(path))
+	seen := make(map[string]bool, len(path))
+	for i, err := range path {
+		var part string
+		if appErr, ok := err.(*Error); ok {
+			part = appErr.snapshot().Message
+		} else {
+			part = err.Error()
+			// A wrapper such as fmt.Errorf("charging card: %w", err) repeats the cause's text
+			if i+1 < len(path) {
+				part = strings.TrimSuffix(strings.TrimSuffix(part, path[i+1].Error()), ": ")
+			}
+		}
+
+		if part != "" && !seen[part] {
+			seen[part] = true
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ": ")
+}
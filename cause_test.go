@@ -0,0 +1,45 @@
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+	"testing"
+)
+
+func TestWithCause(t *testing.T) {
+	root := stderrors.New("card expired")
+	err := New(402, "Payment declined", "PAYMENT_DECLINED").WithCause(fmt.Errorf("charging card: %w", root))
+
+	if err.Type != "PAYMENT_DECLINED" || err.Code != 402 || err.Message != "Payment declined" {
+		t.Errorf("WithCause should keep the classification, got %s/%d/%s", err.Type, err.Code, err.Message)
+	}
+	if !stderrors.Is(err, root) {
+		t.Error("The cause should be reachable with errors.Is")
+	}
+	if got := err.CauseChain(); got != "Payment declined: charging card: card expired" {
+		t.Errorf("CauseChain = %q", got)
+	}
+}
+
+func TestCauseChain(t *testing.T) {
+	inner := New(404, "User not found", "NOT_FOUND").WithCause(stderrors.New("sql: no rows in result set"))
+	outer := WrapWith(fmt.Errorf("loading profile: %w", inner), 404, "NOT_FOUND", "User not found")
+
+	if got := outer.CauseChain(); got != "User not found: loading profile: sql: no rows in result set" {
+		t.Errorf("CauseChain = %q", got)
+	}
+	if got := New(500, "Boom", "INTERNAL").CauseChain(); got != "Boom" {
+		t.Errorf("CauseChain without a cause = %q", got)
+	}
+
+	// A wrapper whose text does not end with its cause's is kept whole
+	custom := New(502, "Upstream failed", "BAD_GATEWAY").WithCause(fmt.Errorf("%w (after 3 attempts)", stderrors.New("timeout")))
+	if got := custom.CauseChain(); got != "Upstream failed: timeout (after 3 attempts): timeout" {
+		t.Errorf("CauseChain = %q", got)
+	}
+
+	var nilErr *Error
+	if nilErr.CauseChain() != "" {
+		t.Error("CauseChain of nil should be empty")
+	}
+}
@@ -0,0 +1,74 @@
+package errors
+
+// maxChainDepth bounds chain walks so that a cyclic Unwrap cannot loop forever
+const maxChainDepth = 100
+
+// Chain returns err followed by every error reachable through Unwrap, in depth-first order.
+// Errors with an Unwrap() []error method (e.g. errors.Join or Combine) contribute each of
+// their children in turn. Intermediate *Error values are included; nil errors are skipped.
+func Chain(err error) []error {
+	chain := make([]error, 0)
+
+	var walk func(err error, depth int)
+	walk = func(err error, depth int) {
+		if isNil(err) || depth >= maxChainDepth {
+			return
+		}
+		chain = append(chain, err)
+
+		switch u := err.(type) {
+		case interface{ Unwrap() []error }:
+			for _, inner := range u.Unwrap() {
+				walk(inner, depth+1)
+			}
+		case interface{ Unwrap() error }:
+			walk(u.Unwrap(), depth+1)
+		}
+	}
+	walk(err, 0)
+
+	return chain
+}
+
+// RootCause returns the deepest error in err's chain. For errors wrapping several errors it
+// follows the first non-nil one. It returns err itself when nothing is wrapped and nil for nil.
+func RootCause(err error) error {
+	path := causePath(err)
+	if len(path) == 0 {
+		return nil
+	}
+	return path[len(path)-1]
+}
+
+// causePath returns err followed by the errors RootCause passes through on its way down
+func causePath(err error) []error {
+	path := make([]error, 0)
+	for depth := 0; depth < maxChainDepth && !isNil(err); depth++ {
+		path = append(path, err)
+
+		var next error
+		switch u := err.(type) {
+		case interface{ Unwrap() []error }:
+			for _, inner := range u.Unwrap() {
+				if !isNil(inner) {
+					next = inner
+					break
+				}
+			}
+		case interface{ Unwrap() error }:
+			next = u.Unwrap()
+		}
+		err = next
+	}
+
+	return path
+}
+
+// isNil reports whether err is nil or a nil *Error
+func isNil(err error) bool {
+	if err == nil {
+		return true
+	}
+	e, ok := err.(*Error)
+	return ok && e == nil
+}
@@ -0,0 +1,52 @@
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+	"testing"
+)
+
+func TestChain(t *testing.T) {
+	root := stderrors.New("connection reset")
+	db := Wrap(fmt.Errorf("query users: %w", root))
+	err := fmt.Errorf("load profile: %w", db)
+
+	chain := Chain(err)
+	if len(chain) != 4 {
+		t.Fatalf("Expected 4 errors in chain, got %d", len(chain))
+	}
+	if chain[0] != err || chain[1] != db || chain[3] != root {
+		t.Errorf("Unexpected chain order %v", chain)
+	}
+
+	if RootCause(err) != root {
+		t.Errorf("Expected root cause %v, got %v", root, RootCause(err))
+	}
+}
+
+func TestChainMultiUnwrap(t *testing.T) {
+	first := stderrors.New("first")
+	second := stderrors.New("second")
+	err := Combine(Wrap(first), second)
+
+	chain := Chain(err)
+	// aggregate, joined error, wrapped first, first, second
+	if len(chain) != 5 || chain[3] != first || chain[4] != second {
+		t.Errorf("Unexpected chain %v", chain)
+	}
+	if RootCause(err) != first {
+		t.Errorf("Expected first branch root cause, got %v", RootCause(err))
+	}
+}
+
+func TestRootCauseNil(t *testing.T) {
+	var e *Error
+	if RootCause(nil) != nil || RootCause(e) != nil || len(Chain(e)) != 0 {
+		t.Error("Nil errors should have no root cause or chain")
+	}
+
+	plain := stderrors.New("plain")
+	if RootCause(plain) != plain {
+		t.Error("An unwrapped error is its own root cause")
+	}
+}
@@ -0,0 +1,92 @@
+package errors
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/base64"
+	"encoding/json"
+	stderrors "errors"
+	"io"
+)
+
+// maxCompactSize limits the decompressed size of a compact token
+const maxCompactSize = 64 << 10
+
+// compactEnvelope is the essential error envelope carried by compact tokens.
+// Short keys keep the token small enough for a header or query parameter.
+type compactEnvelope struct {
+	Type         string            `json:"t"`
+	Code         int64             `json:"c"`
+	BusinessCode string            `json:"b,omitempty"`
+	Message      string            `json:"m"`
+	Violations   []ValidationError `json:"v,omitempty"`
+}
+
+// EncodeCompact encodes the essential envelope (type, code, business code, message and violations) of err
+// into a URL-safe base64 deflate token. Errors that are not *Error are encoded as DefaultError().
+// Stack traces and wrapped errors are never included. A nil error, typed nil *Error included,
+// encodes to an empty string.
+func EncodeCompact(err error) string {
+	if isNil(err) {
+		return ""
+	}
+
+	var e *Error
+	if !stderrors.As(err, &e) || e == nil {
+		e = DefaultError()
+	}
+	s := e.snapshot()
+
+	payload, marshalErr := json.Marshal(compactEnvelope{
+		Type:         s.Type,
+		Code:         s.Code,
+		BusinessCode: s.BusinessCode,
+		Message:      s.Message,
+		Violations:   s.Violations,
+	})
+	if marshalErr != nil {
+		return ""
+	}
+
+	var buf bytes.Buffer
+	w, _ := flate.NewWriter(&buf, flate.BestCompression)
+	_, _ = w.Write(payload)
+	_ = w.Close()
+
+	return base64.RawURLEncoding.EncodeToString(buf.Bytes())
+}
+
+// DecodeCompact decodes a token produced by EncodeCompact back into an *Error.
+// The decoded error carries no stack traces.
+func DecodeCompact(token string) (*Error, error) {
+	compressed, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+
+	r := flate.NewReader(bytes.NewReader(compressed))
+	defer r.Close()
+
+	payload, err := io.ReadAll(io.LimitReader(r, maxCompactSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(payload) > maxCompactSize {
+		return nil, stderrors.New("errors: compact token exceeds maximum size")
+	}
+
+	var envelope compactEnvelope
+	if err := json.Unmarshal(payload, &envelope); err != nil {
+		return nil, err
+	}
+
+	e := &Error{
+		Type:         envelope.Type,
+		Code:         envelope.Code,
+		BusinessCode: envelope.BusinessCode,
+		Message:      envelope.Message,
+		Violations:   envelope.Violations,
+	}
+
+	return e, nil
+}
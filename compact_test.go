@@ -0,0 +1,70 @@
+package errors
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+)
+
+func TestCompactRoundTrip(t *testing.T) {
+	original := Violations([]ValidationError{
+		{Type: ViolationErrorTypeRequired, Field: "email", Message: "Email is required"},
+	})
+
+	token := EncodeCompact(fmt.Errorf("callback: %w", original))
+	if token == "" {
+		t.Fatal("EncodeCompact should return a token")
+	}
+	if url.QueryEscape(token) != token {
+		t.Errorf("Token should be safe for query parameters: %s", token)
+	}
+
+	decoded, err := DecodeCompact(token)
+	if err != nil {
+		t.Fatalf("DecodeCompact returned error: %v", err)
+	}
+	if decoded.Type != original.Type || decoded.Code != original.Code || decoded.Message != original.Message {
+		t.Errorf("Decoded envelope mismatch: %+v", decoded)
+	}
+	if len(decoded.Violations) != 1 || decoded.Violations[0].Field != "email" {
+		t.Errorf("Decoded violations mismatch: %+v", decoded.Violations)
+	}
+	if len(decoded.StackTraces) != 0 {
+		t.Error("Decoded error should not carry stack traces")
+	}
+}
+
+func TestCompactPlainError(t *testing.T) {
+	decoded, err := DecodeCompact(EncodeCompact(fmt.Errorf("select * from users")))
+	if err != nil {
+		t.Fatalf("DecodeCompact returned error: %v", err)
+	}
+	if decoded.Code != 500 || decoded.Message != "An internal server error occurred" {
+		t.Errorf("Plain errors should encode as the default error, got %+v", decoded)
+	}
+
+	if EncodeCompact(nil) != "" {
+		t.Error("EncodeCompact(nil) should return an empty string")
+	}
+}
+
+func TestDecodeCompactInvalid(t *testing.T) {
+	if _, err := DecodeCompact("not a token!"); err == nil {
+		t.Error("DecodeCompact should fail on invalid base64")
+	}
+	if _, err := DecodeCompact("AAAA"); err == nil {
+		t.Error("DecodeCompact should fail on invalid payload")
+	}
+}
+
+func TestCompactTypedNil(t *testing.T) {
+	var typedNil *Error
+	if token := EncodeCompact(typedNil); token != "" {
+		t.Errorf("A typed nil should encode to an empty string, got %q", token)
+	}
+
+	decoded, err := DecodeCompact(EncodeCompact(fmt.Errorf("wrapped: %w", typedNil)))
+	if err != nil || decoded.Type != "INTERNAL_SERVER_ERROR" {
+		t.Errorf("A typed nil in the chain should encode as DefaultError, got %v (%v)", decoded, err)
+	}
+}
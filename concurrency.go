@@ -0,0 +1,74 @@
+package errors
+
+import (
+	"encoding/json"
+	"sync"
+	"unsafe"
+)
+
+// errorLocks guards the errors' mutable state. Errors are striped across the locks by address,
+// so Error stays a plain copyable struct. At most one of these locks is held at a time.
+var errorLocks [64]sync.RWMutex
+
+// lock returns the lock guarding e
+func (e *Error) lock() *sync.RWMutex {
+	return &errorLocks[(uintptr(unsafe.Pointer(e))>>4)%uintptr(len(errorLocks))]
+}
+
+// snapshot returns a shallow copy of the error taken under its read lock. Because the With
+// methods replace Fields instead of writing to it and only append to slices, the maps and
+// slices of the copy can be read without holding the lock.
+func (e *Error) snapshot() Error {
+	mu := e.lock()
+	mu.RLock()
+	defer mu.RUnlock()
+	return *e
+}
+
+// cause returns the wrapped error under the error's read lock, without copying the rest of it
+func (e *Error) cause() error {
+	mu := e.lock()
+	mu.RLock()
+	defer mu.RUnlock()
+	return e.Err
+}
+
+// update runs fn under the error's write lock and returns the error for chaining
+func (e *Error) update(fn func(e *Error)) *Error {
+	mu := e.lock()
+	mu.Lock()
+	defer mu.Unlock()
+	fn(e)
+	return e
+}
+
+// MarshalJSON encodes the error as json.Marshal would encode its fields, from a consistent
+// snapshot so it may run concurrently with the With methods. The stack trace is rendered in the
+// format set with SetStackFormat.
+func (e *Error) MarshalJSON() ([]byte, error) {
+	if e == nil {
+		return []byte("null"), nil
+	}
+
+	s := e.snapshot()
+	format := StackFormat(jsonStackFormat.Load())
+	if format == StackLines {
+		return json.Marshal(jsonForm(s))
+	}
+	return json.Marshal(struct {
+		*errorJSON
+		StackTraces any `json:"stack_traces"`
+	}{jsonForm(s), stackValue(s.StackTraces, format)})
+}
+
+// jsonForm returns the JSON form of the snapshot s. Violations and stack traces are allocated
+// only when needed, so absent ones are encoded as empty arrays, as they always were.
+func jsonForm(s Error) *errorJSON {
+	if s.Violations == nil {
+		s.Violations = []ValidationError{}
+	}
+	if s.StackTraces == nil {
+		s.StackTraces = []string{}
+	}
+	return (*errorJSON)(&s)
+}
@@ -0,0 +1,169 @@
+package errors
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestConcurrentEnrichment runs the With methods against logging, formatting and encoding of
+// the same error; run with -race to check the contract
+func TestConcurrentEnrichment(t *testing.T) {
+	err := Wrap(fmt.Errorf("connection reset"))
+	logger := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				err.WithField("key_"+strconv.Itoa(i), j).
+					WithAttempt(j, 50).
+					WithMessagePrefix("worker").
+					WithRetryAfter(time.Duration(j) * time.Millisecond).
+					WithSeverity(SeverityWarning).
+					WithOp("worker." + strconv.Itoa(i))
+				err.WithInternalMessage("attempt "+strconv.Itoa(j)).WithSampling(true, 0.5)
+				err.EnsureID()
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				Log(t.Context(), logger, err)
+				_ = fmt.Sprintf("%+v", err)
+				_, _ = json.Marshal(err)
+				_ = err.Attributes()
+				_ = err.Public()
+				_ = err.Clone()
+				_ = err.Fingerprint()
+				_ = err.OriginalMessage()
+				_ = err.Temporary()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(err.Fields) != 8+2 || len(err.MessageHistory) != 8*50 {
+		t.Errorf("Every update should be applied, got %d fields and %d messages", len(err.Fields), len(err.MessageHistory))
+	}
+}
+
+func TestWithFieldCopyOnWrite(t *testing.T) {
+	err := ErrorBadRequest().WithField("user_id", 1)
+	fields := err.Fields
+
+	err.WithField("user_id", 2).WithField("order_id", 3)
+	if fields["user_id"] != 1 || len(fields) != 1 {
+		t.Errorf("Maps read earlier should not change, got %v", fields)
+	}
+	if err.Fields["user_id"] != 2 || err.Fields["order_id"] != 3 {
+		t.Errorf("Unexpected fields %v", err.Fields)
+	}
+}
+
+func TestEnsureIDConcurrent(t *testing.T) {
+	err := ErrorNotFound()
+
+	ids := make([]string, 16)
+	var wg sync.WaitGroup
+	for i := range ids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ids[i] = err.EnsureID()
+		}()
+	}
+	wg.Wait()
+
+	for _, id := range ids {
+		if id != err.ID {
+			t.Fatalf("Every caller should get the same ID, got %q and %q", id, err.ID)
+		}
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	err := ErrorConflict().WithField("order_id", 7)
+
+	got, marshalErr := json.Marshal(err)
+	if marshalErr != nil {
+		t.Fatal(marshalErr)
+	}
+	// Violations are allocated lazily but still encoded as an empty array
+	s := *err
+	s.Violations = []ValidationError{}
+	want, _ := json.Marshal((*errorJSON)(&s))
+	if string(got) != string(want) {
+		t.Errorf("MarshalJSON should match the default encoding, got %s want %s", got, want)
+	}
+
+	if got, _ := json.Marshal((*Error)(nil)); string(got) != "null" {
+		t.Errorf("nil should encode as null, got %s", got)
+	}
+}
+
+func TestConcurrentCause(t *testing.T) {
+	err := ErrorNotFound()
+	cause := fmt.Errorf("row missing")
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for range 50 {
+			err.WithCause(cause)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for range 50 {
+			_ = stderrors.Is(err, ErrNotFound)
+			_ = stderrors.Unwrap(err)
+			_ = err.Errors()
+		}
+	}()
+	wg.Wait()
+
+	if !stderrors.Is(err, cause) {
+		t.Error("The cause should be reachable")
+	}
+}
+
+func TestConcurrentReaders(t *testing.T) {
+	err := WrapOp("store.FindUser", ErrorServiceUnavailable())
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for j := range 50 {
+			err.WithOp("store.FindUser").WithRetryAfter(time.Duration(j) * time.Second)
+			err.AddViolation(ValidationError{Type: ViolationErrorTypeRequired, Field: "id", Message: "ID is required"})
+			_ = err.UnmarshalJSON([]byte(`{"fields":{"attempt":1}}`))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for range 50 {
+			_ = err.OpTrace()
+			_ = ResponseHeaders(err)
+			_ = HTTPStatus(err)
+			_ = err.Clone()
+			_ = Combine(err, ErrorNotFound())
+			_ = Translate(err, "id")
+		}
+	}()
+	wg.Wait()
+
+	if err.Fields["attempt"] != float64(1) || HTTPStatus(err) != 503 {
+		t.Errorf("Unexpected error after concurrent updates: %v %d", err.Fields, HTTPStatus(err))
+	}
+}
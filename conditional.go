@@ -0,0 +1,35 @@
+package errors
+
+// NewIf is like New when cond holds and returns nil otherwise, e.g.
+// return errors.NewIf(balance < amount, 10423, "Insufficient funds", "INSUFFICIENT_FUNDS")
+func NewIf(cond bool, code int64, message, errorType string) *Error {
+	if !cond {
+		return nil
+	}
+	return created(&Error{
+		Type:        errorType,
+		Code:        code,
+		Message:     message,
+		StackTraces: captureStackTrace(1),
+	})
+}
+
+// WrapIf is like Wrap when cond holds and returns nil otherwise, e.g. to pass expected errors
+// through: errors.WrapIf(!stderrors.Is(err, io.EOF), err). It returns nil for a nil error.
+func WrapIf(cond bool, err error) *Error {
+	if !cond || isNil(err) {
+		return nil
+	}
+	return createdFrom(err, wrapError(err, err, 1))
+}
+
+// FirstError returns the first non-nil error of errs, or nil when all are nil. Typed nils, e.g.
+// a nil *Error stored in an error, count as nil.
+func FirstError(errs ...error) error {
+	for _, err := range errs {
+		if !isNil(err) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package errors
+
+import (
+	stderrors "errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestWrapNil(t *testing.T) {
+	var typedNil *Error
+	if Wrap(nil) != nil || Wrap(typedNil) != nil || DefaultFactory.Wrap(nil) != nil {
+		t.Error("Wrapping nil should return nil")
+	}
+}
+
+func TestNewIf(t *testing.T) {
+	if NewIf(false, 10423, "Insufficient funds", "INSUFFICIENT_FUNDS") != nil {
+		t.Error("NewIf should return nil when the condition does not hold")
+	}
+
+	e := NewIf(true, 10423, "Insufficient funds", "INSUFFICIENT_FUNDS")
+	if e == nil || e.Code != 10423 || !strings.Contains(e.StackTraces[0], "TestNewIf") {
+		t.Errorf("NewIf should create the error at the caller, got %+v", e)
+	}
+}
+
+func TestWrapIf(t *testing.T) {
+	if WrapIf(false, io.ErrUnexpectedEOF) != nil || WrapIf(true, nil) != nil {
+		t.Error("WrapIf should return nil when the condition does not hold or err is nil")
+	}
+
+	e := WrapIf(!stderrors.Is(io.ErrUnexpectedEOF, io.EOF), io.ErrUnexpectedEOF)
+	if e == nil || e.Type != "INTERNAL_SERVER_ERROR" || !stderrors.Is(e, io.ErrUnexpectedEOF) || !strings.Contains(e.StackTraces[0], "TestWrapIf") {
+		t.Errorf("WrapIf should wrap like Wrap at the caller, got %+v", e)
+	}
+}
+
+func TestFirstError(t *testing.T) {
+	var typedNil *Error
+	first, second := stderrors.New("first"), stderrors.New("second")
+
+	if got := FirstError(nil, typedNil, first, second); got != first {
+		t.Errorf("FirstError = %v, want the first non-nil error", got)
+	}
+	if FirstError() != nil || FirstError(nil, typedNil) != nil {
+		t.Error("FirstError should return nil when every error is nil")
+	}
+}
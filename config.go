@@ -0,0 +1,123 @@
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ConfigError reports an invalid configuration setting. field is a dotted path whose first
+// segment is the section, e.g. "database.url"; the environment variable name is derived
+// from it ("DATABASE_URL").
+func ConfigError(field, reason string) *Error {
+	e := newConfigError(field, envName(field), reason)
+	e.StackTraces = captureStackTrace(1)
+	return created(e)
+}
+
+// ConfigEnvError is like ConfigError with an explicit environment variable name
+func ConfigEnvError(field, env, reason string) *Error {
+	e := newConfigError(field, env, reason)
+	e.StackTraces = captureStackTrace(1)
+	return created(e)
+}
+
+// InvalidConfiguration combines configuration errors into one INVALID_CONFIGURATION error,
+// or returns nil when every error is nil
+func InvalidConfiguration(errs ...error) *Error {
+	e := Combine(errs...)
+	if e == nil {
+		return nil
+	}
+
+	e.Type = "INVALID_CONFIGURATION"
+	e.Code = 500
+	e.Message = "Invalid configuration"
+	return e
+}
+
+// RenderConfigReport renders the configuration problems in err as one block grouped by section,
+// listing each setting with its environment variable. Errors that are not configuration errors
+// are listed under "general".
+func RenderConfigReport(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	type problem struct {
+		field, env, reason string
+	}
+	sections := make(map[string][]problem)
+	count := 0
+
+	var collect func(err error)
+	collect = func(err error) {
+		var e *Error
+		if !stderrors.As(err, &e) || e.Type != "INVALID_CONFIGURATION" {
+			sections["general"] = append(sections["general"], problem{reason: err.Error()})
+			count++
+			return
+		}
+
+		if e.isAggregate() {
+			for _, inner := range e.Errors() {
+				collect(inner)
+			}
+			return
+		}
+
+		env, _ := e.Fields["env"].(string)
+		for _, v := range e.Violations {
+			section, _, _ := strings.Cut(v.Field, ".")
+			sections[section] = append(sections[section], problem{field: v.Field, env: env, reason: v.Message})
+			count++
+		}
+	}
+	collect(err)
+
+	names := make([]string, 0, len(sections))
+	for name := range sections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "invalid configuration: %d problem(s)\n", count)
+	for _, name := range names {
+		fmt.Fprintf(&b, "\n[%s]\n", name)
+		for _, p := range sections[name] {
+			switch {
+			case p.field == "":
+				fmt.Fprintf(&b, "  %s\n", p.reason)
+			case p.env == "":
+				fmt.Fprintf(&b, "  %s: %s\n", p.field, p.reason)
+			default:
+				fmt.Fprintf(&b, "  %s (%s): %s\n", p.field, p.env, p.reason)
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// newConfigError builds a configuration error without a stack trace
+func newConfigError(field, env, reason string) *Error {
+	e := &Error{
+		Type:    "INVALID_CONFIGURATION",
+		Code:    500,
+		Message: reason,
+		Violations: []ValidationError{
+			{Type: ViolationErrorTypeConfig, Field: field, Message: reason},
+		},
+	}
+	if env != "" {
+		e.WithField("env", env)
+	}
+	return e
+}
+
+// envName derives an environment variable name from a dotted setting path
+func envName(field string) string {
+	return strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(field))
+}
@@ -0,0 +1,42 @@
+package errors
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestInvalidConfigurationReport(t *testing.T) {
+	err := InvalidConfiguration(
+		ConfigError("database.url", "is required"),
+		nil,
+		ConfigError("http.port", "must be between 1 and 65535"),
+		ConfigEnvError("database.pool_size", "DB_POOL", "must be a positive integer"),
+		fmt.Errorf("reading .env: permission denied"),
+	)
+	if err == nil || err.Type != "INVALID_CONFIGURATION" {
+		t.Fatalf("Expected INVALID_CONFIGURATION, got %v", err)
+	}
+	if len(err.Violations) != 3 {
+		t.Errorf("Expected 3 violations, got %d", len(err.Violations))
+	}
+
+	expected := `invalid configuration: 4 problem(s)
+
+[database]
+  database.url (DATABASE_URL): is required
+  database.pool_size (DB_POOL): must be a positive integer
+
+[general]
+  reading .env: permission denied
+
+[http]
+  http.port (HTTP_PORT): must be between 1 and 65535
+`
+	if got := RenderConfigReport(err); got != expected {
+		t.Errorf("Unexpected report:\n%s", got)
+	}
+
+	if InvalidConfiguration(nil) != nil {
+		t.Error("InvalidConfiguration without errors should be nil")
+	}
+}
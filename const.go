@@ -11,4 +11,20 @@ const (
 	ViolationErrorTypeDate       ViolationErrorType = "DATE"
 	ViolationErrorTypeRequiredIf ViolationErrorType = "REQUIRED_IF"
 	ViolationErrorTypeSort       ViolationErrorType = "SORT"
+	ViolationErrorTypeLen        ViolationErrorType = "LEN"
+	ViolationErrorTypeGT         ViolationErrorType = "GT"
+	ViolationErrorTypeGTE        ViolationErrorType = "GTE"
+	ViolationErrorTypeLT         ViolationErrorType = "LT"
+	ViolationErrorTypeLTE        ViolationErrorType = "LTE"
+	ViolationErrorTypeURL        ViolationErrorType = "URL"
+	ViolationErrorTypeNumeric    ViolationErrorType = "NUMERIC"
+	ViolationErrorTypeAlphanum   ViolationErrorType = "ALPHANUM"
+	ViolationErrorTypeDateTime   ViolationErrorType = "DATETIME"
+	ViolationErrorTypePhone      ViolationErrorType = "PHONE"
+	ViolationErrorTypeEnum       ViolationErrorType = "ENUM"
+	ViolationErrorTypeUnique     ViolationErrorType = "UNIQUE"
+	ViolationErrorTypeRegexp     ViolationErrorType = "REGEXP"
+
+	// Configuration setting violation, see ConfigError
+	ViolationErrorTypeConfig ViolationErrorType = "CONFIG"
 )
@@ -0,0 +1,94 @@
+package errors
+
+import "context"
+
+// Field keys of request-scoped metadata. WithContext stamps FieldUserID; request and trace IDs
+// are stored in Error.RequestID and Error.TraceID, and FieldRequestID and FieldTraceID name
+// them in summaries.
+const (
+	FieldRequestID = "request_id"
+	FieldTraceID   = "trace_id"
+	FieldUserID    = "user_id"
+)
+
+// Metadata is request-scoped information stamped onto errors created with NewWithContext
+type Metadata struct {
+	RequestID string
+	TraceID   string
+	UserID    string
+	// Fields are additional fields, e.g. "tenant_id"
+	Fields map[string]any
+}
+
+type metadataKey struct{}
+
+// NewContext returns a context carrying md, typically set by middleware. Metadata already in ctx
+// is kept for values md leaves empty, so layers can add to it.
+func NewContext(ctx context.Context, md Metadata) context.Context {
+	if parent, ok := FromContext(ctx); ok {
+		if md.RequestID == "" {
+			md.RequestID = parent.RequestID
+		}
+		if md.TraceID == "" {
+			md.TraceID = parent.TraceID
+		}
+		if md.UserID == "" {
+			md.UserID = parent.UserID
+		}
+		if len(parent.Fields) > 0 {
+			fields := make(map[string]any, len(parent.Fields)+len(md.Fields))
+			for key, value := range parent.Fields {
+				fields[key] = value
+			}
+			for key, value := range md.Fields {
+				fields[key] = value
+			}
+			md.Fields = fields
+		}
+	}
+	return context.WithValue(ctx, metadataKey{}, md)
+}
+
+// FromContext returns the metadata carried by ctx
+func FromContext(ctx context.Context) (Metadata, bool) {
+	md, ok := ctx.Value(metadataKey{}).(Metadata)
+	return md, ok
+}
+
+// NewWithContext is like New, and stamps the metadata and breadcrumbs carried by ctx onto the
+// error (see WithContext)
+func NewWithContext(ctx context.Context, code int64, message, errorType string) *Error {
+	e := &Error{
+		Type:        errorType,
+		Code:        code,
+		Message:     message,
+		StackTraces: captureStackTrace(1),
+	}
+	return created(e.WithContext(ctx))
+}
+
+// WithContext stamps the metadata carried by ctx onto the error: the request and trace IDs into
+// RequestID and TraceID, the user ID and Metadata.Fields into its fields under FieldUserID and
+// their keys. It attaches the context's breadcrumbs and, when goroutine capture is on (see
+// SetCaptureGoroutine), its pprof labels, and returns the error for chaining. Without a
+// trace ID in the metadata, the one read by the TraceExtractor (see SetTraceExtractor) is used.
+// Empty values are skipped.
+func (e *Error) WithContext(ctx context.Context) *Error {
+	md, _ := FromContext(ctx)
+	for key, value := range md.Fields {
+		e.WithField(key, value)
+	}
+	if md.RequestID != "" {
+		e.WithRequestID(md.RequestID)
+	}
+	if md.TraceID == "" {
+		md.TraceID = traceFromContext(ctx)
+	}
+	if md.TraceID != "" {
+		e.WithTraceID(md.TraceID)
+	}
+	if md.UserID != "" {
+		e.WithField(FieldUserID, md.UserID)
+	}
+	return e.withLabels(ctx).WithBreadcrumbs(ctx)
+}
@@ -0,0 +1,47 @@
+package errors
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestNewContextMerges(t *testing.T) {
+	ctx := NewContext(context.Background(), Metadata{RequestID: "req-1", TraceID: "trace-1", Fields: map[string]any{"tenant_id": "acme"}})
+	ctx = NewContext(ctx, Metadata{UserID: "user-7", TraceID: "trace-2", Fields: map[string]any{"region": "eu"}})
+
+	md, ok := FromContext(ctx)
+	if !ok || md.RequestID != "req-1" || md.TraceID != "trace-2" || md.UserID != "user-7" {
+		t.Errorf("Unexpected metadata %+v", md)
+	}
+	if md.Fields["tenant_id"] != "acme" || md.Fields["region"] != "eu" {
+		t.Errorf("Fields should be merged, got %v", md.Fields)
+	}
+
+	if _, ok := FromContext(context.Background()); ok {
+		t.Error("Contexts without metadata should report none")
+	}
+}
+
+func TestNewWithContext(t *testing.T) {
+	ctx := NewContext(NewBreadcrumbContext(context.Background(), 0), Metadata{RequestID: "req-1", UserID: "user-7", Fields: map[string]any{"tenant_id": "acme"}})
+	AddBreadcrumb(ctx, "loading user")
+
+	err := NewWithContext(ctx, 404, "User not found", "USER_NOT_FOUND")
+	if err.RequestID != "req-1" || err.Fields[FieldUserID] != "user-7" || err.Fields["tenant_id"] != "acme" {
+		t.Errorf("Metadata should be stamped, got %q %v", err.RequestID, err.Fields)
+	}
+	if err.TraceID != "" {
+		t.Error("Empty values should be skipped")
+	}
+	if _, ok := err.Fields[FieldBreadcrumbs]; !ok {
+		t.Error("Breadcrumbs should be attached")
+	}
+	if !strings.Contains(err.StackTraces[0], "TestNewWithContext") {
+		t.Errorf("The stack should start at the caller, got %v", err.StackTraces)
+	}
+
+	if plain := NewWithContext(context.Background(), 400, "Bad", "BAD_REQUEST"); plain.Fields != nil {
+		t.Errorf("Nothing should be stamped without metadata, got %v", plain.Fields)
+	}
+}
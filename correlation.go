@@ -0,0 +1,69 @@
+package errors
+
+import (
+	"context"
+	"sync"
+)
+
+// TraceExtractor returns the ID of the trace carried by ctx, e.g. from an OpenTelemetry span
+// context, or "" when there is none
+type TraceExtractor func(ctx context.Context) string
+
+var (
+	traceExtractorMu sync.RWMutex
+	traceExtractor   TraceExtractor
+)
+
+// SetTraceExtractor sets the function WithContext uses to read the trace ID from contexts whose
+// Metadata carries none, e.g. errorsotel.TraceID. Passing nil removes it.
+func SetTraceExtractor(fn TraceExtractor) {
+	traceExtractorMu.Lock()
+	defer traceExtractorMu.Unlock()
+	traceExtractor = fn
+}
+
+// traceFromContext returns the trace ID read from ctx by the TraceExtractor, or ""
+func traceFromContext(ctx context.Context) string {
+	traceExtractorMu.RLock()
+	fn := traceExtractor
+	traceExtractorMu.RUnlock()
+
+	if fn == nil {
+		return ""
+	}
+	return fn(ctx)
+}
+
+// WithRequestID sets the ID of the request the error occurred in and returns the error for chaining
+func (e *Error) WithRequestID(id string) *Error {
+	return e.update(func(e *Error) { e.RequestID = id })
+}
+
+// WithTraceID sets the ID of the trace the error occurred in and returns the error for chaining
+func (e *Error) WithTraceID(id string) *Error {
+	return e.update(func(e *Error) { e.TraceID = id })
+}
+
+// RequestID returns the request ID of the first *Error in err's chain that has one, or ""
+func RequestID(err error) string {
+	for _, link := range Chain(err) {
+		if e, ok := link.(*Error); ok && e != nil {
+			if id := e.snapshot().RequestID; id != "" {
+				return id
+			}
+		}
+	}
+	return ""
+}
+
+// TraceID returns the trace ID of the first *Error in err's chain that has one, or ""
+func TraceID(err error) string {
+	for _, link := range Chain(err) {
+		if e, ok := link.(*Error); ok && e != nil {
+			if id := e.snapshot().TraceID; id != "" {
+				return id
+			}
+		}
+	}
+	return ""
+}
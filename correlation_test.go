@@ -0,0 +1,75 @@
+package errors
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+type spanKey struct{}
+
+func TestCorrelationIDs(t *testing.T) {
+	e := ErrorNotFound().WithRequestID("req-1").WithTraceID("4bf92f3577b34da6a3ce929d0e0e4736")
+	if e.RequestID != "req-1" || e.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("Unexpected IDs %q %q", e.RequestID, e.TraceID)
+	}
+
+	wrapped := Wrap(fmt.Errorf("loading: %w", e))
+	if wrapped.RequestID != "req-1" || wrapped.TraceID != e.TraceID {
+		t.Errorf("Wrap should keep the IDs, got %q %q", wrapped.RequestID, wrapped.TraceID)
+	}
+	if RequestID(fmt.Errorf("outer: %w", e)) != "req-1" || TraceID(e) != e.TraceID {
+		t.Error("The accessors should find the IDs in the chain")
+	}
+	if RequestID(fmt.Errorf("plain")) != "" || TraceID(nil) != "" {
+		t.Error("Errors without IDs should have none")
+	}
+
+	var m map[string]any
+	b, _ := json.Marshal(e.Public())
+	if err := json.Unmarshal(b, &m); err != nil {
+		t.Fatal(err)
+	}
+	if m["request_id"] != "req-1" || m["trace_id"] != e.TraceID {
+		t.Errorf("Client responses should carry the IDs, got %s", b)
+	}
+	parsed, err := Parse(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.RequestID != "req-1" || parsed.TraceID != e.TraceID {
+		t.Errorf("Parse should recover the IDs, got %q %q", parsed.RequestID, parsed.TraceID)
+	}
+
+	s := Summarize(wrapped)
+	if s.Metadata[FieldRequestID] != "req-1" || s.Metadata[FieldTraceID] != e.TraceID {
+		t.Errorf("Summaries should carry the IDs, got %v", s.Metadata)
+	}
+	if !Equal(e, ErrorNotFound()) {
+		t.Error("Equal should ignore the IDs")
+	}
+}
+
+func TestTraceExtractor(t *testing.T) {
+	SetTraceExtractor(func(ctx context.Context) string {
+		id, _ := ctx.Value(spanKey{}).(string)
+		return id
+	})
+	defer SetTraceExtractor(nil)
+
+	ctx := context.WithValue(context.Background(), spanKey{}, "span-trace")
+	if e := ErrorInternalServerError().WithContext(ctx); e.TraceID != "span-trace" {
+		t.Errorf("The trace ID should be extracted from the context, got %q", e.TraceID)
+	}
+
+	ctx = NewContext(ctx, Metadata{TraceID: "md-trace"})
+	if e := NewWithContext(ctx, 500, "Failed", "INTERNAL"); e.TraceID != "md-trace" {
+		t.Errorf("Metadata should take precedence, got %q", e.TraceID)
+	}
+
+	SetTraceExtractor(nil)
+	if e := ErrorInternalServerError().WithContext(context.WithValue(context.Background(), spanKey{}, "x")); e.TraceID != "" {
+		t.Errorf("Without an extractor nothing should be stamped, got %q", e.TraceID)
+	}
+}
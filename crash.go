@@ -0,0 +1,96 @@
+package errors
+
+import (
+	"context"
+	stderrors "errors"
+	"io"
+	"os"
+	"os/exec"
+	"runtime/debug"
+	"strings"
+)
+
+// CrashMonitorEnv is set in the environment of the monitor process started by InstallCrashHandler
+const CrashMonitorEnv = "ERRORS_CRASH_MONITOR"
+
+// FieldCrash marks errors reported by the crash handler
+const FieldCrash = "crash"
+
+// InstallCrashHandler reports unrecovered panics and fatal runtime errors, in any goroutine, to
+// sink as a last resort before the process dies. It must be called first thing in main, before
+// any side effects, because it re-executes the program as a monitor process: in the monitor the
+// call never returns, and in the program the runtime writes its crash output to the monitor
+// (see debug.SetCrashOutput), which converts it into a PANIC error with the crashing goroutine's
+// stack and passes it to sink. When the program exits normally the monitor exits silently.
+//
+//	func main() {
+//		if err := errors.InstallCrashHandler(reporter); err != nil {
+//			log.Printf("crash handler: %v", err)
+//		}
+//		...
+//	}
+//
+// sink must deliver the report synchronously, e.g. flush before returning.
+func InstallCrashHandler(sink Reporter) error {
+	if os.Getenv(CrashMonitorEnv) != "" {
+		monitorCrash(os.Stdin, sink)
+		os.Exit(0)
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	r, w, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+
+	cmd := exec.Command(executable, os.Args[1:]...)
+	cmd.Env = append(os.Environ(), CrashMonitorEnv+"=1")
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = r, os.Stdout, os.Stderr
+	startErr := cmd.Start()
+	r.Close()
+	if startErr != nil {
+		return startErr
+	}
+
+	return debug.SetCrashOutput(w, debug.CrashOptions{})
+}
+
+// monitorCrash reads the crash output of the monitored process until it exits and reports the
+// crash, if any, to sink
+func monitorCrash(r io.Reader, sink Reporter) {
+	output, _ := io.ReadAll(r)
+	if e := parseCrash(string(output)); e != nil {
+		sink.Report(context.Background(), e)
+	}
+}
+
+// parseCrash converts the runtime's crash output, e.g. "panic: boom\n\ngoroutine 1 [running]:...",
+// into a PANIC error, or returns nil when there is no crash
+func parseCrash(output string) *Error {
+	first, rest, _ := strings.Cut(strings.TrimSpace(output), "\n")
+	value, ok := strings.CutPrefix(first, "panic: ")
+	if !ok {
+		if value, ok = strings.CutPrefix(first, "fatal error: "); !ok {
+			return nil
+		}
+	}
+
+	value, _, _ = strings.Cut(value, " [recovered")
+
+	// Only the crashing goroutine is kept when GOTRACEBACK prints the others too
+	_, dump, _ := strings.Cut(rest, "goroutine ")
+	dump, _, _ = strings.Cut(dump, "\n\ngoroutine ")
+
+	e := &Error{
+		Type:        "PANIC",
+		Code:        500,
+		Message:     "Panic",
+		StackTraces: parseGoroutineStack("goroutine " + dump),
+		Err:         stderrors.New(value),
+	}
+	return created(e.WithField(FieldCrash, true))
+}
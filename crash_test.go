@@ -0,0 +1,97 @@
+package errors
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const crashDump = `panic: boom [recovered, repanicked]
+
+goroutine 7 [running]:
+main.worker(0x1)
+	/app/worker.go:42 +0x1d
+created by main.main in goroutine 1
+	/app/main.go:10 +0x25
+
+goroutine 1 [sleep]:
+time.Sleep(0x3b9aca00)
+	/usr/local/go/src/runtime/time.go:300 +0xf2
+main.main()
+	/app/main.go:12 +0x30
+exit status 2
+`
+
+func TestParseCrash(t *testing.T) {
+	e := parseCrash(crashDump)
+	if e == nil || e.Type != "PANIC" || e.Error() != "boom" || e.Fields[FieldCrash] != true {
+		t.Fatalf("Unexpected error %+v", e)
+	}
+	if len(e.StackTraces) != 1 || e.StackTraces[0] != "/app/worker.go:42 main.worker" {
+		t.Errorf("Only the crashing goroutine should be kept, got %v", e.StackTraces)
+	}
+
+	if fatal := parseCrash("fatal error: concurrent map writes\n\ngoroutine 5 [running]:\n"); fatal == nil || fatal.Error() != "concurrent map writes" {
+		t.Errorf("Fatal errors should be reported, got %v", fatal)
+	}
+	if parseCrash("") != nil || parseCrash("some log line\n") != nil {
+		t.Error("Output without a crash should not be reported")
+	}
+}
+
+// crashReportPath is where the monitor of TestInstallCrashHandlerProcess writes its report
+const crashReportPath = "ERRORS_TEST_CRASH_REPORT"
+
+func TestInstallCrashHandlerProcess(t *testing.T) {
+	report := os.Getenv(crashReportPath)
+	if report == "" {
+		t.Skip("run by TestInstallCrashHandler")
+	}
+
+	sink := ReporterFunc(func(ctx context.Context, e *Error) {
+		data, _ := json.Marshal(e)
+		_ = os.WriteFile(report, data, 0o600)
+	})
+	if err := InstallCrashHandler(sink); err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		panic("worker exploded")
+	}()
+	<-done
+}
+
+func TestInstallCrashHandler(t *testing.T) {
+	if testing.Short() {
+		t.Skip("starts processes")
+	}
+
+	report := filepath.Join(t.TempDir(), "report.json")
+	cmd := exec.Command(os.Args[0], "-test.run=^TestInstallCrashHandlerProcess$")
+	cmd.Env = append(os.Environ(), crashReportPath+"="+report)
+	if err := cmd.Run(); err == nil {
+		t.Fatal("The process should crash")
+	}
+
+	var data []byte
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if data, _ = os.ReadFile(report); len(data) > 0 {
+			break
+		}
+	}
+	var e Error
+	if err := json.Unmarshal(data, &e); err != nil || e.Type != "PANIC" || e.Fields[FieldCrash] != true {
+		t.Fatalf("Expected a crash report, got %s (%v)", data, err)
+	}
+	if len(e.StackTraces) == 0 || !strings.Contains(e.StackTraces[0], "TestInstallCrashHandlerProcess") {
+		t.Errorf("The report should carry the crashing goroutine's stack, got %v", e.StackTraces)
+	}
+}
@@ -0,0 +1,32 @@
+package errors
+
+import stderrors "errors"
+
+// Ensure returns err as an *Error without reclassifying it, so handlers can convert any error
+// without burying a NOT_FOUND under a 500. An *Error is returned unchanged. An error wrapping
+// one, e.g. fmt.Errorf("loading: %w", e), is wrapped in a new error with the classification and
+// stack trace of the nearest *Error, keeping the added context in Error(). Other errors are
+// wrapped like Wrap, with the stack trace of the caller. Ensure returns nil for a nil error.
+func Ensure(err error) *Error {
+	if isNil(err) {
+		return nil
+	}
+	if e, ok := err.(*Error); ok {
+		return e
+	}
+
+	var inner *Error
+	if stderrors.As(err, &inner) && inner != nil {
+		return inherit(inner, err)
+	}
+	return createdFrom(err, wrapError(err, err, 1))
+}
+
+// As returns the first *Error in err's chain, like errors.As without the target variable
+func As(err error) (*Error, bool) {
+	var e *Error
+	if stderrors.As(err, &e) && e != nil {
+		return e, true
+	}
+	return nil, false
+}
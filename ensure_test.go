@@ -0,0 +1,53 @@
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestEnsure(t *testing.T) {
+	notFound := ErrorNotFound().WithBusinessCode("USR-0404")
+	if Ensure(notFound) != notFound {
+		t.Error("An *Error should be returned unchanged")
+	}
+
+	wrapped := fmt.Errorf("loading profile: %w", notFound)
+	e := Ensure(wrapped)
+	if e.Type != "NOT_FOUND" || e.Code != 404 || e.BusinessCode != "USR-0404" || e.Message != "Not found" {
+		t.Errorf("The nearest classification should be kept, got %s/%d/%s", e.Type, e.Code, e.BusinessCode)
+	}
+	if e.Error() != "loading profile: Not found" || !stderrors.Is(e, notFound) {
+		t.Errorf("The wrapping context should be kept, got %q", e.Error())
+	}
+	if len(e.StackTraces) == 0 || e.StackTraces[0] != notFound.StackTraces[0] {
+		t.Error("The stack trace of the nearest *Error should be reused")
+	}
+
+	plain := Ensure(stderrors.New("disk full"))
+	if plain.Type != "INTERNAL_SERVER_ERROR" || !strings.Contains(plain.StackTraces[0], "TestEnsure") {
+		t.Errorf("Plain errors should be wrapped at the caller, got %s %v", plain.Type, plain.StackTraces)
+	}
+
+	if Ensure(nil) != nil {
+		t.Error("Ensure(nil) should be nil")
+	}
+	var typedNil *Error
+	if Ensure(typedNil) != nil {
+		t.Error("Ensure of a typed nil should be nil")
+	}
+}
+
+func TestAs(t *testing.T) {
+	conflict := ErrorConflict()
+	if e, ok := As(fmt.Errorf("saving: %w", conflict)); !ok || e != conflict {
+		t.Error("As should find the *Error in the chain")
+	}
+	if e, ok := As(stderrors.New("plain")); ok || e != nil {
+		t.Error("As should fail for plain errors")
+	}
+	if _, ok := As(nil); ok {
+		t.Error("As should fail for nil")
+	}
+}
@@ -0,0 +1,126 @@
+package errors
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+// EnvelopeVersion is the version of the JSON error envelope written by this package
+const EnvelopeVersion = 1
+
+// CapabilitiesHeader is the request header in which clients advertise the envelope capabilities
+// they understand, as a decimal bitset. Responses are reduced to those capabilities.
+const CapabilitiesHeader = "X-Error-Capabilities"
+
+// Capability is a bitset of optional envelope features
+type Capability uint64
+
+// Optional envelope features
+const (
+	// CapabilityID is the occurrence ID
+	CapabilityID Capability = 1 << iota
+	// CapabilityRetryable is the retryable flag
+	CapabilityRetryable
+	// CapabilityViolationDocs is the docs_url of violations
+	CapabilityViolationDocs
+)
+
+// SupportedCapabilities is every capability this version of the package reads and writes
+const SupportedCapabilities = CapabilityID | CapabilityRetryable | CapabilityViolationDocs
+
+// Envelope is the JSON document of an error response: the error's public fields, the envelope
+// version and the capabilities it was written with
+type Envelope struct {
+	Version      int
+	Capabilities Capability
+	Error        *Error
+}
+
+// String returns the decimal form of the bitset used in CapabilitiesHeader
+func (c Capability) String() string {
+	return strconv.FormatUint(uint64(c), 10)
+}
+
+// NewEnvelope returns the envelope of err's Public() copy reduced to the capabilities in caps
+// that this package supports
+func NewEnvelope(err *Error, caps Capability) Envelope {
+	caps &= SupportedCapabilities
+	return Envelope{
+		Version:      EnvelopeVersion,
+		Capabilities: caps,
+		Error:        Downgrade(err.Public(), caps),
+	}
+}
+
+// MarshalJSON encodes the error's fields next to "version" and "capabilities"
+func (env Envelope) MarshalJSON() ([]byte, error) {
+	var e *errorJSON
+	if env.Error != nil {
+		e = jsonForm(env.Error.snapshot())
+	}
+	return json.Marshal(struct {
+		*errorJSON
+		Version      int        `json:"version"`
+		Capabilities Capability `json:"capabilities"`
+	}{e, env.Version, env.Capabilities})
+}
+
+// Downgrade returns a copy of err without the optional features missing from caps, e.g. to
+// relay an error to a peer running an older version of this package
+func Downgrade(err *Error, caps Capability) *Error {
+	c := err.Clone()
+	if c == nil {
+		return nil
+	}
+
+	if caps&CapabilityID == 0 {
+		c.ID = ""
+	}
+	if caps&CapabilityRetryable == 0 {
+		c.Retryable = false
+	}
+	if caps&CapabilityViolationDocs == 0 {
+		for i := range c.Violations {
+			c.Violations[i].DocsURL = ""
+		}
+	}
+	return c
+}
+
+// RequestCapabilities returns the capabilities r advertises in CapabilitiesHeader, see ParseCapabilities
+func RequestCapabilities(r *http.Request) Capability {
+	if r == nil {
+		return SupportedCapabilities
+	}
+	return ParseCapabilities(r.Header.Get(CapabilitiesHeader))
+}
+
+// ParseCapabilities parses a CapabilitiesHeader value, limited to SupportedCapabilities.
+// An empty or invalid value, e.g. from a client predating versioning, yields SupportedCapabilities.
+func ParseCapabilities(value string) Capability {
+	caps, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return SupportedCapabilities
+	}
+	return Capability(caps) & SupportedCapabilities
+}
+
+// ParseNegotiated is like Parse, and additionally drops the features missing from caps or from
+// the capabilities the envelope advertises. Envelopes without capabilities, written before
+// versioning, and envelopes of newer versions are accepted; unknown keys are ignored.
+func ParseNegotiated(data []byte, caps Capability) (*Error, error) {
+	e, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var header struct {
+		Capabilities *Capability `json:"capabilities"`
+	}
+	_ = json.Unmarshal(data, &header)
+	if header.Capabilities != nil {
+		caps &= *header.Capabilities
+	}
+	return Downgrade(e, caps), nil
+}
@@ -0,0 +1,79 @@
+package errors
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEnvelopeVersionAndCapabilities(t *testing.T) {
+	err := Violations([]ValidationError{{Type: "REQUIRED", Field: "email", Message: "required", DocsURL: "https://docs/email"}}).WithRetryable(true)
+	err.EnsureID()
+
+	rec := httptest.NewRecorder()
+	WriteJSON(rec, err)
+
+	var body map[string]any
+	if decodeErr := json.Unmarshal(rec.Body.Bytes(), &body); decodeErr != nil {
+		t.Fatal(decodeErr)
+	}
+	if body["version"] != float64(EnvelopeVersion) || body["capabilities"] != float64(SupportedCapabilities) {
+		t.Errorf("Expected the version and capabilities, got %v", body)
+	}
+	if body["id"] != err.ID || body["retryable"] != true || body["type"] != "UNPROCESSABLE_ENTITY" {
+		t.Errorf("Expected the public fields, got %v", body)
+	}
+}
+
+func TestDefaultResponderNegotiates(t *testing.T) {
+	err := Violations([]ValidationError{{Type: "REQUIRED", Field: "email", Message: "required", DocsURL: "https://docs/email"}}).WithRetryable(true)
+	err.EnsureID()
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(CapabilitiesHeader, (CapabilityRetryable | 1<<40).String())
+	rec := httptest.NewRecorder()
+	DefaultResponder(rec, req, err)
+
+	parsed, parseErr := Parse(rec.Body.Bytes())
+	if parseErr != nil {
+		t.Fatal(parseErr)
+	}
+	if parsed.ID != "" || parsed.Violations[0].DocsURL != "" || !parsed.Retryable {
+		t.Errorf("Only the advertised capabilities should be written, got %+v", parsed)
+	}
+	if err.ID == "" || err.Violations[0].DocsURL == "" {
+		t.Error("The original error should not be changed")
+	}
+}
+
+func TestParseNegotiated(t *testing.T) {
+	data := []byte(`{"version":7,"capabilities":1024,"id":"abc","type":"NOT_FOUND","code":404,"message":"Not found","retryable":true,"violations":[{"type":"REQUIRED","field":"id","message":"x","docs_url":"d","params":{"min":1}}]}`)
+
+	e, err := ParseNegotiated(data, SupportedCapabilities)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Type != "NOT_FOUND" || e.ID != "" || e.Retryable || e.Violations[0].DocsURL != "" {
+		t.Errorf("Features the envelope does not advertise should be dropped, got %+v", e)
+	}
+
+	legacy := []byte(`{"id":"abc","type":"NOT_FOUND","code":404,"message":"Not found","retryable":true}`)
+	if e, _ := ParseNegotiated(legacy, CapabilityID); e.ID != "abc" || e.Retryable {
+		t.Errorf("Legacy envelopes should be reduced to the requested capabilities, got %+v", e)
+	}
+}
+
+func TestParseCapabilities(t *testing.T) {
+	for value, want := range map[string]Capability{
+		"":        SupportedCapabilities,
+		"invalid": SupportedCapabilities,
+		"0":       0,
+		"3":       CapabilityID | CapabilityRetryable,
+		"1023":    SupportedCapabilities,
+	} {
+		if got := ParseCapabilities(value); got != want {
+			t.Errorf("ParseCapabilities(%q) = %d, want %d", value, got, want)
+		}
+	}
+}
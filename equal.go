@@ -0,0 +1,59 @@
+package errors
+
+import (
+	"reflect"
+	"slices"
+)
+
+// Equal reports whether a and b describe the same error: type, codes, operation, messages,
+// message template and parameters, notes, violations, fields, retry hints and severity are
+// compared deeply, and their causes by message. Volatile data, i.e. the ID, request and trace
+// IDs, stack trace, sampling decision, timestamp, elapsed time and goroutine, is ignored, so two occurrences of the same
+// failure are equal. Two nil errors are equal.
+func Equal(a, b *Error) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if a == b {
+		return true
+	}
+
+	x, y := a.snapshot(), b.snapshot()
+	return x.Type == y.Type &&
+		x.Code == y.Code &&
+		x.BusinessCode == y.BusinessCode &&
+		x.Status == y.Status &&
+		x.GRPCCode == y.GRPCCode &&
+		x.Op == y.Op &&
+		x.Message == y.Message &&
+		x.InternalMessage == y.InternalMessage &&
+		x.MessageKey == y.MessageKey &&
+		x.MessageTemplate == y.MessageTemplate &&
+		x.Retryable == y.Retryable &&
+		x.RetryAfter == y.RetryAfter &&
+		x.Severity == y.Severity &&
+		causeMessage(x.Err) == causeMessage(y.Err) &&
+		slices.Equal(x.MessageHistory, y.MessageHistory) &&
+		slices.Equal(x.Notes, y.Notes) &&
+		equalLoose(x.MessageParams, y.MessageParams) &&
+		equalLoose(x.Violations, y.Violations) &&
+		equalLoose(x.Fields, y.Fields)
+}
+
+// causeMessage returns the message of err, or "" for nil
+func causeMessage(err error) string {
+	if isNil(err) {
+		return ""
+	}
+	return err.Error()
+}
+
+// equalLoose reports whether a and b are deeply equal, treating nil and empty slices and maps
+// as equal since constructors leave them nil until needed
+func equalLoose[T any](a, b T) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if va.Len() == 0 && vb.Len() == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,43 @@
+package errors
+
+import (
+	stderrors "errors"
+	"testing"
+)
+
+func TestEqual(t *testing.T) {
+	build := func() *Error {
+		return Violations([]ValidationError{{Type: ViolationErrorTypeRequired, Field: "email", Message: "Email is required"}}).
+			WithField("tenant", "acme").
+			WithCause(stderrors.New("form invalid"))
+	}
+
+	a, b := build(), build()
+	b.ID = "err_other"
+	b.StackTraces = []string{"elsewhere.go:1 main.main"}
+	if !Equal(a, b) {
+		t.Error("Occurrences differing only in ID and stack should be equal")
+	}
+	if !Equal(ErrorNotFound(), &Error{Type: "NOT_FOUND", Code: 404, Message: "Not found", Violations: []ValidationError{}}) {
+		t.Error("nil and empty violations should be equal")
+	}
+
+	differs := map[string]*Error{
+		"field":     build().WithField("tenant", "globex"),
+		"message":   build().WithMessage("Invalid form"),
+		"violation": build(),
+		"cause":     build().WithCause(stderrors.New("other")),
+		"code":      build().WithBusinessCode("USR-1"),
+		"retry":     build().WithRetryable(true),
+	}
+	differs["violation"].Violations = append(differs["violation"].Violations, ValidationError{Type: ViolationErrorTypeRequired, Field: "name"})
+	for name, other := range differs {
+		if Equal(a, other) {
+			t.Errorf("Errors with a different %s should not be equal", name)
+		}
+	}
+
+	if !Equal(nil, nil) || Equal(a, nil) || Equal(nil, a) {
+		t.Error("nil is only equal to nil")
+	}
+}
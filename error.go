@@ -1,21 +1,38 @@
 package errors
 
 import (
+	"context"
+	stderrors "errors"
 	"fmt"
+	"net/http"
 	"runtime"
-	"strings"
 )
 
+// unsampledStackFrames is how many frames are walked for errors keeping only their creation frame
+const unsampledStackFrames = 8
+
 // captureStackTrace captures the current stack trace using runtime.Callers
 // skip parameter indicates how many stack frames to skip (0 = current function, 1 = caller, etc.)
 func captureStackTrace(skip int) []string {
-	const maxFrames = 32
-	pcs := make([]uintptr, maxFrames)
+	settings := currentStackSettings()
+
+	// Errors in CaptureCaller mode or not sampled by the StackSampler keep only their creation
+	// frame, so only a few frames are walked to find it past filtered ones
+	full, size := true, settings.maxFrames
+	if settings.mode == CaptureCaller {
+		full, size = false, min(size, unsampledStackFrames)
+	} else if settings.sampler != nil {
+		var site [1]uintptr
+		if runtime.Callers(skip+2, site[:]) == 1 && !settings.sampler.sample(site[0]) {
+			full, size = false, min(size, unsampledStackFrames)
+		}
+	}
+	pcs := make([]uintptr, size)
 
 	// Skip additional frames: skip + 1 (for captureStackTrace itself)
 	n := runtime.Callers(skip+2, pcs)
 	if n == 0 {
-		return []string{}
+		return nil
 	}
 
 	frames := runtime.CallersFrames(pcs[:n])
@@ -24,9 +41,12 @@ func captureStackTrace(skip int) []string {
 	for {
 		frame, more := frames.Next()
 
-		// Skip internal runtime frames and this package's internal frames
-		if isRelevantFrame(frame) {
-			result = append(result, fmt.Sprintf("%s:%d %s", frame.File, frame.Line, frame.Function))
+		// Skip internal runtime frames and frames rejected by the registered filters
+		if settings.relevant(frame) {
+			result = append(result, settings.format(frame))
+			if !full {
+				break
+			}
 		}
 
 		if !more {
@@ -36,53 +56,121 @@ func captureStackTrace(skip int) []string {
 	return result
 }
 
-// isRelevantFrame determines if a stack frame is relevant for error reporting
-func isRelevantFrame(frame runtime.Frame) bool {
-	// Skip runtime internal functions
-	if strings.HasPrefix(frame.Function, "runtime.") {
-		return false
-	}
-
-	// Skip testing framework functions
-	if strings.HasPrefix(frame.Function, "testing.") {
-		return false
-	}
-
-	// Include all other frames
-	return true
-}
-
 // New creates a new error with the provided code, message, and error type.
 func New(code int64, message, errorType string) *Error {
+	return created(&Error{
+		Type:        errorType,
+		Code:        code,
+		Message:     message,
+		StackTraces: captureStackTrace(1),
+	})
+}
+
+// NewWith is like New but applies the overrides before the error is reported to the created
+// hooks, so hooks observe the final classification. Integrations that derive the type, code
+// or cause from a foreign error build through it instead of mutating the result of New.
+func NewWith(code int64, message, errorType string, overrides ...Override) *Error {
 	e := &Error{
 		Type:        errorType,
 		Code:        code,
-		Violations:  make([]ValidationError, 0),
 		Message:     message,
-		StackTraces: make([]string, 0),
+		StackTraces: captureStackTrace(1),
 	}
-
-	if len(e.StackTraces) == 0 {
-		e.StackTraces = append(e.StackTraces, captureStackTrace(1)...)
+	for _, override := range overrides {
+		override(e)
 	}
+	return created(e)
+}
 
-	return e
+// Newf creates a new error whose message is formatted from format and args.
+// The raw format and args are kept in MessageTemplate and MessageParams for localization and grouping.
+func Newf(code int64, format, errorType string, args ...any) *Error {
+	return created(&Error{
+		Type:            errorType,
+		Code:            code,
+		Message:         fmt.Sprintf(format, args...),
+		MessageTemplate: format,
+		MessageParams:   args,
+		StackTraces:     captureStackTrace(1),
+	})
 }
 
 // Wrap wraps an existing error with a default error, setting the error type, code, and message.
+// Context errors are classified by their meaning instead: context.DeadlineExceeded becomes a
+// retryable GATEWAY_TIMEOUT (504) and context.Canceled a CLIENT_CLOSED_REQUEST (499).
+// When err's chain already contains an *Error with a stack trace, that trace is reused instead of
+// capturing a new one, so wrapping at every layer does not multiply stack traces.
+// Wrap returns nil for a nil error, so wrapping a result never invents a failure.
 func Wrap(err error) *Error {
-	e := &Error{
-		Type:        "INTERNAL_SERVER_ERROR",
-		Code:        500,
-		Message:     "An internal server error occurred",
-		Violations:  make([]ValidationError, 0),
-		StackTraces: captureStackTrace(1),
-		Err:         err,
+	if isNil(err) {
+		return nil
+	}
+	return createdFrom(err, wrapError(err, err, 1))
+}
+
+// Wrapf is like Wrap, with the cause prefixed by context formatted from format and args, e.g.
+// Wrapf(err, "loading user %d", id) reads "loading user 42: sql: no rows in result set".
+// The cause stays reachable with errors.Is and errors.As. Wrapf returns nil for a nil error.
+func Wrapf(err error, format string, args ...any) *Error {
+	if isNil(err) {
+		return nil
+	}
+	return createdFrom(err, wrapError(err, fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err), 1))
+}
+
+// wrapError builds the error Wrap returns for err with cause as the wrapped error. The stack trace
+// of an *Error in err's chain is reused, otherwise it is captured skip frames above wrapError's caller.
+func wrapError(err, cause error, skip int) *Error {
+	stack := existingStackTrace(err)
+	if stack == nil {
+		stack = captureStackTrace(skip + 1)
 	}
 
+	e := classifyCause(err)
+	e.StackTraces = stack
+	e.Err = cause
+	return e
+}
+
+// classifyCause returns the classification Wrap gives err, without a stack trace or cause: a
+// 500, except for context errors
+func classifyCause(err error) *Error {
+	e := &Error{
+		Type:    "INTERNAL_SERVER_ERROR",
+		Code:    500,
+		Message: "An internal server error occurred",
+	}
+	switch {
+	case stderrors.Is(err, context.DeadlineExceeded):
+		e.Type, e.Code, e.Message, e.Retryable = "GATEWAY_TIMEOUT", 504, "Gateway Timeout", true
+	case stderrors.Is(err, context.Canceled):
+		e.Type, e.Code, e.Message = "CLIENT_CLOSED_REQUEST", 499, "Client Closed Request"
+	}
 	return e
 }
 
+// WrapWith wraps err with the provided code, error type and message instead of the 500 of Wrap.
+// Error() still returns the cause's text, while Message is what clients see.
+// WrapWith returns nil for a nil error.
+func WrapWith(err error, code int64, errorType, message string) *Error {
+	if isNil(err) {
+		return nil
+	}
+
+	stack := existingStackTrace(err)
+	if stack == nil {
+		stack = captureStackTrace(1)
+	}
+
+	return createdFrom(err, &Error{
+		Type:        errorType,
+		Code:        code,
+		Message:     message,
+		StackTraces: stack,
+		Err:         err,
+	})
+}
+
 // Violations returns a validation error with a 422 status code, "UNPROCESSABLE_ENTITY" type, and the provided validation violations.
 func Violations(violations []ValidationError) *Error {
 	e := &Error{
@@ -93,7 +181,7 @@ func Violations(violations []ValidationError) *Error {
 		StackTraces: captureStackTrace(1),
 	}
 
-	return e
+	return created(e)
 }
 
 // Factory functions for common errors - these capture stack trace when called, not during package init
@@ -101,108 +189,354 @@ func ErrorBadRequest() *Error {
 	e := &Error{
 		Type:        "BAD_REQUEST",
 		Code:        400,
-		Violations:  make([]ValidationError, 0),
 		Message:     "Bad request",
 		StackTraces: captureStackTrace(1),
 	}
-	return e
+	return created(e)
 }
 
 func ErrorUnauthorized() *Error {
 	e := &Error{
 		Type:        "UNAUTHORIZED",
 		Code:        401,
-		Violations:  make([]ValidationError, 0),
 		Message:     "Unauthorized",
 		StackTraces: captureStackTrace(1),
 	}
-	return e
+	return created(e)
 }
 
 func ErrorForbidden() *Error {
 	e := &Error{
 		Type:        "FORBIDDEN",
 		Code:        403,
-		Violations:  make([]ValidationError, 0),
 		Message:     "Forbidden",
 		StackTraces: captureStackTrace(1),
 	}
-	return e
+	return created(e)
 }
 
 func ErrorNotFound() *Error {
 	e := &Error{
 		Type:        "NOT_FOUND",
 		Code:        404,
-		Violations:  make([]ValidationError, 0),
 		Message:     "Not found",
 		StackTraces: captureStackTrace(1),
 	}
-	return e
+	return created(e)
 }
 
 func ErrorConflict() *Error {
 	e := &Error{
 		Type:        "CONFLICT",
 		Code:        409,
-		Violations:  make([]ValidationError, 0),
 		Message:     "Conflict",
 		StackTraces: captureStackTrace(1),
 	}
-	return e
+	return created(e)
 }
 
 func ErrorUnprocessableEntity() *Error {
 	e := &Error{
 		Type:        "UNPROCESSABLE_ENTITY",
 		Code:        422,
-		Violations:  make([]ValidationError, 0),
 		Message:     "Unprocessable Entity",
 		StackTraces: captureStackTrace(1),
 	}
-	return e
+	return created(e)
 }
 
 func ErrorInternalServerError() *Error {
 	e := &Error{
 		Type:        "INTERNAL_SERVER_ERROR",
 		Code:        500,
-		Violations:  make([]ValidationError, 0),
 		Message:     "Internal Server Error",
 		StackTraces: captureStackTrace(1),
 	}
-	return e
+	return created(e)
 }
 
 func ErrorPanic() *Error {
 	e := &Error{
 		Type:        "PANIC",
 		Code:        500,
-		Violations:  make([]ValidationError, 0),
 		Message:     "Panic",
 		StackTraces: captureStackTrace(1),
 	}
-	return e
+	return created(e)
 }
 
 func ErrorTooManyRequests() *Error {
 	e := &Error{
 		Type:        "TOO_MANY_REQUEST",
 		Code:        429,
-		Violations:  make([]ValidationError, 0),
 		Message:     "Too Many Requests",
 		StackTraces: captureStackTrace(1),
+		Retryable:   true,
 	}
-	return e
+	return created(e)
+}
+
+func ErrorPaymentRequired() *Error {
+	e := &Error{
+		Type:        "PAYMENT_REQUIRED",
+		Code:        402,
+		Message:     "Payment Required",
+		StackTraces: captureStackTrace(1),
+	}
+	return created(e)
+}
+
+func ErrorMethodNotAllowed() *Error {
+	e := &Error{
+		Type:        "METHOD_NOT_ALLOWED",
+		Code:        405,
+		Message:     "Method Not Allowed",
+		StackTraces: captureStackTrace(1),
+	}
+	return created(e)
+}
+
+func ErrorNotAcceptable() *Error {
+	e := &Error{
+		Type:        "NOT_ACCEPTABLE",
+		Code:        406,
+		Message:     "Not Acceptable",
+		StackTraces: captureStackTrace(1),
+	}
+	return created(e)
+}
+
+func ErrorRequestTimeout() *Error {
+	e := &Error{
+		Type:        "REQUEST_TIMEOUT",
+		Code:        408,
+		Message:     "Request Timeout",
+		StackTraces: captureStackTrace(1),
+		Retryable:   true,
+	}
+	return created(e)
+}
+
+func ErrorGone() *Error {
+	e := &Error{
+		Type:        "GONE",
+		Code:        410,
+		Message:     "Gone",
+		StackTraces: captureStackTrace(1),
+	}
+	return created(e)
+}
+
+func ErrorPreconditionFailed() *Error {
+	e := &Error{
+		Type:        "PRECONDITION_FAILED",
+		Code:        412,
+		Message:     "Precondition Failed",
+		StackTraces: captureStackTrace(1),
+	}
+	return created(e)
+}
+
+func ErrorRequestEntityTooLarge() *Error {
+	e := &Error{
+		Type:        "REQUEST_ENTITY_TOO_LARGE",
+		Code:        413,
+		Message:     "Request Entity Too Large",
+		StackTraces: captureStackTrace(1),
+	}
+	return created(e)
+}
+
+func ErrorUnsupportedMediaType() *Error {
+	e := &Error{
+		Type:        "UNSUPPORTED_MEDIA_TYPE",
+		Code:        415,
+		Message:     "Unsupported Media Type",
+		StackTraces: captureStackTrace(1),
+	}
+	return created(e)
+}
+
+func ErrorLocked() *Error {
+	e := &Error{
+		Type:        "LOCKED",
+		Code:        423,
+		Message:     "Locked",
+		StackTraces: captureStackTrace(1),
+		Retryable:   true,
+	}
+	return created(e)
+}
+
+func ErrorTooEarly() *Error {
+	e := &Error{
+		Type:        "TOO_EARLY",
+		Code:        425,
+		Message:     "Too Early",
+		StackTraces: captureStackTrace(1),
+		Retryable:   true,
+	}
+	return created(e)
+}
+
+func ErrorPreconditionRequired() *Error {
+	e := &Error{
+		Type:        "PRECONDITION_REQUIRED",
+		Code:        428,
+		Message:     "Precondition Required",
+		StackTraces: captureStackTrace(1),
+	}
+	return created(e)
+}
+
+func ErrorRequestHeaderFieldsTooLarge() *Error {
+	e := &Error{
+		Type:        "REQUEST_HEADER_FIELDS_TOO_LARGE",
+		Code:        431,
+		Message:     "Request Header Fields Too Large",
+		StackTraces: captureStackTrace(1),
+	}
+	return created(e)
+}
+
+func ErrorUnavailableForLegalReasons() *Error {
+	e := &Error{
+		Type:        "UNAVAILABLE_FOR_LEGAL_REASONS",
+		Code:        451,
+		Message:     "Unavailable For Legal Reasons",
+		StackTraces: captureStackTrace(1),
+	}
+	return created(e)
+}
+
+func ErrorNotImplemented() *Error {
+	e := &Error{
+		Type:        "NOT_IMPLEMENTED",
+		Code:        501,
+		Message:     "Not Implemented",
+		StackTraces: captureStackTrace(1),
+	}
+	return created(e)
+}
+
+func ErrorBadGateway() *Error {
+	e := &Error{
+		Type:        "BAD_GATEWAY",
+		Code:        502,
+		Message:     "Bad Gateway",
+		StackTraces: captureStackTrace(1),
+		Retryable:   true,
+	}
+	return created(e)
+}
+
+func ErrorServiceUnavailable() *Error {
+	e := &Error{
+		Type:        "SERVICE_UNAVAILABLE",
+		Code:        503,
+		Message:     "Service Unavailable",
+		StackTraces: captureStackTrace(1),
+		Retryable:   true,
+	}
+	return created(e)
+}
+
+func ErrorGatewayTimeout() *Error {
+	e := &Error{
+		Type:        "GATEWAY_TIMEOUT",
+		Code:        504,
+		Message:     "Gateway Timeout",
+		StackTraces: captureStackTrace(1),
+		Retryable:   true,
+	}
+	return created(e)
+}
+
+// ErrorClientClosedRequest returns an error with a 499 status code, "CLIENT_CLOSED_REQUEST" type, and a default message.
+// It reports a request the client abandoned, e.g. through context.Canceled, rather than a server failure.
+func ErrorClientClosedRequest() *Error {
+	e := &Error{
+		Type:        "CLIENT_CLOSED_REQUEST",
+		Code:        499,
+		Message:     "Client Closed Request",
+		StackTraces: captureStackTrace(1),
+	}
+	return created(e)
+}
+
+// FromHTTPStatus returns the error for an HTTP status: the matching factory's error for known
+// statuses, otherwise an error whose type and message derive from the status text, e.g.
+// "EXPECTATION_FAILED" for 417. Unregistered 4xx and 5xx statuses keep their code with a
+// BAD_REQUEST or INTERNAL_SERVER_ERROR type. It returns nil for statuses below 400.
+// The overrides are applied before the created hooks run (see NewWith).
+func FromHTTPStatus(status int, overrides ...Override) *Error {
+	if status < 400 {
+		return nil
+	}
+
+	e := &Error{Code: int64(status)}
+	if class, ok := statusClasses[status]; ok {
+		e.Type, e.Message, e.Retryable = class.errorType, class.message, class.retryable
+	} else {
+		e.Type, e.Message = statusType(status), http.StatusText(status)
+		if e.Message == "" {
+			e.Type, e.Message = "BAD_REQUEST", "Bad Request"
+			if status >= 500 {
+				e.Type, e.Message = "INTERNAL_SERVER_ERROR", "Internal Server Error"
+			}
+		}
+	}
+	e.StackTraces = captureStackTrace(1)
+	for _, override := range overrides {
+		override(e)
+	}
+	return created(e)
+}
+
+// statusClass is the type, message and retryability of the predefined error for a status
+type statusClass struct {
+	errorType string
+	message   string
+	retryable bool
+}
+
+// statusClasses describes the predefined errors by status, as the Error* factories create them.
+// FromHTTPStatus and the response parsers classify statuses through it, so errors decoded from
+// a status match the factories and their sentinels.
+var statusClasses = map[int]statusClass{
+	400: {"BAD_REQUEST", "Bad request", false},
+	401: {"UNAUTHORIZED", "Unauthorized", false},
+	402: {"PAYMENT_REQUIRED", "Payment Required", false},
+	403: {"FORBIDDEN", "Forbidden", false},
+	404: {"NOT_FOUND", "Not found", false},
+	405: {"METHOD_NOT_ALLOWED", "Method Not Allowed", false},
+	406: {"NOT_ACCEPTABLE", "Not Acceptable", false},
+	408: {"REQUEST_TIMEOUT", "Request Timeout", true},
+	409: {"CONFLICT", "Conflict", false},
+	410: {"GONE", "Gone", false},
+	412: {"PRECONDITION_FAILED", "Precondition Failed", false},
+	413: {"REQUEST_ENTITY_TOO_LARGE", "Request Entity Too Large", false},
+	415: {"UNSUPPORTED_MEDIA_TYPE", "Unsupported Media Type", false},
+	422: {"UNPROCESSABLE_ENTITY", "Unprocessable Entity", false},
+	423: {"LOCKED", "Locked", true},
+	425: {"TOO_EARLY", "Too Early", true},
+	428: {"PRECONDITION_REQUIRED", "Precondition Required", false},
+	429: {"TOO_MANY_REQUEST", "Too Many Requests", true},
+	431: {"REQUEST_HEADER_FIELDS_TOO_LARGE", "Request Header Fields Too Large", false},
+	451: {"UNAVAILABLE_FOR_LEGAL_REASONS", "Unavailable For Legal Reasons", false},
+	500: {"INTERNAL_SERVER_ERROR", "Internal Server Error", false},
+	501: {"NOT_IMPLEMENTED", "Not Implemented", false},
+	502: {"BAD_GATEWAY", "Bad Gateway", true},
+	503: {"SERVICE_UNAVAILABLE", "Service Unavailable", true},
+	504: {"GATEWAY_TIMEOUT", "Gateway Timeout", true},
 }
 
 // DefaultError returns a default error with a 500 status code, "INTERNAL_SERVER_ERROR" type, and a generic error message.
 func DefaultError() *Error {
-	return &Error{
+	return created(&Error{
 		Type:        "INTERNAL_SERVER_ERROR",
 		Code:        500,
 		Message:     "An internal server error occurred",
-		Violations:  make([]ValidationError, 0),
 		StackTraces: captureStackTrace(1),
-	}
+	})
 }
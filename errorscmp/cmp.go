@@ -0,0 +1,45 @@
+// Package errorscmp provides go-cmp options for comparing *errors.Error values in tests and
+// deduplication logic.
+package errorscmp
+
+import (
+	"reflect"
+
+	errors "github.com/andryhardiyanto/go-errors"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+// errorType is the type of errors.Error
+var errorType = reflect.TypeOf(errors.Error{})
+
+// IgnoreStacks ignores the stack traces of errors, which differ between occurrences of the same
+// failure. Combine it with CompareCauses when errors wrap causes.
+func IgnoreStacks() cmp.Option {
+	return cmpopts.IgnoreFields(errors.Error{}, "StackTraces")
+}
+
+// IgnoreVolatile ignores the data errors.Equal ignores: the ID, request and trace IDs, stack
+// trace, sampling decision, timestamp, elapsed time and goroutine
+func IgnoreVolatile() cmp.Option {
+	return cmpopts.IgnoreFields(errors.Error{}, "ID", "RequestID", "TraceID", "StackTraces", "Sampling", "Timestamp", "Elapsed", "Goroutine")
+}
+
+// CompareCauses compares the wrapped causes of errors by message. Causes usually have unexported
+// fields, e.g. those created by errors.New or fmt.Errorf, which cmp refuses to compare.
+func CompareCauses() cmp.Option {
+	return cmp.FilterPath(func(p cmp.Path) bool {
+		field, ok := p.Last().(cmp.StructField)
+		return ok && field.Name() == "Err" && p.Index(-2).Type() == errorType
+	}, cmp.Comparer(func(a, b error) bool {
+		if a == nil || b == nil {
+			return a == nil && b == nil
+		}
+		return a.Error() == b.Error()
+	}))
+}
+
+// Equal compares *errors.Error values with errors.Equal, e.g. inside larger structures
+func Equal() cmp.Option {
+	return cmp.Comparer(errors.Equal)
+}
@@ -0,0 +1,63 @@
+package errorscmp
+
+import (
+	stderrors "errors"
+	"testing"
+
+	errors "github.com/andryhardiyanto/go-errors"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestIgnoreStacks(t *testing.T) {
+	a, b := errors.ErrorNotFound(), errors.ErrorNotFound()
+	b.StackTraces = []string{"elsewhere.go:1 main.main"}
+	b.Timestamp = a.Timestamp
+
+	if diff := cmp.Diff(a, b, IgnoreStacks()); diff != "" {
+		t.Errorf("Stacks should be ignored:\n%s", diff)
+	}
+	if cmp.Equal(a, b) {
+		t.Error("Stacks should differ without the option")
+	}
+
+	b.Message = "User not found"
+	if diff := cmp.Diff(a, b, IgnoreStacks()); diff == "" {
+		t.Error("Messages should still be compared")
+	}
+}
+
+func TestIgnoreVolatileWithCauses(t *testing.T) {
+	build := func(id string) *errors.Error {
+		e := errors.Violations([]errors.ValidationError{{Type: errors.ViolationErrorTypeRequired, Field: "email"}}).
+			WithCause(stderrors.New("form invalid")).
+			WithSampling(true, 0.5)
+		e.ID = id
+		return e
+	}
+
+	a, b := build("err_1"), build("err_2")
+	if diff := cmp.Diff(a, b, IgnoreVolatile(), CompareCauses()); diff != "" {
+		t.Errorf("Volatile data should be ignored:\n%s", diff)
+	}
+
+	b.WithCause(stderrors.New("other"))
+	if cmp.Equal(a, b, IgnoreVolatile(), CompareCauses()) {
+		t.Error("Causes should be compared by message")
+	}
+	b.Violations[0].Field = "name"
+	if diff := cmp.Diff(a, b, IgnoreVolatile(), CompareCauses()); diff == "" {
+		t.Error("Violations should be compared")
+	}
+}
+
+func TestEqual(t *testing.T) {
+	type result struct {
+		Err *errors.Error
+	}
+	a := result{errors.ErrorConflict().WithCause(stderrors.New("duplicate key"))}
+	b := result{errors.ErrorConflict().WithCause(stderrors.New("duplicate key"))}
+
+	if !cmp.Equal(a, b, Equal()) {
+		t.Error("Errors should be compared with errors.Equal")
+	}
+}
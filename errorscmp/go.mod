@@ -0,0 +1,10 @@
+module github.com/andryhardiyanto/go-errors/errorscmp
+
+go 1.26.2
+
+require (
+	github.com/andryhardiyanto/go-errors v0.0.0
+	github.com/google/go-cmp v0.7.0
+)
+
+replace github.com/andryhardiyanto/go-errors => ../
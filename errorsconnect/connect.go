@@ -0,0 +1,261 @@
+// Package errorsconnect converts between *errors.Error and connect-go errors.
+package errorsconnect
+
+import (
+	"context"
+	stderrors "errors"
+	"strconv"
+
+	"connectrpc.com/connect"
+	errors "github.com/andryhardiyanto/go-errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// ErrorInfoDomain is the domain of the ErrorInfo detail carrying the error type and code. It
+// matches the domain used by errorsgrpc, so both transports understand each other's details.
+const ErrorInfoDomain = "github.com/andryhardiyanto/go-errors"
+
+// codeByStatus maps HTTP-style error codes to Connect codes
+var codeByStatus = map[int64]connect.Code{
+	400: connect.CodeInvalidArgument,
+	401: connect.CodeUnauthenticated,
+	403: connect.CodePermissionDenied,
+	404: connect.CodeNotFound,
+	408: connect.CodeDeadlineExceeded,
+	409: connect.CodeAlreadyExists,
+	412: connect.CodeFailedPrecondition,
+	422: connect.CodeInvalidArgument,
+	429: connect.CodeResourceExhausted,
+	499: connect.CodeCanceled,
+	500: connect.CodeInternal,
+	501: connect.CodeUnimplemented,
+	503: connect.CodeUnavailable,
+	504: connect.CodeDeadlineExceeded,
+}
+
+// statusByCode maps Connect codes to HTTP-style error codes and types
+var statusByCode = map[connect.Code]struct {
+	code      int64
+	errorType string
+}{
+	connect.CodeCanceled:           {499, "CLIENT_CLOSED_REQUEST"},
+	connect.CodeUnknown:            {500, "INTERNAL_SERVER_ERROR"},
+	connect.CodeInvalidArgument:    {400, "BAD_REQUEST"},
+	connect.CodeDeadlineExceeded:   {504, "GATEWAY_TIMEOUT"},
+	connect.CodeNotFound:           {404, "NOT_FOUND"},
+	connect.CodeAlreadyExists:      {409, "CONFLICT"},
+	connect.CodePermissionDenied:   {403, "FORBIDDEN"},
+	connect.CodeResourceExhausted:  {429, "TOO_MANY_REQUEST"},
+	connect.CodeFailedPrecondition: {412, "PRECONDITION_FAILED"},
+	connect.CodeAborted:            {409, "CONFLICT"},
+	connect.CodeOutOfRange:         {400, "BAD_REQUEST"},
+	connect.CodeUnimplemented:      {501, "NOT_IMPLEMENTED"},
+	connect.CodeInternal:           {500, "INTERNAL_SERVER_ERROR"},
+	connect.CodeUnavailable:        {503, "SERVICE_UNAVAILABLE"},
+	connect.CodeDataLoss:           {500, "INTERNAL_SERVER_ERROR"},
+	connect.CodeUnauthenticated:    {401, "UNAUTHORIZED"},
+}
+
+// Code returns the Connect code for err: its GRPCCode when set, since Connect codes are the gRPC
+// codes, otherwise a mapping of its HTTP status (see errors.HTTPStatus) where other 4xx statuses
+// become CodeFailedPrecondition and everything else CodeInternal
+func Code(err *errors.Error) connect.Code {
+	if err.GRPCCode != 0 {
+		return connect.Code(err.GRPCCode)
+	}
+	status := int64(errors.HTTPStatus(err))
+	if code, ok := codeByStatus[status]; ok {
+		return code
+	}
+	if status >= 400 && status < 500 {
+		return connect.CodeFailedPrecondition
+	}
+	return connect.CodeInternal
+}
+
+// ToConnect converts the Public() copy of err into a Connect error. The type, code and ID travel
+// in an ErrorInfo detail, violations in a google.rpc.BadRequest detail and RetryAfter in a
+// RetryInfo detail.
+func ToConnect(err *errors.Error) *connect.Error {
+	public := err.Public()
+	connectErr := connect.NewError(Code(public), stderrors.New(public.Message))
+
+	info := &errdetails.ErrorInfo{
+		Reason:   public.Type,
+		Domain:   ErrorInfoDomain,
+		Metadata: map[string]string{"code": strconv.FormatInt(public.Code, 10)},
+	}
+	if public.ID != "" {
+		info.Metadata["id"] = public.ID
+	}
+	details := []proto.Message{info}
+
+	if len(public.Violations) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, v := range public.Violations {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       v.Field,
+				Description: v.Message,
+				Reason:      string(v.Type),
+			})
+		}
+		details = append(details, badRequest)
+	}
+
+	if public.RetryAfter > 0 {
+		details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(public.RetryAfter)})
+	}
+
+	for _, detail := range details {
+		if d, detailErr := connect.NewErrorDetail(detail); detailErr == nil {
+			connectErr.AddDetail(d)
+		}
+	}
+	return connectErr
+}
+
+// FromConnect converts a Connect error into an *errors.Error, restoring the type, code, ID,
+// violations and retry delay when the error carries this package's details. A nil error
+// returns nil.
+func FromConnect(connectErr *connect.Error) *errors.Error {
+	if connectErr == nil {
+		return nil
+	}
+
+	mapped, ok := statusByCode[connectErr.Code()]
+	if !ok {
+		mapped = statusByCode[connect.CodeUnknown]
+	}
+
+	return errors.NewWith(mapped.code, connectErr.Message(), mapped.errorType, restoreConnect(connectErr))
+}
+
+// FromError converts err into an *errors.Error: *errors.Error values in the chain are returned
+// as is, Connect errors are converted with FromConnect and anything else is wrapped
+func FromError(err error) *errors.Error {
+	if err == nil {
+		return nil
+	}
+
+	var e *errors.Error
+	if stderrors.As(err, &e) {
+		return e
+	}
+	var connectErr *connect.Error
+	if stderrors.As(err, &connectErr) {
+		return FromConnect(connectErr)
+	}
+	return errors.Wrap(err)
+}
+
+// NewInterceptor returns an interceptor converting errors in both directions: on handlers,
+// returned *errors.Error values become Connect errors (Connect errors pass through unchanged and
+// any other error becomes a generic CodeInternal); on clients, received Connect errors become
+// *errors.Error, so errors.Is and errors.As work across service boundaries
+func NewInterceptor() connect.Interceptor {
+	return interceptor{}
+}
+
+// interceptor implements connect.Interceptor
+type interceptor struct{}
+
+func (interceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		resp, err := next(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+		if req.Spec().IsClient {
+			return resp, fromConnectError(err)
+		}
+		return resp, toConnectError(err)
+	}
+}
+
+func (interceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return func(ctx context.Context, spec connect.Spec) connect.StreamingClientConn {
+		return &clientConn{StreamingClientConn: next(ctx, spec)}
+	}
+}
+
+func (interceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		return toConnectError(next(ctx, conn))
+	}
+}
+
+// clientConn converts Connect errors returned by the wrapped stream
+type clientConn struct {
+	connect.StreamingClientConn
+}
+
+func (c *clientConn) Send(m any) error {
+	return fromConnectError(c.StreamingClientConn.Send(m))
+}
+
+func (c *clientConn) Receive(m any) error {
+	return fromConnectError(c.StreamingClientConn.Receive(m))
+}
+
+func (c *clientConn) CloseResponse() error {
+	return fromConnectError(c.StreamingClientConn.CloseResponse())
+}
+
+// toConnectError converts err for returning from a Connect handler
+func toConnectError(err error) error {
+	if _, ok := err.(*connect.Error); ok || err == nil {
+		return err
+	}
+	var e *errors.Error
+	if !stderrors.As(err, &e) {
+		e = errors.Wrap(err)
+	}
+	return ToConnect(e)
+}
+
+// fromConnectError converts Connect errors and leaves other errors (such as io.EOF) untouched
+func fromConnectError(err error) error {
+	var connectErr *connect.Error
+	if !stderrors.As(err, &connectErr) {
+		return err
+	}
+	return FromConnect(connectErr)
+}
+
+// restoreConnect applies the gRPC code, cause and this package's details carried by connectErr
+func restoreConnect(connectErr *connect.Error) errors.Override {
+	return func(e *errors.Error) {
+		e.Err = connectErr
+		e.GRPCCode = uint32(connectErr.Code())
+
+		for _, detail := range connectErr.Details() {
+			value, err := detail.Value()
+			if err != nil {
+				continue
+			}
+			switch d := value.(type) {
+			case *errdetails.ErrorInfo:
+				if d.GetDomain() != ErrorInfoDomain {
+					continue
+				}
+				e.Type = d.GetReason()
+				if code, err := strconv.ParseInt(d.GetMetadata()["code"], 10, 64); err == nil {
+					e.Code = code
+				}
+				e.ID = d.GetMetadata()["id"]
+			case *errdetails.BadRequest:
+				for _, v := range d.GetFieldViolations() {
+					e.Violations = append(e.Violations, errors.ValidationError{
+						Type:    errors.ViolationErrorType(v.GetReason()),
+						Field:   v.GetField(),
+						Message: v.GetDescription(),
+					})
+				}
+			case *errdetails.RetryInfo:
+				e.WithRetryAfter(d.GetRetryDelay().AsDuration())
+			}
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package errorsconnect
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	errors "github.com/andryhardiyanto/go-errors"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestConnectRoundTrip(t *testing.T) {
+	original := errors.Violations([]errors.ValidationError{
+		{Type: errors.ViolationErrorTypeRequired, Field: "email", Message: "Email is required"},
+	}).WithRetryAfter(time.Second).WithInternalMessage("secret")
+	original.ID = "abc"
+
+	connectErr := ToConnect(original)
+	if connectErr.Code() != connect.CodeInvalidArgument || connectErr.Message() != "Unprocessable entity" {
+		t.Errorf("Unexpected Connect error %v", connectErr)
+	}
+
+	decoded := FromConnect(connectErr)
+	if decoded.Type != "UNPROCESSABLE_ENTITY" || decoded.Code != 422 || decoded.ID != "abc" {
+		t.Errorf("Unexpected decoded error %s/%d/%s", decoded.Type, decoded.Code, decoded.ID)
+	}
+	if len(decoded.Violations) != 1 || decoded.Violations[0].Field != "email" || decoded.Violations[0].Type != errors.ViolationErrorTypeRequired {
+		t.Errorf("Violations should round-trip, got %+v", decoded.Violations)
+	}
+	if decoded.RetryAfter != time.Second || decoded.InternalMessage != "" {
+		t.Error("Retry delay should round-trip and internal details should not")
+	}
+}
+
+func TestFromConnectPlain(t *testing.T) {
+	e := FromConnect(connect.NewError(connect.CodeUnavailable, stderrors.New("upstream down")))
+	if e.Type != "SERVICE_UNAVAILABLE" || e.Code != 503 || e.Message != "upstream down" {
+		t.Errorf("Unexpected error %s/%d/%s", e.Type, e.Code, e.Message)
+	}
+	if FromConnect(nil) != nil {
+		t.Error("nil should convert to nil")
+	}
+}
+
+func TestCode(t *testing.T) {
+	e := errors.New(10423, "Insufficient funds", "INSUFFICIENT_FUNDS")
+	if Code(e) != connect.CodeInternal {
+		t.Errorf("Non-HTTP code should map to CodeInternal, got %v", Code(e))
+	}
+
+	e.Status = 402
+	if Code(e) != connect.CodeFailedPrecondition {
+		t.Errorf("Other 4xx statuses should map to CodeFailedPrecondition, got %v", Code(e))
+	}
+
+	e.GRPCCode = uint32(connect.CodeAborted)
+	if Code(e) != connect.CodeAborted {
+		t.Errorf("GRPCCode should take precedence, got %v", Code(e))
+	}
+}
+
+func TestInterceptor(t *testing.T) {
+	const procedure = "/test.v1.TestService/Do"
+
+	tests := []struct {
+		name     string
+		err      error
+		wantType string
+		wantCode int64
+	}{
+		{"package error", fmt.Errorf("handler: %w", errors.ErrorConflict()), "CONFLICT", 409},
+		{"connect error", connect.NewError(connect.CodeNotFound, stderrors.New("missing")), "NOT_FOUND", 404},
+		{"other error", stderrors.New("secret failure"), "INTERNAL_SERVER_ERROR", 500},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.Handle(procedure, connect.NewUnaryHandler(procedure,
+				func(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[emptypb.Empty], error) {
+					return nil, tt.err
+				},
+				connect.WithInterceptors(NewInterceptor()),
+			))
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			client := connect.NewClient[emptypb.Empty, emptypb.Empty](server.Client(), server.URL+procedure,
+				connect.WithInterceptors(NewInterceptor()))
+			_, err := client.CallUnary(context.Background(), connect.NewRequest(&emptypb.Empty{}))
+
+			var e *errors.Error
+			if !stderrors.As(err, &e) || e.Type != tt.wantType || e.Code != tt.wantCode {
+				t.Fatalf("Expected %s/%d, got %v", tt.wantType, tt.wantCode, err)
+			}
+			if e.Message == "secret failure" {
+				t.Error("Messages of other errors should not be exposed")
+			}
+		})
+	}
+}
@@ -0,0 +1,12 @@
+module github.com/andryhardiyanto/go-errors/errorsconnect
+
+go 1.26.2
+
+require (
+	connectrpc.com/connect v1.21.0
+	github.com/andryhardiyanto/go-errors v0.0.0
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20260921155816-b14227669459
+	google.golang.org/protobuf v1.36.12
+)
+
+replace github.com/andryhardiyanto/go-errors => ../
@@ -0,0 +1,86 @@
+// Package errorsecho integrates *errors.Error with the Echo web framework.
+package errorsecho
+
+import (
+	stderrors "errors"
+	"fmt"
+	"net/http"
+
+	errors "github.com/andryhardiyanto/go-errors"
+	"github.com/labstack/echo/v4"
+)
+
+type (
+	// Option customizes the error handler
+	Option func(*options)
+
+	options struct {
+		onError func(c echo.Context, err *errors.Error)
+	}
+)
+
+// WithOnError sets a hook called with the complete error before the handler writes the
+// response, for example to log it with its internal message and stack
+func WithOnError(fn func(c echo.Context, err *errors.Error)) Option {
+	return func(o *options) {
+		o.onError = fn
+	}
+}
+
+// HTTPErrorHandler returns an echo.HTTPErrorHandler that writes *errors.Error values with Code as
+// the status and their envelope (see errors.NewEnvelope), including violations, as the JSON body.
+// *echo.HTTPError values (routing errors, binder errors, ...) are converted first.
+//
+//	e := echo.New()
+//	e.HTTPErrorHandler = errorsecho.HTTPErrorHandler()
+func HTTPErrorHandler(opts ...Option) echo.HTTPErrorHandler {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return func(err error, c echo.Context) {
+		if c.Response().Committed {
+			return
+		}
+
+		e := FromError(err)
+		if o.onError != nil {
+			o.onError(c, e)
+		}
+
+		status := errors.HTTPStatus(e)
+		for key, values := range errors.ResponseHeaders(e) {
+			c.Response().Header()[key] = values
+		}
+		if c.Request().Method == http.MethodHead {
+			_ = c.NoContent(status)
+		} else {
+			_ = c.JSON(status, errors.NewEnvelope(e, errors.RequestCapabilities(c.Request())))
+		}
+		errors.NotifyRendered(e)
+	}
+}
+
+// FromError returns the first *errors.Error in err's chain, converts an *echo.HTTPError,
+// or wraps any other error
+func FromError(err error) *errors.Error {
+	var e *errors.Error
+	if stderrors.As(err, &e) {
+		return e
+	}
+
+	var httpErr *echo.HTTPError
+	if stderrors.As(err, &httpErr) {
+		e = errors.FromHTTPStatus(httpErr.Code, func(e *errors.Error) {
+			e.Message = fmt.Sprint(httpErr.Message)
+			e.Err = httpErr.Internal
+		})
+		if e == nil {
+			return errors.Wrap(err)
+		}
+		return e
+	}
+
+	return errors.Wrap(err)
+}
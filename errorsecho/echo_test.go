@@ -0,0 +1,93 @@
+package errorsecho
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	errors "github.com/andryhardiyanto/go-errors"
+	"github.com/labstack/echo/v4"
+)
+
+func serve(t *testing.T, method, path string, handler echo.HandlerFunc, opts ...Option) (*httptest.ResponseRecorder, map[string]any) {
+	t.Helper()
+
+	e := echo.New()
+	e.HTTPErrorHandler = HTTPErrorHandler(opts...)
+	e.GET("/", handler)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+
+	body := map[string]any{}
+	if rec.Body.Len() > 0 {
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("Response is not JSON: %s", rec.Body.String())
+		}
+	}
+	return rec, body
+}
+
+func TestHTTPErrorHandlerError(t *testing.T) {
+	var reported *errors.Error
+	rec, body := serve(t, http.MethodGet, "/", func(c echo.Context) error {
+		return fmt.Errorf("validating: %w", errors.Violations([]errors.ValidationError{
+			{Type: errors.ViolationErrorTypeRequired, Field: "email", Message: "Email is required"},
+		}))
+	}, WithOnError(func(c echo.Context, err *errors.Error) {
+		reported = err
+	}))
+
+	if rec.Code != http.StatusUnprocessableEntity || body["type"] != "UNPROCESSABLE_ENTITY" {
+		t.Errorf("Unexpected response %d %v", rec.Code, body)
+	}
+	if violations, _ := body["violations"].([]any); len(violations) != 1 {
+		t.Errorf("Expected violations in body, got %v", body)
+	}
+	if reported == nil {
+		t.Error("OnError callback should be invoked")
+	}
+}
+
+func TestHTTPErrorHandlerEchoError(t *testing.T) {
+	rec, body := serve(t, http.MethodGet, "/missing", nil)
+	if rec.Code != http.StatusNotFound || body["type"] != "NOT_FOUND" || body["message"] != "Not Found" {
+		t.Errorf("Unexpected response %d %v", rec.Code, body)
+	}
+
+	rec, _ = serve(t, http.MethodGet, "/", func(c echo.Context) error {
+		return echo.NewHTTPError(http.StatusTeapot, "short and stout")
+	})
+	if rec.Code != http.StatusTeapot {
+		t.Errorf("Expected 418, got %d", rec.Code)
+	}
+}
+
+func TestHTTPErrorHandlerPlainError(t *testing.T) {
+	rec, body := serve(t, http.MethodGet, "/", func(c echo.Context) error {
+		return fmt.Errorf("pq: connection refused")
+	})
+	if rec.Code != http.StatusInternalServerError || body["message"] != "An internal server error occurred" {
+		t.Errorf("Unexpected response %d %v", rec.Code, body)
+	}
+}
+
+func TestFromErrorEchoStatus(t *testing.T) {
+	e := FromError(echo.NewHTTPError(http.StatusMethodNotAllowed, "not here"))
+	if e.Type != "METHOD_NOT_ALLOWED" || e.Code != 405 || e.Message != "not here" {
+		t.Errorf("Unexpected error %s/%d/%s", e.Type, e.Code, e.Message)
+	}
+}
+
+func TestFromErrorHooksSeeMessage(t *testing.T) {
+	var seen string
+	handle := errors.RegisterHook(func(e *errors.Error) { seen = e.Message })
+	defer handle.Remove()
+
+	FromError(echo.NewHTTPError(http.StatusMethodNotAllowed, "not here"))
+	if seen != "not here" {
+		t.Errorf("Hooks should observe the echo message, got %q", seen)
+	}
+}
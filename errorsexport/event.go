@@ -0,0 +1,63 @@
+// Package errorsexport batches error events into analytical stores such as ClickHouse, BigQuery
+// or a data lake, through a Sink writing JSON lines.
+package errorsexport
+
+import (
+	stderrors "errors"
+	"fmt"
+	"time"
+
+	errors "github.com/andryhardiyanto/go-errors"
+)
+
+// Event is the flat, warehouse-friendly row exported for an error.
+// Field values are stringified so they fit a Map(String, String) column.
+type Event struct {
+	Timestamp       time.Time                `json:"timestamp"`
+	Service         string                   `json:"service,omitempty"`
+	ID              string                   `json:"id,omitempty"`
+	Type            string                   `json:"type"`
+	Code            int64                    `json:"code"`
+	Message         string                   `json:"message"`
+	Severity        string                   `json:"severity"`
+	InternalMessage string                   `json:"internal_message,omitempty"`
+	MessageTemplate string                   `json:"message_template,omitempty"`
+	Fingerprint     string                   `json:"fingerprint"`
+	Cause           string                   `json:"cause,omitempty"`
+	Violations      []errors.ValidationError `json:"violations"`
+	Fields          map[string]string        `json:"fields"`
+	StackTraces     []string                 `json:"stack_traces"`
+}
+
+// NewEvent builds the event for err at the given time. The first *errors.Error in the chain is
+// exported; other errors are wrapped.
+func NewEvent(err error, service string, at time.Time) Event {
+	var e *errors.Error
+	if !stderrors.As(err, &e) {
+		e = errors.Wrap(err)
+	}
+
+	event := Event{
+		Timestamp:       at.UTC(),
+		Service:         service,
+		ID:              e.ID,
+		Type:            e.Type,
+		Code:            e.Code,
+		Message:         e.Message,
+		Severity:        e.LogSeverity().String(),
+		InternalMessage: e.InternalMessage,
+		MessageTemplate: e.MessageTemplate,
+		Fingerprint:     e.Fingerprint(),
+		Violations:      append(make([]errors.ValidationError, 0, len(e.Violations)), e.Violations...),
+		Fields:          make(map[string]string, len(e.Fields)),
+		StackTraces:     append(make([]string, 0, len(e.StackTraces)), e.StackTraces...),
+	}
+	if e.Err != nil {
+		event.Cause = e.Err.Error()
+	}
+	for key, value := range e.Fields {
+		event.Fields[key] = fmt.Sprint(value)
+	}
+
+	return event
+}
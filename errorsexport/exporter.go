@@ -0,0 +1,260 @@
+package errorsexport
+
+import (
+	"context"
+	stderrors "errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	// ErrDropped is returned by Export when the queue is full and the event was dropped
+	ErrDropped = stderrors.New("errorsexport: queue full, event dropped")
+
+	// ErrClosed is returned by Export after Close
+	ErrClosed = stderrors.New("errorsexport: exporter closed")
+)
+
+type (
+	// Option customizes an Exporter
+	Option func(*options)
+
+	options struct {
+		batchSize     int
+		flushInterval time.Duration
+		queueSize     int
+		block         bool
+		service       string
+		onSinkError   func(err error, events []Event)
+	}
+
+	// Stats are the exporter's counters since creation
+	Stats struct {
+		// Exported events were written by the sink
+		Exported uint64
+		// Dropped events were rejected because the queue was full
+		Dropped uint64
+		// Failed events were part of a batch the sink failed to write
+		Failed uint64
+		// Queued events are waiting to be written
+		Queued int
+	}
+
+	// Exporter queues error events and writes them to a Sink in batches, when a batch is full or
+	// at every flush interval. The queue is bounded: by default events are dropped when it is full,
+	// with WithBlocking callers wait instead. It is safe for concurrent use.
+	Exporter struct {
+		sink    Sink
+		opts    options
+		queue   chan Event
+		flushes chan chan error
+		done    chan struct{}
+		stopped chan struct{}
+		once    sync.Once
+
+		exported atomic.Uint64
+		dropped  atomic.Uint64
+		failed   atomic.Uint64
+	}
+)
+
+// WithBatchSize sets the maximum number of events per sink write (default 500)
+func WithBatchSize(n int) Option {
+	return func(o *options) {
+		o.batchSize = n
+	}
+}
+
+// WithFlushInterval sets how often partial batches are written (default 1s)
+func WithFlushInterval(d time.Duration) Option {
+	return func(o *options) {
+		o.flushInterval = d
+	}
+}
+
+// WithQueueSize sets the number of events buffered before backpressure applies (default 10000)
+func WithQueueSize(n int) Option {
+	return func(o *options) {
+		o.queueSize = n
+	}
+}
+
+// WithBlocking makes Export wait for queue space, up to its context deadline, instead of dropping
+func WithBlocking() Option {
+	return func(o *options) {
+		o.block = true
+	}
+}
+
+// WithService sets the service name recorded on every event
+func WithService(name string) Option {
+	return func(o *options) {
+		o.service = name
+	}
+}
+
+// WithOnSinkError sets a callback invoked with the events of every batch the sink failed to write
+func WithOnSinkError(fn func(err error, events []Event)) Option {
+	return func(o *options) {
+		o.onSinkError = fn
+	}
+}
+
+// NewExporter creates an exporter writing to sink and starts its background worker.
+// Call Close to flush the remaining events and stop it.
+func NewExporter(sink Sink, opts ...Option) *Exporter {
+	o := options{batchSize: 500, flushInterval: time.Second, queueSize: 10000}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	x := &Exporter{
+		sink:    sink,
+		opts:    o,
+		queue:   make(chan Event, o.queueSize),
+		flushes: make(chan chan error),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	go x.run()
+
+	return x
+}
+
+// Export queues err for export. It returns ErrDropped when the queue is full (or, with
+// WithBlocking, the context's error when ctx ends first) and ErrClosed after Close.
+// A nil error is ignored.
+func (x *Exporter) Export(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	select {
+	case <-x.done:
+		return ErrClosed
+	default:
+	}
+
+	event := NewEvent(err, x.opts.service, time.Now())
+
+	if !x.opts.block {
+		select {
+		case x.queue <- event:
+			return nil
+		default:
+			x.dropped.Add(1)
+			return ErrDropped
+		}
+	}
+
+	select {
+	case x.queue <- event:
+		return nil
+	case <-x.done:
+		return ErrClosed
+	case <-ctx.Done():
+		x.dropped.Add(1)
+		return ctx.Err()
+	}
+}
+
+// Flush writes every queued event and returns the sink's error, if any
+func (x *Exporter) Flush(ctx context.Context) error {
+	reply := make(chan error, 1)
+	select {
+	case x.flushes <- reply:
+	case <-x.stopped:
+		return ErrClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case err := <-reply:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stops accepting events, writes the queued ones and waits for the worker until ctx ends
+func (x *Exporter) Close(ctx context.Context) error {
+	x.once.Do(func() { close(x.done) })
+
+	select {
+	case <-x.stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Stats returns the current counters
+func (x *Exporter) Stats() Stats {
+	return Stats{
+		Exported: x.exported.Load(),
+		Dropped:  x.dropped.Load(),
+		Failed:   x.failed.Load(),
+		Queued:   len(x.queue),
+	}
+}
+
+// run batches queued events until Close
+func (x *Exporter) run() {
+	defer close(x.stopped)
+
+	ticker := time.NewTicker(x.opts.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]Event, 0, x.opts.batchSize)
+	write := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		err := x.sink.Write(context.Background(), batch)
+		if err != nil {
+			x.failed.Add(uint64(len(batch)))
+			if x.opts.onSinkError != nil {
+				x.opts.onSinkError(err, batch)
+			}
+		} else {
+			x.exported.Add(uint64(len(batch)))
+		}
+		// The sink may retain the slice, so start a new one
+		batch = make([]Event, 0, x.opts.batchSize)
+		return err
+	}
+	drain := func() error {
+		var errs []error
+		for {
+			select {
+			case event := <-x.queue:
+				batch = append(batch, event)
+				if len(batch) >= x.opts.batchSize {
+					errs = append(errs, write())
+				}
+			default:
+				return stderrors.Join(append(errs, write())...)
+			}
+		}
+	}
+
+	for {
+		select {
+		case event := <-x.queue:
+			batch = append(batch, event)
+			if len(batch) >= x.opts.batchSize {
+				_ = write()
+			}
+		case <-ticker.C:
+			_ = write()
+		case reply := <-x.flushes:
+			reply <- drain()
+		case <-x.done:
+			_ = drain()
+			return
+		}
+	}
+}
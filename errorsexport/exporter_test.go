@@ -0,0 +1,142 @@
+package errorsexport
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	errors "github.com/andryhardiyanto/go-errors"
+)
+
+func TestExporterJSONL(t *testing.T) {
+	var buf bytes.Buffer
+	x := NewExporter(NewJSONLSink(&buf), WithBatchSize(2), WithService("checkout"))
+
+	for range 3 {
+		if err := x.Export(context.Background(), errors.ErrorNotFound().WithField("order_id", 42)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := x.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	scanner := bufio.NewScanner(&buf)
+	lines := 0
+	for scanner.Scan() {
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatal(err)
+		}
+		if event.Type != "NOT_FOUND" || event.Service != "checkout" || event.Fields["order_id"] != "42" || event.Fingerprint == "" {
+			t.Errorf("Unexpected event %+v", event)
+		}
+		lines++
+	}
+	if lines != 3 || x.Stats().Exported != 3 {
+		t.Errorf("Expected 3 exported events, got %d lines and %+v", lines, x.Stats())
+	}
+	if x.Export(context.Background(), errors.ErrorConflict()) != ErrClosed {
+		t.Error("Export after Close should fail")
+	}
+}
+
+func TestExporterBackpressure(t *testing.T) {
+	release := make(chan struct{})
+	sink := SinkFunc(func(context.Context, []Event) error {
+		<-release
+		return nil
+	})
+	x := NewExporter(sink, WithBatchSize(1), WithQueueSize(1))
+
+	// The first event is taken by the blocked sink, the second fills the queue
+	_ = x.Export(context.Background(), errors.ErrorConflict())
+	deadline := time.Now().Add(time.Second)
+	for x.Stats().Queued != 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	_ = x.Export(context.Background(), errors.ErrorConflict())
+
+	if err := x.Export(context.Background(), errors.ErrorConflict()); err != ErrDropped {
+		t.Errorf("Expected ErrDropped, got %v", err)
+	}
+	if x.Stats().Dropped != 1 {
+		t.Errorf("Expected 1 dropped event, got %+v", x.Stats())
+	}
+
+	close(release)
+	_ = x.Close(context.Background())
+	if x.Stats().Exported != 2 {
+		t.Errorf("Expected 2 exported events, got %+v", x.Stats())
+	}
+}
+
+func TestExporterBlocking(t *testing.T) {
+	release := make(chan struct{})
+	x := NewExporter(SinkFunc(func(context.Context, []Event) error {
+		<-release
+		return nil
+	}), WithBatchSize(1), WithQueueSize(1), WithBlocking())
+	defer func() {
+		close(release)
+		_ = x.Close(context.Background())
+	}()
+
+	_ = x.Export(context.Background(), errors.ErrorConflict())
+	_ = x.Export(context.Background(), errors.ErrorConflict())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := x.Export(ctx, errors.ErrorConflict()); err != context.DeadlineExceeded {
+		t.Errorf("Blocking export should wait for the context, got %v", err)
+	}
+}
+
+func TestHTTPSink(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		query string
+		body  []byte
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		query = r.URL.Query().Get("query")
+		body, _ = io.ReadAll(r.Body)
+		if r.Header.Get("X-ClickHouse-Key") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	sink := NewHTTPSink(server.URL+"/?query=INSERT+INTO+errors+FORMAT+JSONEachRow", nil, http.Header{"X-Clickhouse-Key": {"secret"}})
+	x := NewExporter(sink)
+	_ = x.Export(context.Background(), fmt.Errorf("boom"))
+	if err := x.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	if query != "INSERT INTO errors FORMAT JSONEachRow" || !bytes.Contains(body, []byte(`"cause":"boom"`)) {
+		t.Errorf("Unexpected request %q: %s", query, body)
+	}
+	mu.Unlock()
+
+	failing := NewHTTPSink(server.URL, nil, nil)
+	var failed []Event
+	y := NewExporter(failing, WithOnSinkError(func(_ error, events []Event) { failed = events }))
+	_ = y.Export(context.Background(), errors.ErrorConflict())
+	if err := y.Flush(context.Background()); err == nil || len(failed) != 1 || y.Stats().Failed != 1 {
+		t.Errorf("Expected a sink failure, got %v and %+v", err, y.Stats())
+	}
+	_ = y.Close(context.Background())
+	_ = x.Close(context.Background())
+}
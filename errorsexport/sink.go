@@ -0,0 +1,109 @@
+package errorsexport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+type (
+	// Sink writes a batch of events to a destination. Write is never called concurrently by an Exporter.
+	Sink interface {
+		Write(ctx context.Context, events []Event) error
+	}
+
+	// SinkFunc adapts a function to the Sink interface
+	SinkFunc func(ctx context.Context, events []Event) error
+
+	// JSONLSink writes events as JSON lines to an io.Writer. It is safe for concurrent use.
+	JSONLSink struct {
+		mu sync.Mutex
+		w  io.Writer
+	}
+
+	// HTTPSink posts each batch as a JSON lines body to a bulk endpoint, e.g. ClickHouse's
+	// "/?query=INSERT INTO errors FORMAT JSONEachRow"
+	HTTPSink struct {
+		url    string
+		client *http.Client
+		header http.Header
+	}
+)
+
+// Write calls f(ctx, events)
+func (f SinkFunc) Write(ctx context.Context, events []Event) error {
+	return f(ctx, events)
+}
+
+// NewJSONLSink creates a sink writing JSON lines to w
+func NewJSONLSink(w io.Writer) *JSONLSink {
+	return &JSONLSink{w: w}
+}
+
+// Write encodes the batch and writes it with a single Write call
+func (s *JSONLSink) Write(_ context.Context, events []Event) error {
+	body, err := encodeJSONL(events)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err = s.w.Write(body)
+	return err
+}
+
+// NewHTTPSink creates a sink posting to url with client, or http.DefaultClient when nil.
+// header is added to every request, e.g. for authentication.
+func NewHTTPSink(url string, client *http.Client, header http.Header) *HTTPSink {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &HTTPSink{url: url, client: client, header: header}
+}
+
+// Write posts the batch; any non-2xx response is an error
+func (s *HTTPSink) Write(ctx context.Context, events []Event) error {
+	body, err := encodeJSONL(events)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for key, values := range s.header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("errorsexport: bulk endpoint returned %d: %s", resp.StatusCode, bytes.TrimSpace(detail))
+	}
+	return nil
+}
+
+// encodeJSONL encodes events as newline-terminated JSON objects
+func encodeJSONL(events []Event) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, event := range events {
+		if err := encoder.Encode(event); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,84 @@
+// Package errorsfiber integrates *errors.Error with the Fiber web framework.
+package errorsfiber
+
+import (
+	stderrors "errors"
+
+	errors "github.com/andryhardiyanto/go-errors"
+	"github.com/gofiber/fiber/v3"
+)
+
+type (
+	// Option customizes the error handler
+	Option func(*options)
+
+	options struct {
+		onError func(c fiber.Ctx, err *errors.Error)
+	}
+)
+
+// WithOnError sets a callback that sees every handled error in full, e.g. to report it,
+// before the public copy is sent
+func WithOnError(fn func(c fiber.Ctx, err *errors.Error)) Option {
+	return func(o *options) {
+		o.onError = fn
+	}
+}
+
+// ErrorHandler returns a fiber.ErrorHandler that writes *errors.Error values with Code as the
+// status and their envelope (see errors.NewEnvelope) as the JSON body. *fiber.Error values are converted first.
+//
+//	app := fiber.New(fiber.Config{ErrorHandler: errorsfiber.ErrorHandler()})
+func ErrorHandler(opts ...Option) fiber.ErrorHandler {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return func(c fiber.Ctx, err error) error {
+		e := FromError(err)
+		if o.onError != nil {
+			o.onError(c, e)
+		}
+		for key, values := range errors.ResponseHeaders(e) {
+			c.Set(key, values[0])
+		}
+		writeErr := c.Status(errors.HTTPStatus(e)).JSON(errors.NewEnvelope(e, errors.ParseCapabilities(c.Get(errors.CapabilitiesHeader))))
+		errors.NotifyRendered(e)
+		return writeErr
+	}
+}
+
+// Recover returns a middleware converting panics into errors.ErrorPanic() with the panicking
+// goroutine's stack trace. The error is returned to Fiber so the configured ErrorHandler renders it.
+func Recover() fiber.Handler {
+	return func(c fiber.Ctx) (err error) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				err = errors.FromPanic(recovered)
+			}
+		}()
+
+		return c.Next()
+	}
+}
+
+// FromError returns the first *errors.Error in err's chain, converts a *fiber.Error,
+// or wraps any other error
+func FromError(err error) *errors.Error {
+	var e *errors.Error
+	if stderrors.As(err, &e) {
+		return e
+	}
+
+	var fiberErr *fiber.Error
+	if stderrors.As(err, &fiberErr) {
+		e = errors.FromHTTPStatus(fiberErr.Code, errors.OverrideMessage(fiberErr.Message))
+		if e == nil {
+			return errors.Wrap(err)
+		}
+		return e
+	}
+
+	return errors.Wrap(err)
+}
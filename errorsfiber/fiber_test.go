@@ -0,0 +1,71 @@
+package errorsfiber
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	errors "github.com/andryhardiyanto/go-errors"
+	"github.com/gofiber/fiber/v3"
+)
+
+func serve(t *testing.T, path string, handler fiber.Handler, opts ...Option) (int, map[string]any) {
+	t.Helper()
+
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler(opts...)})
+	app.Use(Recover())
+	app.Get("/", handler)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, path, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	data, _ := io.ReadAll(resp.Body)
+	body := map[string]any{}
+	if err := json.Unmarshal(data, &body); err != nil {
+		t.Fatalf("Response is not JSON: %s", data)
+	}
+	return resp.StatusCode, body
+}
+
+func TestErrorHandler(t *testing.T) {
+	status, body := serve(t, "/", func(c fiber.Ctx) error {
+		return fmt.Errorf("loading: %w", errors.ErrorNotFound())
+	})
+	if status != http.StatusNotFound || body["type"] != "NOT_FOUND" {
+		t.Errorf("Unexpected response %d %v", status, body)
+	}
+
+	status, body = serve(t, "/missing", func(c fiber.Ctx) error { return nil })
+	if status != http.StatusNotFound || body["type"] != "NOT_FOUND" {
+		t.Errorf("Fiber errors should be converted, got %d %v", status, body)
+	}
+
+	status, body = serve(t, "/", func(c fiber.Ctx) error {
+		return fmt.Errorf("pq: connection refused")
+	})
+	if status != http.StatusInternalServerError || body["message"] != "An internal server error occurred" {
+		t.Errorf("Unexpected response %d %v", status, body)
+	}
+}
+
+func TestRecover(t *testing.T) {
+	var reported *errors.Error
+	status, body := serve(t, "/", func(c fiber.Ctx) error {
+		panic("boom")
+	}, WithOnError(func(c fiber.Ctx, err *errors.Error) {
+		reported = err
+	}))
+
+	if status != http.StatusInternalServerError || body["type"] != "PANIC" {
+		t.Errorf("Unexpected response %d %v", status, body)
+	}
+	if reported == nil || reported.Unwrap().Error() != "boom" || len(reported.StackTraces) == 0 {
+		t.Errorf("Reported error should carry the panic value and stack, got %v", reported)
+	}
+}
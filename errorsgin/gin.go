@@ -0,0 +1,97 @@
+// Package errorsgin integrates *errors.Error with the Gin web framework.
+package errorsgin
+
+import (
+	stderrors "errors"
+
+	errors "github.com/andryhardiyanto/go-errors"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	// Option customizes the middleware
+	Option func(*options)
+
+	options struct {
+		onError func(c *gin.Context, err *errors.Error)
+	}
+)
+
+// WithOnError sets a function receiving each error, internal details included, before the
+// middleware or Abort renders it; use it to log or report
+func WithOnError(fn func(c *gin.Context, err *errors.Error)) Option {
+	return func(o *options) {
+		o.onError = fn
+	}
+}
+
+// Middleware recovers panics into errors.ErrorPanic() and renders the errors collected in
+// c.Errors when the handler did not write a response. Multiple errors are combined.
+func Middleware(opts ...Option) gin.HandlerFunc {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return func(c *gin.Context) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				render(c, errors.FromPanic(recovered), o)
+			}
+		}()
+
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		var e *errors.Error
+		if len(c.Errors) == 1 {
+			e = toError(c.Errors[0].Err)
+		} else {
+			errs := make([]error, 0, len(c.Errors))
+			for _, ginErr := range c.Errors {
+				errs = append(errs, ginErr.Err)
+			}
+			e = errors.Combine(errs...)
+		}
+		render(c, e, o)
+	}
+}
+
+// Abort records err on the context, aborts the chain and writes the error response.
+// Use it in handlers instead of c.AbortWithStatusJSON.
+func Abort(c *gin.Context, err error) {
+	e := toError(err)
+	_ = c.Error(e)
+	writeHeaders(c, e)
+	c.AbortWithStatusJSON(errors.HTTPStatus(e), errors.NewEnvelope(e, errors.RequestCapabilities(c.Request)))
+	errors.NotifyRendered(e)
+}
+
+// render reports the error and writes its public copy
+func render(c *gin.Context, e *errors.Error, o options) {
+	if o.onError != nil {
+		o.onError(c, e)
+	}
+	writeHeaders(c, e)
+	c.AbortWithStatusJSON(errors.HTTPStatus(e), errors.NewEnvelope(e, errors.RequestCapabilities(c.Request)))
+	errors.NotifyRendered(e)
+}
+
+// writeHeaders sets the error response headers, see errors.ResponseHeaders
+func writeHeaders(c *gin.Context, e *errors.Error) {
+	for key, values := range errors.ResponseHeaders(e) {
+		c.Writer.Header()[key] = values
+	}
+}
+
+// toError returns the first *errors.Error in err's chain or wraps err
+func toError(err error) *errors.Error {
+	var e *errors.Error
+	if stderrors.As(err, &e) {
+		return e
+	}
+	return errors.Wrap(err)
+}
@@ -0,0 +1,98 @@
+package errorsgin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	errors "github.com/andryhardiyanto/go-errors"
+	"github.com/gin-gonic/gin"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+func serve(t *testing.T, handler gin.HandlerFunc, opts ...Option) (*httptest.ResponseRecorder, map[string]any) {
+	t.Helper()
+
+	router := gin.New()
+	router.Use(Middleware(opts...))
+	router.GET("/", handler)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	body := map[string]any{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Response is not JSON: %s", rec.Body.String())
+	}
+	return rec, body
+}
+
+func TestMiddlewareRecoversPanic(t *testing.T) {
+	var reported *errors.Error
+	rec, body := serve(t, func(c *gin.Context) {
+		panic("boom")
+	}, WithOnError(func(c *gin.Context, err *errors.Error) {
+		reported = err
+	}))
+
+	if rec.Code != http.StatusInternalServerError || body["type"] != "PANIC" {
+		t.Errorf("Unexpected response %d %v", rec.Code, body)
+	}
+	if reported == nil || reported.Unwrap().Error() != "boom" || len(reported.StackTraces) == 0 {
+		t.Errorf("Reported error should carry the panic value and stack, got %v", reported)
+	}
+}
+
+func TestMiddlewareRendersContextErrors(t *testing.T) {
+	rec, body := serve(t, func(c *gin.Context) {
+		_ = c.Error(fmt.Errorf("loading user: %w", errors.ErrorNotFound()))
+	})
+
+	if rec.Code != http.StatusNotFound || body["type"] != "NOT_FOUND" {
+		t.Errorf("Unexpected response %d %v", rec.Code, body)
+	}
+}
+
+func TestAbort(t *testing.T) {
+	rec, body := serve(t, func(c *gin.Context) {
+		Abort(c, errors.Violations([]errors.ValidationError{
+			{Type: errors.ViolationErrorTypeRequired, Field: "email", Message: "Email is required"},
+		}))
+	})
+
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422, got %d", rec.Code)
+	}
+	if violations, _ := body["violations"].([]any); len(violations) != 1 {
+		t.Errorf("Expected violations in body, got %v", body)
+	}
+}
+
+func TestAbortHidesInternalDetails(t *testing.T) {
+	rec, body := serve(t, func(c *gin.Context) {
+		Abort(c, fmt.Errorf("pq: connection refused"))
+	})
+
+	if rec.Code != http.StatusInternalServerError || body["message"] != "An internal server error occurred" {
+		t.Errorf("Unexpected response %d %v", rec.Code, body)
+	}
+	if traces, _ := body["stack_traces"].([]any); len(traces) != 0 {
+		t.Error("Stack traces should not be rendered")
+	}
+}
+
+func TestAbortRetryAfter(t *testing.T) {
+	rec, _ := serve(t, func(c *gin.Context) {
+		Abort(c, errors.ErrorTooManyRequests().WithRetryAfter(30*time.Second))
+	})
+
+	if rec.Header().Get("Retry-After") != "30" || rec.Header().Get(errors.EnvelopeHeader) == "" {
+		t.Errorf("Expected Retry-After and envelope headers, got %v", rec.Header())
+	}
+}
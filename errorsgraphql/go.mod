@@ -0,0 +1,17 @@
+module github.com/andryhardiyanto/go-errors/errorsgraphql
+
+go 1.26.2
+
+require (
+	github.com/99designs/gqlgen v0.17.95
+	github.com/andryhardiyanto/go-errors v0.0.0
+	github.com/vektah/gqlparser/v2 v2.5.58
+)
+
+require (
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/sosodev/duration v1.4.0 // indirect
+	golang.org/x/sync v0.22.0 // indirect
+)
+
+replace github.com/andryhardiyanto/go-errors => ../
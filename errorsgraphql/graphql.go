@@ -0,0 +1,107 @@
+// Package errorsgraphql renders *errors.Error values as GraphQL errors whose extensions carry the
+// code, type and violations, and provides an error presenter and recover function for gqlgen.
+package errorsgraphql
+
+import (
+	"context"
+	stderrors "errors"
+
+	"github.com/99designs/gqlgen/graphql"
+	errors "github.com/andryhardiyanto/go-errors"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+type (
+	// Option customizes the error presenter
+	Option func(*options)
+
+	options struct {
+		onError func(ctx context.Context, err *errors.Error)
+	}
+)
+
+// WithOnError sets a function receiving each error, internal details included, before the
+// presenter renders it; use it to log or report
+func WithOnError(fn func(ctx context.Context, err *errors.Error)) Option {
+	return func(o *options) {
+		o.onError = fn
+	}
+}
+
+// Extensions returns the GraphQL extensions of e's public copy: "code" and "type", plus "id",
+// "retryable" and "violations" when set
+func Extensions(e *errors.Error) map[string]any {
+	public := e.Public()
+	extensions := map[string]any{
+		"code": public.Code,
+		"type": public.Type,
+	}
+	if public.ID != "" {
+		extensions["id"] = public.ID
+	}
+	if public.Retryable {
+		extensions["retryable"] = true
+	}
+	if len(public.Violations) > 0 {
+		extensions["violations"] = public.Violations
+	}
+	return extensions
+}
+
+// ToGraphQL converts err into a GraphQL error with the public message of the first *errors.Error
+// in its chain and its Extensions. Other errors are classified with errors.Wrap, so their
+// messages are not exposed. nil returns nil.
+func ToGraphQL(err error) *gqlerror.Error {
+	if err == nil {
+		return nil
+	}
+
+	e := errors.DefaultClassifier.Classify(err)
+	return &gqlerror.Error{
+		Err:        e,
+		Message:    e.Public().Message,
+		Extensions: Extensions(e),
+	}
+}
+
+// ErrorPresenter returns a gqlgen error presenter (handler.Server.SetErrorPresenter) rendering
+// errors with ToGraphQL while keeping the path and locations set by gqlgen. Errors produced by
+// gqlgen itself, such as query parsing and validation errors, are presented unchanged.
+func ErrorPresenter(opts ...Option) graphql.ErrorPresenterFunc {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return func(ctx context.Context, err error) *gqlerror.Error {
+		presented := graphql.DefaultErrorPresenter(ctx, err)
+
+		var e *errors.Error
+		var gqlErr *gqlerror.Error
+		if !stderrors.As(err, &e) && stderrors.As(err, &gqlErr) && gqlErr.Err == nil {
+			return presented
+		}
+
+		e = errors.DefaultClassifier.Classify(err)
+		if o.onError != nil {
+			o.onError(ctx, e)
+		}
+		converted := ToGraphQL(e)
+		presented.Err = e
+		presented.Message = converted.Message
+		if presented.Extensions == nil {
+			presented.Extensions = make(map[string]any, len(converted.Extensions))
+		}
+		for key, value := range converted.Extensions {
+			presented.Extensions[key] = value
+		}
+		errors.NotifyRendered(e)
+		return presented
+	}
+}
+
+// RecoverFunc is a gqlgen recover function (handler.Server.SetRecoverFunc) converting resolver
+// panics into errors.ErrorPanic() with the panic value and stack
+func RecoverFunc(ctx context.Context, recovered any) error {
+	return errors.FromPanic(recovered)
+}
@@ -0,0 +1,59 @@
+package errorsgraphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	errors "github.com/andryhardiyanto/go-errors"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+func TestToGraphQL(t *testing.T) {
+	e := errors.Violations([]errors.ValidationError{{Type: errors.ViolationErrorTypeRequired, Field: "input.email", Message: "Email is required"}})
+	e.InternalMessage = "schema check failed"
+
+	gqlErr := ToGraphQL(fmt.Errorf("createUser: %w", e))
+	if gqlErr.Message != "Unprocessable entity" || gqlErr.Extensions["code"] != int64(422) || gqlErr.Extensions["type"] != "UNPROCESSABLE_ENTITY" {
+		t.Errorf("Unexpected error %+v", gqlErr)
+	}
+
+	data, _ := json.Marshal(gqlErr)
+	if !strings.Contains(string(data), `"violations":[{"type":"REQUIRED","field":"input.email"`) || strings.Contains(string(data), "schema check") {
+		t.Errorf("Extensions should carry the public violations only, got %s", data)
+	}
+
+	plain := ToGraphQL(fmt.Errorf("dial tcp: connection refused"))
+	if plain.Message != "An internal server error occurred" || plain.Extensions["type"] != "INTERNAL_SERVER_ERROR" || plain.Extensions["violations"] != nil {
+		t.Errorf("Plain errors should not be exposed, got %+v", plain)
+	}
+	if ToGraphQL(nil) != nil {
+		t.Error("nil should convert to nil")
+	}
+}
+
+func TestErrorPresenter(t *testing.T) {
+	var reported *errors.Error
+	present := ErrorPresenter(WithOnError(func(ctx context.Context, err *errors.Error) { reported = err }))
+
+	e := errors.ErrorNotFound().WithRetryable(true)
+	e.EnsureID()
+	presented := present(context.Background(), e)
+	if presented.Message != "Not found" || presented.Extensions["code"] != int64(404) || presented.Extensions["retryable"] != true || presented.Extensions["id"] != e.ID {
+		t.Errorf("Unexpected presented error %+v", presented)
+	}
+	if reported != e {
+		t.Error("The error should be passed to the OnError function")
+	}
+
+	validation := gqlerror.Errorf("Cannot query field \"nope\" on type \"Query\".")
+	if got := present(context.Background(), validation); got.Message != validation.Message || got.Extensions != nil {
+		t.Errorf("gqlgen errors should be presented unchanged, got %+v", got)
+	}
+
+	if err := RecoverFunc(context.Background(), "boom"); !errors.IsPanic(err) {
+		t.Errorf("Panics should become PANIC errors, got %v", err)
+	}
+}
@@ -0,0 +1,18 @@
+module github.com/andryhardiyanto/go-errors/errorsgrpc
+
+go 1.26.2
+
+require (
+	github.com/andryhardiyanto/go-errors v0.0.0
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20260921155816-b14227669459
+	google.golang.org/grpc v1.84.0
+	google.golang.org/protobuf v1.36.12
+)
+
+require (
+	golang.org/x/net v0.58.0 // indirect
+	golang.org/x/sys v0.48.0 // indirect
+	golang.org/x/text v0.42.0 // indirect
+)
+
+replace github.com/andryhardiyanto/go-errors => ../
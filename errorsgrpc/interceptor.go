@@ -0,0 +1,101 @@
+package errorsgrpc
+
+import (
+	"context"
+	stderrors "errors"
+
+	errors "github.com/andryhardiyanto/go-errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor converts *errors.Error values returned by handlers into status errors.
+// Status errors pass through unchanged and any other error becomes a generic Internal status.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		return resp, toStatusError(err)
+	}
+}
+
+// StreamServerInterceptor is the streaming counterpart of UnaryServerInterceptor.
+// Converted errors are also placed in the trailing metadata, see SetTrailer.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		err := handler(srv, ss)
+		if err != nil && !isStatusError(err) {
+			SetTrailer(ss, asError(err))
+		}
+		return toStatusError(err)
+	}
+}
+
+// UnaryClientInterceptor converts status errors received from servers into *errors.Error
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if err := invoker(ctx, method, req, reply, cc, opts...); err != nil {
+			return FromError(err)
+		}
+		return nil
+	}
+}
+
+// StreamClientInterceptor converts status errors from stream creation, SendMsg and RecvMsg into
+// *errors.Error, reading the trailing metadata when the status lost its details (see FromStreamError).
+// io.EOF from RecvMsg is passed through unchanged.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			return nil, FromError(err)
+		}
+		return &clientStream{ClientStream: stream}, nil
+	}
+}
+
+// clientStream converts status errors returned by the wrapped stream
+type clientStream struct {
+	grpc.ClientStream
+}
+
+func (s *clientStream) SendMsg(m any) error {
+	return fromStatusError(s.ClientStream.SendMsg(m))
+}
+
+func (s *clientStream) RecvMsg(m any) error {
+	return FromStreamError(s.ClientStream, s.ClientStream.RecvMsg(m))
+}
+
+// toStatusError converts err for returning from a gRPC handler
+func toStatusError(err error) error {
+	if err == nil || isStatusError(err) {
+		return err
+	}
+	return ToStatus(asError(err)).Err()
+}
+
+// isStatusError reports whether err is a gRPC status error
+func isStatusError(err error) bool {
+	_, ok := err.(interface{ GRPCStatus() *status.Status })
+	return ok
+}
+
+// asError returns the *errors.Error in err's chain or wraps err
+func asError(err error) *errors.Error {
+	var e *errors.Error
+	if !stderrors.As(err, &e) {
+		e = errors.Wrap(err)
+	}
+	return e
+}
+
+// fromStatusError converts status errors and leaves other errors (such as io.EOF) untouched
+func fromStatusError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if st, ok := status.FromError(err); ok {
+		return FromStatus(st)
+	}
+	return err
+}
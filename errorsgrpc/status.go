@@ -0,0 +1,178 @@
+// Package errorsgrpc converts between *errors.Error and gRPC status errors.
+package errorsgrpc
+
+import (
+	stderrors "errors"
+	"strconv"
+
+	errors "github.com/andryhardiyanto/go-errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// ErrorInfoDomain is the domain of the ErrorInfo detail carrying the error type and code
+const ErrorInfoDomain = "github.com/andryhardiyanto/go-errors"
+
+// codeByStatus maps HTTP-style error codes to gRPC codes
+var codeByStatus = map[int64]codes.Code{
+	400: codes.InvalidArgument,
+	401: codes.Unauthenticated,
+	403: codes.PermissionDenied,
+	404: codes.NotFound,
+	408: codes.DeadlineExceeded,
+	409: codes.AlreadyExists,
+	412: codes.FailedPrecondition,
+	422: codes.InvalidArgument,
+	429: codes.ResourceExhausted,
+	499: codes.Canceled,
+	500: codes.Internal,
+	501: codes.Unimplemented,
+	503: codes.Unavailable,
+	504: codes.DeadlineExceeded,
+}
+
+// statusByCode maps gRPC codes to HTTP-style error codes and types
+var statusByCode = map[codes.Code]struct {
+	code      int64
+	errorType string
+}{
+	codes.Canceled:           {499, "CLIENT_CLOSED_REQUEST"},
+	codes.Unknown:            {500, "INTERNAL_SERVER_ERROR"},
+	codes.InvalidArgument:    {400, "BAD_REQUEST"},
+	codes.DeadlineExceeded:   {504, "GATEWAY_TIMEOUT"},
+	codes.NotFound:           {404, "NOT_FOUND"},
+	codes.AlreadyExists:      {409, "CONFLICT"},
+	codes.PermissionDenied:   {403, "FORBIDDEN"},
+	codes.ResourceExhausted:  {429, "TOO_MANY_REQUEST"},
+	codes.FailedPrecondition: {412, "PRECONDITION_FAILED"},
+	codes.Aborted:            {409, "CONFLICT"},
+	codes.OutOfRange:         {400, "BAD_REQUEST"},
+	codes.Unimplemented:      {501, "NOT_IMPLEMENTED"},
+	codes.Internal:           {500, "INTERNAL_SERVER_ERROR"},
+	codes.Unavailable:        {503, "SERVICE_UNAVAILABLE"},
+	codes.DataLoss:           {500, "INTERNAL_SERVER_ERROR"},
+	codes.Unauthenticated:    {401, "UNAUTHORIZED"},
+}
+
+// Code returns the gRPC code for err: its GRPCCode when set, otherwise a mapping of its HTTP
+// status (see errors.HTTPStatus) where other 4xx statuses become FailedPrecondition and
+// everything else Internal
+func Code(err *errors.Error) codes.Code {
+	if err.GRPCCode != 0 {
+		return codes.Code(err.GRPCCode)
+	}
+	status := int64(errors.HTTPStatus(err))
+	if code, ok := codeByStatus[status]; ok {
+		return code
+	}
+	if status >= 400 && status < 500 {
+		return codes.FailedPrecondition
+	}
+	return codes.Internal
+}
+
+// ToStatus converts the Public() copy of err into a gRPC status. The type, code and ID travel in
+// an ErrorInfo detail, violations in a BadRequest detail and RetryAfter in a RetryInfo detail.
+func ToStatus(err *errors.Error) *status.Status {
+	public := err.Public()
+	st := status.New(Code(public), public.Message)
+
+	info := &errdetails.ErrorInfo{
+		Reason:   public.Type,
+		Domain:   ErrorInfoDomain,
+		Metadata: map[string]string{"code": strconv.FormatInt(public.Code, 10)},
+	}
+	if public.ID != "" {
+		info.Metadata["id"] = public.ID
+	}
+	details := []protoadapt.MessageV1{info}
+
+	if len(public.Violations) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, v := range public.Violations {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       v.Field,
+				Description: v.Message,
+				Reason:      string(v.Type),
+			})
+		}
+		details = append(details, badRequest)
+	}
+
+	if public.RetryAfter > 0 {
+		details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(public.RetryAfter)})
+	}
+
+	if withDetails, detailErr := st.WithDetails(details...); detailErr == nil {
+		return withDetails
+	}
+	return st
+}
+
+// FromStatus converts a gRPC status into an *errors.Error, restoring the type, code, ID,
+// violations and retry delay when the status carries this package's details.
+// A nil or OK status returns nil.
+func FromStatus(st *status.Status) *errors.Error {
+	if st == nil || st.Code() == codes.OK {
+		return nil
+	}
+
+	mapped, ok := statusByCode[st.Code()]
+	if !ok {
+		mapped = statusByCode[codes.Unknown]
+	}
+
+	return errors.NewWith(mapped.code, st.Message(), mapped.errorType, restoreStatus(st))
+}
+
+// FromError converts err into an *errors.Error: *errors.Error values in the chain are returned
+// as is, gRPC status errors are converted with FromStatus and anything else is wrapped
+func FromError(err error) *errors.Error {
+	if err == nil {
+		return nil
+	}
+
+	var e *errors.Error
+	if stderrors.As(err, &e) {
+		return e
+	}
+	if st, ok := status.FromError(err); ok {
+		return FromStatus(st)
+	}
+	return errors.Wrap(err)
+}
+
+// restoreStatus applies the gRPC code, cause and this package's details carried by st
+func restoreStatus(st *status.Status) errors.Override {
+	return func(e *errors.Error) {
+		e.Err = st.Err()
+		e.GRPCCode = uint32(st.Code())
+
+		for _, detail := range st.Details() {
+			switch d := detail.(type) {
+			case *errdetails.ErrorInfo:
+				if d.GetDomain() != ErrorInfoDomain {
+					continue
+				}
+				e.Type = d.GetReason()
+				if code, err := strconv.ParseInt(d.GetMetadata()["code"], 10, 64); err == nil {
+					e.Code = code
+				}
+				e.ID = d.GetMetadata()["id"]
+			case *errdetails.BadRequest:
+				for _, v := range d.GetFieldViolations() {
+					e.Violations = append(e.Violations, errors.ValidationError{
+						Type:    errors.ViolationErrorType(v.GetReason()),
+						Field:   v.GetField(),
+						Message: v.GetDescription(),
+					})
+				}
+			case *errdetails.RetryInfo:
+				e.WithRetryAfter(d.GetRetryDelay().AsDuration())
+			}
+		}
+	}
+}
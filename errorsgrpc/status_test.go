@@ -0,0 +1,159 @@
+package errorsgrpc
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	errors "github.com/andryhardiyanto/go-errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestStatusRoundTrip(t *testing.T) {
+	original := errors.Violations([]errors.ValidationError{
+		{Type: errors.ViolationErrorTypeRequired, Field: "email", Message: "Email is required"},
+	}).WithRetryAfter(time.Second).WithInternalMessage("secret")
+	original.ID = "abc"
+
+	st := ToStatus(original)
+	if st.Code() != codes.InvalidArgument || st.Message() != "Unprocessable entity" {
+		t.Errorf("Unexpected status %v", st)
+	}
+
+	decoded := FromStatus(st)
+	if decoded.Type != "UNPROCESSABLE_ENTITY" || decoded.Code != 422 || decoded.ID != "abc" {
+		t.Errorf("Unexpected decoded error %s/%d/%s", decoded.Type, decoded.Code, decoded.ID)
+	}
+	if len(decoded.Violations) != 1 || decoded.Violations[0].Field != "email" || decoded.Violations[0].Type != errors.ViolationErrorTypeRequired {
+		t.Errorf("Violations should round-trip, got %+v", decoded.Violations)
+	}
+	if decoded.RetryAfter != time.Second || decoded.InternalMessage != "" {
+		t.Error("Retry delay should round-trip and internal details should not")
+	}
+}
+
+func TestFromStatusHooksSeeDetails(t *testing.T) {
+	st := ToStatus(errors.New(409, "Order already paid", "ORDER_PAID"))
+
+	var seen string
+	handle := errors.RegisterHook(func(e *errors.Error) { seen = fmt.Sprintf("%s/%d/%d", e.Type, e.Code, e.GRPCCode) })
+	defer handle.Remove()
+
+	FromStatus(st)
+	if want := fmt.Sprintf("ORDER_PAID/409/%d", codes.AlreadyExists); seen != want {
+		t.Errorf("Hooks should observe the restored classification %q, got %q", want, seen)
+	}
+}
+
+func TestFromStatusPlain(t *testing.T) {
+	e := FromStatus(status.New(codes.Unavailable, "upstream down"))
+	if e.Type != "SERVICE_UNAVAILABLE" || e.Code != 503 || e.Message != "upstream down" {
+		t.Errorf("Unexpected error %s/%d/%s", e.Type, e.Code, e.Message)
+	}
+	if FromStatus(status.New(codes.OK, "")) != nil {
+		t.Error("OK status should convert to nil")
+	}
+}
+
+func TestCodeOverrides(t *testing.T) {
+	e := errors.New(10423, "Insufficient funds", "INSUFFICIENT_FUNDS")
+	if Code(e) != codes.Internal {
+		t.Errorf("Non-HTTP code should map to Internal, got %v", Code(e))
+	}
+
+	e.Status = 422
+	if Code(e) != codes.InvalidArgument {
+		t.Errorf("HTTP status should drive the mapping, got %v", Code(e))
+	}
+
+	e.GRPCCode = uint32(codes.FailedPrecondition)
+	if Code(e) != codes.FailedPrecondition {
+		t.Errorf("gRPC code should take precedence, got %v", Code(e))
+	}
+	if decoded := FromStatus(ToStatus(e)); decoded.Code != 10423 || Code(decoded) != codes.FailedPrecondition {
+		t.Errorf("Code and gRPC code should round-trip, got %d/%v", decoded.Code, Code(decoded))
+	}
+}
+
+type healthServer struct {
+	healthpb.UnimplementedHealthServer
+	err     error
+	trailer error
+}
+
+func (s *healthServer) Check(context.Context, *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	return nil, s.err
+}
+
+func (s *healthServer) Watch(_ *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	if s.trailer != nil {
+		SetTrailer(stream, s.trailer)
+	}
+	return s.err
+}
+
+func dial(t *testing.T, handlerErr error) healthpb.HealthClient {
+	t.Helper()
+	return dialServer(t, &healthServer{err: handlerErr})
+}
+
+func dialServer(t *testing.T, srv *healthServer) healthpb.HealthClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(UnaryServerInterceptor()),
+		grpc.StreamInterceptor(StreamServerInterceptor()),
+	)
+	healthpb.RegisterHealthServer(server, srv)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(UnaryClientInterceptor()),
+		grpc.WithStreamInterceptor(StreamClientInterceptor()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return healthpb.NewHealthClient(conn)
+}
+
+func TestInterceptorsUnary(t *testing.T) {
+	client := dial(t, fmt.Errorf("finding user: %w", errors.ErrorNotFound()))
+
+	_, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	var e *errors.Error
+	if !stderrors.As(err, &e) || e.Type != "NOT_FOUND" || e.Code != 404 {
+		t.Fatalf("Expected NOT_FOUND *errors.Error, got %v", err)
+	}
+	if st, _ := status.FromError(e.Unwrap()); st.Code() != codes.NotFound {
+		t.Errorf("Wrapped status should be NotFound, got %v", st.Code())
+	}
+}
+
+func TestInterceptorsStream(t *testing.T) {
+	client := dial(t, fmt.Errorf("db password=hunter2"))
+
+	stream, err := client.Watch(context.Background(), &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = stream.Recv()
+
+	var e *errors.Error
+	if !stderrors.As(err, &e) || e.Code != 500 || e.Message != "An internal server error occurred" {
+		t.Fatalf("Expected a generic internal error, got %v", err)
+	}
+}
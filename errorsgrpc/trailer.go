@@ -0,0 +1,65 @@
+package errorsgrpc
+
+import (
+	errors "github.com/andryhardiyanto/go-errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// TrailerKey is the trailing metadata key carrying the compact error envelope of a failed stream
+const TrailerKey = "x-error-envelope"
+
+// SetTrailer places the compact envelope of err (see errors.EncodeCompact) into the stream's
+// trailing metadata. Some proxies drop status details but forward trailers, so clients can still
+// reconstruct the error of a stream that fails mid-way.
+func SetTrailer(ss grpc.ServerStream, err error) {
+	if token := errors.EncodeCompact(err); token != "" {
+		ss.SetTrailer(metadata.Pairs(TrailerKey, token))
+	}
+}
+
+// FromTrailer reconstructs the error placed in md by SetTrailer, or returns nil when md carries
+// no valid envelope
+func FromTrailer(md metadata.MD) *errors.Error {
+	values := md.Get(TrailerKey)
+	if len(values) == 0 {
+		return nil
+	}
+
+	e, err := errors.DecodeCompact(values[0])
+	if err != nil {
+		return nil
+	}
+	return e
+}
+
+// FromStreamError converts an error returned by stream.RecvMsg. Status errors carrying this
+// package's details are converted with FromStatus; when the details were lost the envelope in
+// the stream's trailer is used instead. Other errors, such as io.EOF, are returned unchanged.
+func FromStreamError(stream grpc.ClientStream, err error) error {
+	st, ok := status.FromError(err)
+	if err == nil || !ok {
+		return err
+	}
+
+	if st.Code() != codes.OK && !hasErrorInfo(st) {
+		if e := FromTrailer(stream.Trailer()); e != nil {
+			e.Err = err
+			return e
+		}
+	}
+	return FromStatus(st)
+}
+
+// hasErrorInfo reports whether st carries this package's ErrorInfo detail
+func hasErrorInfo(st *status.Status) bool {
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.GetDomain() == ErrorInfoDomain {
+			return true
+		}
+	}
+	return false
+}
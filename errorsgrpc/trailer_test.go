@@ -0,0 +1,54 @@
+package errorsgrpc
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+
+	errors "github.com/andryhardiyanto/go-errors"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestStreamTrailerFallback(t *testing.T) {
+	// A status without details, as left by a proxy stripping them, with the envelope in the trailer
+	client := dialServer(t, &healthServer{
+		err: status.Error(codes.InvalidArgument, "invalid request"),
+		trailer: errors.Violations([]errors.ValidationError{
+			{Type: errors.ViolationErrorTypeRequired, Field: "service", Message: "Service is required"},
+		}),
+	})
+
+	stream, err := client.Watch(context.Background(), &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = stream.Recv()
+
+	var e *errors.Error
+	if !stderrors.As(err, &e) || e.Type != "UNPROCESSABLE_ENTITY" || len(e.Violations) != 1 {
+		t.Fatalf("Expected the error from the trailer, got %v", err)
+	}
+	if st, _ := status.FromError(e.Unwrap()); st.Code() != codes.InvalidArgument {
+		t.Errorf("The received status should be wrapped, got %v", st.Code())
+	}
+}
+
+func TestStreamServerInterceptorSetsTrailer(t *testing.T) {
+	client := dial(t, errors.ErrorConflict())
+
+	stream, err := client.Watch(context.Background(), &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = stream.Recv()
+
+	if e := FromTrailer(stream.Trailer()); e == nil || e.Type != "CONFLICT" {
+		t.Errorf("Expected CONFLICT in the trailer, got %v", e)
+	}
+	if FromTrailer(metadata.Pairs(TrailerKey, "not a token")) != nil {
+		t.Error("An invalid envelope should be ignored")
+	}
+}
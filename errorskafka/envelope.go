@@ -0,0 +1,126 @@
+package errorskafka
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"time"
+
+	errors "github.com/andryhardiyanto/go-errors"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// RecordName is the fully-qualified name of the event message, used by the record name strategies
+const RecordName = "goerrors.v1.ErrorEvent"
+
+// Schema is the protobuf schema of published events, registered with the schema registry
+const Schema = `syntax = "proto3";
+
+package goerrors.v1;
+
+message ErrorEvent {
+  message Violation {
+    string type = 1;
+    string field = 2;
+    string message = 3;
+  }
+
+  string id = 1;
+  string type = 2;
+  int64 code = 3;
+  string message = 4;
+  string internal_message = 5;
+  repeated Violation violations = 6;
+  map<string, string> fields = 7;
+  repeated string stack_traces = 8;
+  string fingerprint = 9;
+  string service = 10;
+  int64 timestamp_unix_ms = 11;
+  string cause = 12;
+}
+`
+
+// ErrorEvent field numbers
+const (
+	fieldID protowire.Number = iota + 1
+	fieldType
+	fieldCode
+	fieldMessage
+	fieldInternalMessage
+	fieldViolations
+	fieldFields
+	fieldStackTraces
+	fieldFingerprint
+	fieldService
+	fieldTimestamp
+	fieldCause
+)
+
+// encodeEvent encodes e as an ErrorEvent message
+func encodeEvent(e *errors.Error, service string, at time.Time) []byte {
+	var b []byte
+	appendString := func(b []byte, num protowire.Number, s string) []byte {
+		if s == "" {
+			return b
+		}
+		b = protowire.AppendTag(b, num, protowire.BytesType)
+		return protowire.AppendString(b, s)
+	}
+
+	b = appendString(b, fieldID, e.ID)
+	b = appendString(b, fieldType, e.Type)
+	if e.Code != 0 {
+		b = protowire.AppendTag(b, fieldCode, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(e.Code))
+	}
+	b = appendString(b, fieldMessage, e.Message)
+	b = appendString(b, fieldInternalMessage, e.InternalMessage)
+
+	for _, v := range e.Violations {
+		var violation []byte
+		violation = appendString(violation, 1, string(v.Type))
+		violation = appendString(violation, 2, v.Field)
+		violation = appendString(violation, 3, v.Message)
+		b = protowire.AppendTag(b, fieldViolations, protowire.BytesType)
+		b = protowire.AppendBytes(b, violation)
+	}
+
+	// Map entries are written in key order so identical errors encode identically
+	keys := make([]string, 0, len(e.Fields))
+	for key := range e.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		var entry []byte
+		entry = appendString(entry, 1, key)
+		entry = appendString(entry, 2, fmt.Sprint(e.Fields[key]))
+		b = protowire.AppendTag(b, fieldFields, protowire.BytesType)
+		b = protowire.AppendBytes(b, entry)
+	}
+
+	for _, frame := range e.StackTraces {
+		b = protowire.AppendTag(b, fieldStackTraces, protowire.BytesType)
+		b = protowire.AppendString(b, frame)
+	}
+
+	b = appendString(b, fieldFingerprint, e.Fingerprint())
+	b = appendString(b, fieldService, service)
+	b = protowire.AppendTag(b, fieldTimestamp, protowire.VarintType)
+	b = protowire.AppendVarint(b, uint64(at.UnixMilli()))
+	if e.Err != nil {
+		b = appendString(b, fieldCause, e.Err.Error())
+	}
+
+	return b
+}
+
+// frame prefixes payload with the schema registry wire format header: magic byte 0, the
+// big-endian schema ID and the message indexes, where a single 0 selects the first message
+func frame(schemaID int, payload []byte) []byte {
+	b := make([]byte, 0, 6+len(payload))
+	b = append(b, 0)
+	b = binary.BigEndian.AppendUint32(b, uint32(schemaID))
+	b = append(b, 0)
+	return append(b, payload...)
+}
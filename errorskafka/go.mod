@@ -0,0 +1,10 @@
+module github.com/andryhardiyanto/go-errors/errorskafka
+
+go 1.26.2
+
+require (
+	github.com/andryhardiyanto/go-errors v0.0.0
+	google.golang.org/protobuf v1.36.12
+)
+
+replace github.com/andryhardiyanto/go-errors => ../
@@ -0,0 +1,91 @@
+// Package errorskafka publishes errors as protobuf events to Kafka, framed in the wire format of
+// Confluent-compatible schema registries, so errors from every service can land in one error lake.
+package errorskafka
+
+import (
+	"context"
+	stderrors "errors"
+	"time"
+
+	errors "github.com/andryhardiyanto/go-errors"
+)
+
+type (
+	// Producer writes a record to a Kafka topic. It is implemented by a thin adapter around the
+	// client in use (franz-go, sarama, confluent-kafka-go, ...).
+	Producer interface {
+		Produce(ctx context.Context, topic string, key, value []byte) error
+	}
+
+	// ProducerFunc adapts a function to the Producer interface
+	ProducerFunc func(ctx context.Context, topic string, key, value []byte) error
+
+	// Option customizes a Publisher
+	Option func(*Publisher)
+
+	// Publisher publishes errors as ErrorEvent records keyed by fingerprint, so occurrences of the
+	// same problem land in the same partition. It is safe for concurrent use.
+	Publisher struct {
+		producer Producer
+		registry SchemaRegistry
+		topic    string
+		subject  SubjectStrategy
+		service  string
+		now      func() time.Time
+	}
+)
+
+// Produce calls f(ctx, topic, key, value)
+func (f ProducerFunc) Produce(ctx context.Context, topic string, key, value []byte) error {
+	return f(ctx, topic, key, value)
+}
+
+// WithSubjectStrategy sets how the subject is derived from the topic; the default is TopicNameStrategy
+func WithSubjectStrategy(strategy SubjectStrategy) Option {
+	return func(p *Publisher) {
+		p.subject = strategy
+	}
+}
+
+// WithService sets the service name recorded on every event
+func WithService(name string) Option {
+	return func(p *Publisher) {
+		p.service = name
+	}
+}
+
+// NewPublisher creates a publisher writing to topic through producer, registering Schema with registry
+func NewPublisher(producer Producer, registry SchemaRegistry, topic string, opts ...Option) *Publisher {
+	p := &Publisher{
+		producer: producer,
+		registry: registry,
+		topic:    topic,
+		subject:  TopicNameStrategy,
+		now:      time.Now,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Publish emits err as an ErrorEvent. The first *errors.Error in the chain is published with its
+// internal details and stack; other errors are wrapped. A nil error publishes nothing.
+func (p *Publisher) Publish(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var e *errors.Error
+	if !stderrors.As(err, &e) {
+		e = errors.Wrap(err)
+	}
+
+	schemaID, registerErr := p.registry.Register(ctx, p.subject(p.topic), Schema)
+	if registerErr != nil {
+		return registerErr
+	}
+
+	value := frame(schemaID, encodeEvent(e, p.service, p.now()))
+	return p.producer.Produce(ctx, p.topic, []byte(e.Fingerprint()), value)
+}
@@ -0,0 +1,128 @@
+package errorskafka
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	errors "github.com/andryhardiyanto/go-errors"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+type record struct {
+	topic      string
+	key, value []byte
+}
+
+func newRegistry(t *testing.T, registrations *int) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if r.URL.Path != "/subjects/errors-value/versions" || body["schemaType"] != "PROTOBUF" {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"error_code":42201,"message":"Invalid schema"}`))
+			return
+		}
+		*registrations++
+		_, _ = w.Write([]byte(`{"id":7}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// decode returns the string and varint fields of a protobuf message by field number
+func decode(t *testing.T, b []byte) map[protowire.Number][]any {
+	t.Helper()
+
+	fields := make(map[protowire.Number][]any)
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		b = b[n:]
+		switch typ {
+		case protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			fields[num] = append(fields[num], v)
+			b = b[n:]
+		case protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			fields[num] = append(fields[num], string(v))
+			b = b[n:]
+		default:
+			t.Fatalf("Unexpected wire type %v", typ)
+		}
+	}
+	return fields
+}
+
+func TestPublisher(t *testing.T) {
+	registrations := 0
+	registry := NewRegistryClient(newRegistry(t, &registrations).URL, nil)
+
+	var records []record
+	producer := ProducerFunc(func(_ context.Context, topic string, key, value []byte) error {
+		records = append(records, record{topic, key, value})
+		return nil
+	})
+
+	publisher := NewPublisher(producer, registry, "errors", WithService("checkout"))
+	publisher.now = func() time.Time { return time.UnixMilli(1700000000000) }
+
+	err := errors.ErrorNotFound().WithField("order_id", 42)
+	err.Err = fmt.Errorf("sql: no rows")
+	for range 2 {
+		if publishErr := publisher.Publish(context.Background(), fmt.Errorf("loading order: %w", err)); publishErr != nil {
+			t.Fatal(publishErr)
+		}
+	}
+
+	if registrations != 1 || len(records) != 2 {
+		t.Fatalf("Expected 1 registration and 2 records, got %d and %d", registrations, len(records))
+	}
+
+	r := records[0]
+	if r.topic != "errors" || string(r.key) != err.Fingerprint() {
+		t.Errorf("Unexpected record topic %q or key %q", r.topic, r.key)
+	}
+	if r.value[0] != 0 || binary.BigEndian.Uint32(r.value[1:5]) != 7 || r.value[5] != 0 {
+		t.Fatalf("Unexpected wire format header % x", r.value[:6])
+	}
+
+	fields := decode(t, r.value[6:])
+	if fields[fieldType][0] != "NOT_FOUND" || fields[fieldCode][0] != uint64(404) || fields[fieldService][0] != "checkout" {
+		t.Errorf("Unexpected event fields %v", fields)
+	}
+	if fields[fieldCause][0] != "sql: no rows" || fields[fieldTimestamp][0] != uint64(1700000000000) {
+		t.Errorf("Unexpected cause or timestamp %v", fields)
+	}
+	entry := decode(t, []byte(fields[fieldFields][0].(string)))
+	if entry[1][0] != "order_id" || entry[2][0] != "42" {
+		t.Errorf("Unexpected fields entry %v", entry)
+	}
+	if len(fields[fieldStackTraces]) == 0 {
+		t.Error("Stack traces should be published")
+	}
+}
+
+func TestPublisherRegistryError(t *testing.T) {
+	registrations := 0
+	registry := NewRegistryClient(newRegistry(t, &registrations).URL, nil)
+	producer := ProducerFunc(func(context.Context, string, []byte, []byte) error {
+		t.Error("Nothing should be produced without a schema ID")
+		return nil
+	})
+
+	publisher := NewPublisher(producer, registry, "errors", WithSubjectStrategy(RecordNameStrategy))
+	if err := publisher.Publish(context.Background(), errors.ErrorConflict()); err == nil {
+		t.Error("Publish should fail when the schema cannot be registered")
+	}
+	if publisher.Publish(context.Background(), nil) != nil {
+		t.Error("Publishing nil should be a no-op")
+	}
+}
@@ -0,0 +1,98 @@
+package errorskafka
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// SchemaRegistry registers schemas under a subject and returns their ID
+type SchemaRegistry interface {
+	Register(ctx context.Context, subject, schema string) (int, error)
+}
+
+// SubjectStrategy derives the schema registry subject from the topic
+type SubjectStrategy func(topic string) string
+
+// TopicNameStrategy names the subject "<topic>-value", the schema registry default
+func TopicNameStrategy(topic string) string {
+	return topic + "-value"
+}
+
+// RecordNameStrategy names the subject after the record, sharing one schema across topics
+func RecordNameStrategy(string) string {
+	return RecordName
+}
+
+// TopicRecordNameStrategy names the subject "<topic>-<record>"
+func TopicRecordNameStrategy(topic string) string {
+	return topic + "-" + RecordName
+}
+
+// RegistryClient is a SchemaRegistry talking to a Confluent-compatible schema registry REST API.
+// Registered IDs are cached per subject. It is safe for concurrent use.
+type RegistryClient struct {
+	url    string
+	client *http.Client
+
+	mu  sync.Mutex
+	ids map[string]int
+}
+
+// NewRegistryClient creates a client for the registry at url, using http.DefaultClient when client is nil
+func NewRegistryClient(url string, client *http.Client) *RegistryClient {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &RegistryClient{
+		url:    strings.TrimSuffix(url, "/"),
+		client: client,
+		ids:    make(map[string]int),
+	}
+}
+
+// Register registers schema as a PROTOBUF schema under subject. Registering an existing schema
+// returns its ID, so it is safe to call on every start.
+func (c *RegistryClient) Register(ctx context.Context, subject, schema string) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if id, ok := c.ids[subject]; ok {
+		return id, nil
+	}
+
+	body, err := json.Marshal(map[string]string{"schemaType": "PROTOBUF", "schema": schema})
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url+"/subjects/"+subject+"/versions", bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/vnd.schemaregistry.v1+json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		ID      int    `json:"id"`
+		Message string `json:"message"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("errorskafka: decoding schema registry response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("errorskafka: registering subject %q: %s (%d)", subject, result.Message, resp.StatusCode)
+	}
+
+	c.ids[subject] = result.ID
+	return result.ID, nil
+}
@@ -0,0 +1,113 @@
+// Package errorsmetrics counts errors with Prometheus, labeled by type, code and dependency
+// (see errors.WithDependency; empty when unset), as they are
+// created by the constructors of the errors package and as they are rendered as HTTP responses.
+// Cancellations (see errors.IsCanceled) are not failures and are skipped unless WithCanceled is used.
+package errorsmetrics
+
+import (
+	"strconv"
+
+	errors "github.com/andryhardiyanto/go-errors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Labels of the counters
+const (
+	LabelType       = "type"
+	LabelCode       = "code"
+	LabelDependency = "dependency"
+)
+
+type (
+	// Option customizes a Collector
+	Option func(*Collector)
+
+	// Collector is a prometheus.Collector and an errors.ErrorHook exporting
+	// <namespace>_errors_created_total and <namespace>_errors_rendered_total
+	Collector struct {
+		namespace string
+		canceled  bool
+		created   *prometheus.CounterVec
+		rendered  *prometheus.CounterVec
+	}
+)
+
+// WithNamespace prefixes the metric names, e.g. "checkout" gives checkout_errors_created_total
+func WithNamespace(namespace string) Option {
+	return func(c *Collector) {
+		c.namespace = namespace
+	}
+}
+
+// WithCanceled counts cancellations too, e.g. in a dashboard of their own
+func WithCanceled() Option {
+	return func(c *Collector) {
+		c.canceled = true
+	}
+}
+
+// New returns a Collector. Register it with a prometheus.Registerer and errors.OnError, or use Register.
+func New(opts ...Option) *Collector {
+	c := &Collector{}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	labels := []string{LabelType, LabelCode, LabelDependency}
+	c.created = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: c.namespace,
+		Name:      "errors_created_total",
+		Help:      "Errors created, by type, code and dependency.",
+	}, labels)
+	c.rendered = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: c.namespace,
+		Name:      "errors_rendered_total",
+		Help:      "Errors written as HTTP responses, by type, code and dependency.",
+	}, labels)
+	return c
+}
+
+// Register creates a Collector, registers it with reg and as an error hook, and returns a function
+// undoing both
+func Register(reg prometheus.Registerer, opts ...Option) (remove func(), err error) {
+	c := New(opts...)
+	if err := reg.Register(c); err != nil {
+		return nil, err
+	}
+
+	removeHook := errors.OnError(c)
+	return func() {
+		removeHook()
+		reg.Unregister(c)
+	}, nil
+}
+
+// OnError counts e under the counter of stage
+func (c *Collector) OnError(stage errors.Stage, e *errors.Error) {
+	if !c.canceled && errors.IsCanceled(e) {
+		return
+	}
+
+	var counter *prometheus.CounterVec
+	switch stage {
+	case errors.StageCreated:
+		counter = c.created
+	case errors.StageRendered:
+		counter = c.rendered
+	default:
+		return
+	}
+	counter.WithLabelValues(e.Type, strconv.FormatInt(e.Code, 10), e.Dependency()).Inc()
+}
+
+// Describe implements prometheus.Collector
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.created.Describe(ch)
+	c.rendered.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.created.Collect(ch)
+	c.rendered.Collect(ch)
+}
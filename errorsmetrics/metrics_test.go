@@ -0,0 +1,88 @@
+package errorsmetrics
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	errors "github.com/andryhardiyanto/go-errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestRegister(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	remove, err := Register(reg, WithNamespace("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	errors.ErrorNotFound()
+	if n, err := testutil.GatherAndCount(reg, "test_errors_created_total"); err != nil || n != 1 {
+		t.Errorf("Expected 1 created series, got %d (%v)", n, err)
+	}
+
+	remove()
+	if n, _ := testutil.GatherAndCount(reg); n != 0 {
+		t.Errorf("Removed collector should be unregistered, got %d series", n)
+	}
+	if _, err := Register(reg, WithNamespace("test")); err != nil {
+		t.Errorf("Registering again after remove should succeed, got %v", err)
+	}
+}
+
+func TestCollectorCounts(t *testing.T) {
+	c := New(WithNamespace("test"))
+	remove := errors.OnError(c)
+	defer remove()
+
+	e := errors.ErrorNotFound()
+	errors.ErrorNotFound()
+	errors.Wrap(fmt.Errorf("boom"))
+	errors.Wrap(e)
+	errors.WriteJSON(httptest.NewRecorder(), e)
+
+	if got := testutil.ToFloat64(c.created.WithLabelValues("NOT_FOUND", "404", "")); got != 2 {
+		t.Errorf("Expected 2 NOT_FOUND errors created, got %v", got)
+	}
+	if got := testutil.ToFloat64(c.created.WithLabelValues("INTERNAL_SERVER_ERROR", "500", "")); got != 1 {
+		t.Errorf("Wrapping an *Error should not count it again, got %v", got)
+	}
+	if got := testutil.ToFloat64(c.rendered.WithLabelValues("NOT_FOUND", "404", "")); got != 1 {
+		t.Errorf("Expected 1 NOT_FOUND error rendered, got %v", got)
+	}
+
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(c)
+	if n, err := testutil.GatherAndCount(reg, "test_errors_created_total"); err != nil || n != 2 {
+		t.Errorf("Expected 2 created series, got %d (%v)", n, err)
+	}
+}
+
+func TestCollectorDependency(t *testing.T) {
+	c := New()
+	remove := errors.OnError(c)
+	defer remove()
+
+	errors.WriteJSON(httptest.NewRecorder(), errors.ErrorBadGateway().WithDependency("payments-api"))
+
+	if got := testutil.ToFloat64(c.rendered.WithLabelValues("BAD_GATEWAY", "502", "payments-api")); got != 1 {
+		t.Errorf("Expected the rendered error under its dependency, got %v", got)
+	}
+}
+
+func TestCollectorCanceled(t *testing.T) {
+	skipping, counting := New(), New(WithCanceled())
+	removeSkipping, removeCounting := errors.OnError(skipping), errors.OnError(counting)
+	defer removeSkipping()
+	defer removeCounting()
+
+	errors.Canceled("shutdown")
+
+	if got := testutil.ToFloat64(skipping.created.WithLabelValues("CANCELED", "499", "")); got != 0 {
+		t.Errorf("Cancellations should be skipped by default, got %v", got)
+	}
+	if got := testutil.ToFloat64(counting.created.WithLabelValues("CANCELED", "499", "")); got != 1 {
+		t.Errorf("WithCanceled should count cancellations, got %v", got)
+	}
+}
@@ -0,0 +1,110 @@
+package errorsnotify
+
+import (
+	"context"
+	"go/build"
+	"net/http"
+	"strings"
+	"time"
+
+	errors "github.com/andryhardiyanto/go-errors"
+)
+
+// DefaultBugsnagEndpoint is Bugsnag's error reporting API
+const DefaultBugsnagEndpoint = "https://notify.bugsnag.com/"
+
+// bugsnagPayloadVersion is the version of the error reporting API payload
+const bugsnagPayloadVersion = "5"
+
+// Bugsnag notifies Bugsnag through its error reporting API. It is safe for concurrent use.
+type Bugsnag struct {
+	apiKey string
+	opts   options
+}
+
+// NewBugsnag creates a Bugsnag notifier posting with the project API key. The release stage
+// defaults to "production", like Bugsnag's SDK.
+func NewBugsnag(apiKey string, opts ...Option) *Bugsnag {
+	o := newOptions(DefaultBugsnagEndpoint, opts)
+	if o.environment == "" {
+		o.environment = "production"
+	}
+	return &Bugsnag{apiKey: apiKey, opts: o}
+}
+
+// Notify posts the event for err; a nil error posts nothing
+func (b *Bugsnag) Notify(ctx context.Context, err *errors.Error) error {
+	if err == nil {
+		return nil
+	}
+	header := http.Header{
+		"Bugsnag-Api-Key":         {b.apiKey},
+		"Bugsnag-Payload-Version": {bugsnagPayloadVersion},
+		"Bugsnag-Sent-At":         {time.Now().UTC().Format(time.RFC3339)},
+	}
+	return b.opts.post(ctx, "bugsnag", header, b.Payload(NewNotice(err)))
+}
+
+// Payload returns the notification Bugsnag's API expects for n, with a single event. Frames are
+// innermost first, frames outside the module cache and GOROOT are marked in-project, the
+// fingerprint is the grouping hash and the metadata tabs are sent as metaData.
+func (b *Bugsnag) Payload(n Notice) map[string]any {
+	stacktrace := make([]map[string]any, 0, len(n.Frames))
+	for _, frame := range n.Frames {
+		stacktrace = append(stacktrace, map[string]any{
+			"file":       frame.File,
+			"lineNumber": frame.Line,
+			"method":     frame.Function,
+			"inProject":  inProject(frame.File),
+		})
+	}
+
+	reason := "handledException"
+	if n.Unhandled {
+		reason = "unhandledPanic"
+	}
+
+	event := map[string]any{
+		"exceptions": []map[string]any{{
+			"errorClass": n.Class,
+			"message":    n.Message,
+			"type":       "go",
+			"stacktrace": stacktrace,
+		}},
+		"severity":       bugsnagSeverity(n.Severity),
+		"unhandled":      n.Unhandled,
+		"severityReason": map[string]any{"type": reason},
+		"groupingHash":   n.Fingerprint,
+		"metaData":       n.Metadata,
+		"app":            map[string]any{"releaseStage": b.opts.environment, "version": b.opts.version},
+		"device":         map[string]any{"hostname": b.opts.hostname, "time": n.Time.UTC().Format(time.RFC3339)},
+	}
+	if op, ok := n.Metadata["error"]["op"]; ok {
+		event["context"] = op
+	}
+
+	return map[string]any{
+		"apiKey":         b.apiKey,
+		"payloadVersion": bugsnagPayloadVersion,
+		"notifier":       map[string]any{"name": notifierName, "url": "https://github.com/andryhardiyanto/go-errors"},
+		"events":         []map[string]any{event},
+	}
+}
+
+// bugsnagSeverity returns Bugsnag's severity for severity, which only knows error, warning and info
+func bugsnagSeverity(severity errors.Severity) string {
+	switch severity {
+	case errors.SeverityWarning:
+		return "warning"
+	case errors.SeverityInfo, errors.SeverityDebug:
+		return "info"
+	}
+	return "error"
+}
+
+// inProject reports whether file belongs to the application rather than a dependency or the
+// standard library
+func inProject(file string) bool {
+	goroot := build.Default.GOROOT
+	return !strings.Contains(file, "/pkg/mod/") && (goroot == "" || !strings.HasPrefix(file, goroot))
+}
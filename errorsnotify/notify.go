@@ -0,0 +1,195 @@
+// Package errorsnotify sends *errors.Error values to crash reporting services such as Rollbar
+// and Bugsnag. A Notice is the service-neutral form of an error, with structured frames and
+// metadata; the adapters convert it into each service's payload and post it to its API.
+package errorsnotify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	errors "github.com/andryhardiyanto/go-errors"
+)
+
+type (
+	// Notifier sends an error to a crash reporting service
+	Notifier interface {
+		Notify(ctx context.Context, err *errors.Error) error
+	}
+
+	// NotifierFunc adapts a function to the Notifier interface
+	NotifierFunc func(ctx context.Context, err *errors.Error) error
+
+	// Notice is the service-neutral form of an error sent by the adapters
+	Notice struct {
+		// Class groups notices in the service, the error's type
+		Class string
+		// Message is the error's cause chain, see errors.(*Error).CauseChain
+		Message     string
+		Severity    errors.Severity
+		Fingerprint string
+		ID          string
+		// Frames are innermost first
+		Frames []errors.Frame
+		// Metadata holds the "error" tab with the error's ID, classification and internal details
+		// and the "fields" tab with its fields
+		Metadata map[string]map[string]any
+		// Unhandled is set for panics
+		Unhandled bool
+		Time      time.Time
+	}
+
+	// Option customizes an adapter
+	Option func(*options)
+
+	options struct {
+		endpoint    string
+		client      *http.Client
+		environment string
+		version     string
+		hostname    string
+	}
+)
+
+// Notify calls f(ctx, err)
+func (f NotifierFunc) Notify(ctx context.Context, err *errors.Error) error {
+	return f(ctx, err)
+}
+
+// WithEndpoint replaces the service's API endpoint, e.g. for a self-hosted instance or tests
+func WithEndpoint(url string) Option {
+	return func(o *options) {
+		o.endpoint = url
+	}
+}
+
+// WithClient sets the HTTP client posting notices (default http.DefaultClient)
+func WithClient(client *http.Client) Option {
+	return func(o *options) {
+		o.client = client
+	}
+}
+
+// WithEnvironment sets the environment or release stage, e.g. "production"
+func WithEnvironment(environment string) Option {
+	return func(o *options) {
+		o.environment = environment
+	}
+}
+
+// WithVersion sets the application version, e.g. a git SHA
+func WithVersion(version string) Option {
+	return func(o *options) {
+		o.version = version
+	}
+}
+
+// NewNotice builds the notice for err at the current time. The first *errors.Error in the chain
+// is sent; other errors are wrapped. A nil error gives the zero Notice.
+func NewNotice(err error) Notice {
+	var e *errors.Error
+	if !stderrors.As(err, &e) || e == nil {
+		e = errors.Wrap(err)
+	}
+	if e == nil {
+		return Notice{}
+	}
+	s := e.Clone()
+
+	details := map[string]any{
+		"code":   s.Code,
+		"status": errors.HTTPStatus(s),
+	}
+	for key, value := range map[string]any{
+		"id":               s.ID,
+		"business_code":    s.BusinessCode,
+		"op":               s.Op,
+		"message":          s.Message,
+		"internal_message": s.InternalMessage,
+	} {
+		if value != "" {
+			details[key] = value
+		}
+	}
+	if len(s.Notes) > 0 {
+		details["notes"] = s.Notes
+	}
+	if len(s.Violations) > 0 {
+		details["violations"] = s.Violations
+	}
+	if s.Retryable {
+		details["retryable"] = true
+	}
+
+	metadata := map[string]map[string]any{"error": details}
+	if len(s.Fields) > 0 {
+		metadata["fields"] = s.Fields
+	}
+
+	return Notice{
+		Class:       s.Type,
+		Message:     e.CauseChain(),
+		Severity:    e.LogSeverity(),
+		Fingerprint: e.Fingerprint(),
+		ID:          s.ID,
+		Frames:      e.Frames(),
+		Metadata:    metadata,
+		Unhandled:   s.Type == "PANIC",
+		Time:        time.Now(),
+	}
+}
+
+// Reporter returns an errors.Reporter notifying n, e.g. for errors.InstallCrashHandler.
+// Delivery is synchronous and failures are ignored; call Notify to handle them.
+func Reporter(n Notifier) errors.Reporter {
+	return errors.ReporterFunc(func(ctx context.Context, err *errors.Error) {
+		if err != nil {
+			_ = n.Notify(ctx, err)
+		}
+	})
+}
+
+// newOptions applies opts over the defaults of an adapter posting to endpoint
+func newOptions(endpoint string, opts []Option) options {
+	o := options{endpoint: endpoint, client: http.DefaultClient}
+	o.hostname, _ = os.Hostname()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// post sends payload as JSON to the endpoint with header; any non-2xx response is an error
+func (o options) post(ctx context.Context, service string, header http.Header, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("errorsnotify: %s returned %d: %s", service, resp.StatusCode, bytes.TrimSpace(detail))
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package errorsnotify
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	errors "github.com/andryhardiyanto/go-errors"
+)
+
+// capture starts a server recording the last request's headers and JSON body
+func capture(t *testing.T, status int) (*httptest.Server, *http.Header, *map[string]any) {
+	t.Helper()
+
+	header, body := new(http.Header), new(map[string]any)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*header = r.Header.Clone()
+		_ = json.NewDecoder(r.Body).Decode(body)
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, header, body
+}
+
+func TestNewNotice(t *testing.T) {
+	err := errors.New(402, "Payment declined", "PAYMENT_DECLINED").
+		WithCause(stderrors.New("card expired")).
+		WithOp("billing.Charge").
+		WithInternalMessage("gateway code 54").
+		WithField("order_id", 42)
+	err.ID = "err_1"
+
+	n := NewNotice(fmt.Errorf("checkout: %w", err))
+	if n.Class != "PAYMENT_DECLINED" || n.Message != "Payment declined: card expired" || n.ID != "err_1" {
+		t.Errorf("Unexpected notice %+v", n)
+	}
+	if len(n.Frames) == 0 || !strings.Contains(n.Frames[0].Function, "TestNewNotice") {
+		t.Errorf("Frames should start at the creation site, got %+v", n.Frames)
+	}
+	if n.Fingerprint != err.Fingerprint() || n.Severity != errors.SeverityInfo || n.Unhandled {
+		t.Errorf("Unexpected classification %+v", n)
+	}
+	details := n.Metadata["error"]
+	if details["code"] != int64(402) || details["op"] != "billing.Charge" || details["internal_message"] != "gateway code 54" || details["id"] != "err_1" {
+		t.Errorf("Unexpected error metadata %+v", details)
+	}
+	if n.Metadata["fields"]["order_id"] != 42 {
+		t.Errorf("Fields should be a metadata tab, got %+v", n.Metadata["fields"])
+	}
+
+	if plain := NewNotice(stderrors.New("boom")); plain.Class != "INTERNAL_SERVER_ERROR" {
+		t.Errorf("Plain errors should be wrapped, got %s", plain.Class)
+	}
+	if panicked := NewNotice(errors.ErrorPanic()); !panicked.Unhandled || panicked.Severity != errors.SeverityCritical {
+		t.Error("Panics should be unhandled and critical")
+	}
+}
+
+func TestRollbar(t *testing.T) {
+	server, header, body := capture(t, http.StatusOK)
+	rollbar := NewRollbar("token", WithEndpoint(server.URL), WithEnvironment("staging"), WithVersion("abc123"))
+
+	err := errors.ErrorServiceUnavailable().WithOp("store.Find")
+	if notifyErr := rollbar.Notify(context.Background(), err); notifyErr != nil {
+		t.Fatalf("Notify failed: %v", notifyErr)
+	}
+
+	if header.Get("X-Rollbar-Access-Token") != "token" {
+		t.Error("The access token should be sent")
+	}
+	data := (*body)["data"].(map[string]any)
+	if data["environment"] != "staging" || data["code_version"] != "abc123" || data["level"] != "error" || data["context"] != "store.Find" {
+		t.Errorf("Unexpected item %+v", data)
+	}
+	if data["fingerprint"] != err.Fingerprint() {
+		t.Error("The fingerprint should set the grouping")
+	}
+
+	trace := data["body"].(map[string]any)["trace"].(map[string]any)
+	frames := trace["frames"].([]any)
+	last := frames[len(frames)-1].(map[string]any)
+	if !strings.Contains(last["method"].(string), "TestRollbar") || last["lineno"].(float64) == 0 {
+		t.Errorf("Frames should be oldest first, ending at the creation site, got %+v", last)
+	}
+	if exception := trace["exception"].(map[string]any); exception["class"] != "SERVICE_UNAVAILABLE" {
+		t.Errorf("Unexpected exception %+v", exception)
+	}
+}
+
+func TestBugsnag(t *testing.T) {
+	server, header, body := capture(t, http.StatusOK)
+	bugsnag := NewBugsnag("key", WithEndpoint(server.URL))
+
+	err := errors.ErrorTooManyRequests().WithField("tenant", "acme")
+	if notifyErr := bugsnag.Notify(context.Background(), err); notifyErr != nil {
+		t.Fatalf("Notify failed: %v", notifyErr)
+	}
+
+	if header.Get("Bugsnag-Api-Key") != "key" || header.Get("Bugsnag-Payload-Version") != "5" || header.Get("Bugsnag-Sent-At") == "" {
+		t.Errorf("Unexpected headers %v", *header)
+	}
+	event := (*body)["events"].([]any)[0].(map[string]any)
+	if event["severity"] != "warning" || event["unhandled"] != false || event["groupingHash"] != err.Fingerprint() {
+		t.Errorf("Unexpected event %+v", event)
+	}
+	if app := event["app"].(map[string]any); app["releaseStage"] != "production" {
+		t.Errorf("Release stage should default to production, got %v", app["releaseStage"])
+	}
+	if fields := event["metaData"].(map[string]any)["fields"].(map[string]any); fields["tenant"] != "acme" {
+		t.Errorf("Fields should be sent as metaData, got %+v", fields)
+	}
+
+	exception := event["exceptions"].([]any)[0].(map[string]any)
+	first := exception["stacktrace"].([]any)[0].(map[string]any)
+	if exception["errorClass"] != "TOO_MANY_REQUEST" || !strings.Contains(first["method"].(string), "TestBugsnag") || first["inProject"] != true {
+		t.Errorf("Unexpected exception %+v", exception)
+	}
+}
+
+func TestNotifyFailure(t *testing.T) {
+	server, _, _ := capture(t, http.StatusUnauthorized)
+
+	err := NewRollbar("bad", WithEndpoint(server.URL)).Notify(context.Background(), errors.ErrorNotFound())
+	if err == nil || !strings.Contains(err.Error(), "rollbar returned 401") {
+		t.Errorf("A rejected notice should fail, got %v", err)
+	}
+}
+
+func TestReporter(t *testing.T) {
+	var notified []*errors.Error
+	reporter := Reporter(NotifierFunc(func(_ context.Context, err *errors.Error) error {
+		notified = append(notified, err)
+		return stderrors.New("unreachable")
+	}))
+
+	reporter.Report(context.Background(), errors.ErrorConflict())
+	reporter.Report(context.Background(), nil)
+	if len(notified) != 1 {
+		t.Errorf("Expected one notification, got %d", len(notified))
+	}
+}
+
+func TestNotifyNil(t *testing.T) {
+	server, header, _ := capture(t, http.StatusOK)
+
+	if err := NewRollbar("token", WithEndpoint(server.URL)).Notify(context.Background(), nil); err != nil || len(*header) != 0 {
+		t.Errorf("A nil error should post nothing, got %v", err)
+	}
+	if n := NewNotice(nil); n.Class != "" || n.Frames != nil {
+		t.Errorf("NewNotice(nil) should be the zero Notice, got %+v", n)
+	}
+}
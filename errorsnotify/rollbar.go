@@ -0,0 +1,95 @@
+package errorsnotify
+
+import (
+	"context"
+	"net/http"
+	"runtime"
+	"slices"
+
+	errors "github.com/andryhardiyanto/go-errors"
+)
+
+// DefaultRollbarEndpoint is Rollbar's item API
+const DefaultRollbarEndpoint = "https://api.rollbar.com/api/1/item/"
+
+// notifierName identifies this package to the services
+const notifierName = "go-errors"
+
+// Rollbar notifies Rollbar through its item API. It is safe for concurrent use.
+type Rollbar struct {
+	token string
+	opts  options
+}
+
+// NewRollbar creates a Rollbar notifier posting with the project access token. The environment
+// defaults to "development", like Rollbar's SDK.
+func NewRollbar(token string, opts ...Option) *Rollbar {
+	o := newOptions(DefaultRollbarEndpoint, opts)
+	if o.environment == "" {
+		o.environment = "development"
+	}
+	return &Rollbar{token: token, opts: o}
+}
+
+// Notify posts the item for err; a nil error posts nothing
+func (r *Rollbar) Notify(ctx context.Context, err *errors.Error) error {
+	if err == nil {
+		return nil
+	}
+	header := http.Header{"X-Rollbar-Access-Token": {r.token}}
+	return r.opts.post(ctx, "rollbar", header, r.Payload(NewNotice(err)))
+}
+
+// Payload returns the item Rollbar's API expects for n. Frames are oldest first, as Rollbar
+// orders them, the fingerprint sets the grouping and the metadata is sent as custom data.
+func (r *Rollbar) Payload(n Notice) map[string]any {
+	frames := make([]map[string]any, 0, len(n.Frames))
+	for _, frame := range slices.Backward(n.Frames) {
+		frames = append(frames, map[string]any{
+			"filename": frame.File,
+			"lineno":   frame.Line,
+			"method":   frame.Function,
+		})
+	}
+
+	data := map[string]any{
+		"environment": r.opts.environment,
+		"level":       rollbarLevel(n.Severity),
+		"timestamp":   n.Time.Unix(),
+		"platform":    runtime.GOOS,
+		"language":    "go",
+		"title":       n.Class + ": " + n.Message,
+		"fingerprint": n.Fingerprint,
+		"body": map[string]any{
+			"trace": map[string]any{
+				"frames":    frames,
+				"exception": map[string]any{"class": n.Class, "message": n.Message},
+			},
+		},
+		"custom":   n.Metadata,
+		"server":   map[string]any{"host": r.opts.hostname},
+		"notifier": map[string]any{"name": notifierName},
+	}
+	if op, ok := n.Metadata["error"]["op"]; ok {
+		data["context"] = op
+	}
+	if r.opts.version != "" {
+		data["code_version"] = r.opts.version
+	}
+	return map[string]any{"data": data}
+}
+
+// rollbarLevel returns Rollbar's level for severity
+func rollbarLevel(severity errors.Severity) string {
+	switch severity {
+	case errors.SeverityCritical:
+		return "critical"
+	case errors.SeverityWarning:
+		return "warning"
+	case errors.SeverityInfo:
+		return "info"
+	case errors.SeverityDebug:
+		return "debug"
+	}
+	return "error"
+}
@@ -0,0 +1,15 @@
+module github.com/andryhardiyanto/go-errors/errorsotel
+
+go 1.26.2
+
+require (
+	github.com/andryhardiyanto/go-errors v0.0.0
+	go.opentelemetry.io/otel/trace v1.44.0
+)
+
+require (
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
+	go.opentelemetry.io/otel v1.44.0 // indirect
+)
+
+replace github.com/andryhardiyanto/go-errors => ../
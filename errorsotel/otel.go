@@ -0,0 +1,26 @@
+// Package errorsotel stamps errors with the trace of the OpenTelemetry span carried by their
+// context. It lives in its own module so the core package does not depend on OpenTelemetry.
+package errorsotel
+
+import (
+	"context"
+
+	errors "github.com/andryhardiyanto/go-errors"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TraceID is the errors.TraceExtractor reading the trace ID of the span context carried by ctx.
+// It returns "" when ctx carries no valid span context.
+func TraceID(ctx context.Context) string {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.HasTraceID() {
+		return ""
+	}
+	return sc.TraceID().String()
+}
+
+// Install sets TraceID as the errors.TraceExtractor, so errors.WithContext and
+// errors.NewWithContext stamp the trace of the current span
+func Install() {
+	errors.SetTraceExtractor(TraceID)
+}
@@ -0,0 +1,30 @@
+package errorsotel
+
+import (
+	"context"
+	"testing"
+
+	errors "github.com/andryhardiyanto/go-errors"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestInstall(t *testing.T) {
+	Install()
+	defer errors.SetTraceExtractor(nil)
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  spanID,
+	}))
+
+	e := errors.NewWithContext(ctx, 500, "Failed", "INTERNAL")
+	if e.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("The span's trace ID should be stamped, got %q", e.TraceID)
+	}
+
+	if e := errors.ErrorNotFound().WithContext(context.Background()); e.TraceID != "" {
+		t.Errorf("Contexts without a span should stamp nothing, got %q", e.TraceID)
+	}
+}
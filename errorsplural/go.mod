@@ -0,0 +1,10 @@
+module github.com/andryhardiyanto/go-errors/errorsplural
+
+go 1.26.2
+
+require (
+	github.com/andryhardiyanto/go-errors v0.0.0
+	golang.org/x/text v0.42.0
+)
+
+replace github.com/andryhardiyanto/go-errors => ../
@@ -0,0 +1,156 @@
+// Package errorsplural provides an errors.Localizer whose messages vary with a count following
+// the CLDR plural rules of each locale, e.g. "1 item" and "2 items" in English, or the one, few
+// and many forms of Polish and Russian.
+package errorsplural
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	errors "github.com/andryhardiyanto/go-errors"
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+// DefaultCountParam is the parameter whose value selects the plural form unless a message names another
+const DefaultCountParam = "n"
+
+type (
+	// Message is a template, or a set of templates keyed by CLDR plural category ("zero", "one",
+	// "two", "few", "many", "other") or exact value ("=0"). In JSON it is either a string or an
+	// object such as {"one": "{n} item", "other": "{n} items"}, optionally with "param" naming
+	// the count parameter.
+	Message struct {
+		Template string
+		Param    string
+		Forms    map[string]string
+	}
+
+	// Bundle maps locale to message key to message. A regional locale such as "pl-PL" falls
+	// back to its base language "pl".
+	Bundle map[string]map[string]Message
+)
+
+// formNames maps the CLDR plural categories to their names in messages
+var formNames = map[plural.Form]string{
+	plural.Zero:  "zero",
+	plural.One:   "one",
+	plural.Two:   "two",
+	plural.Few:   "few",
+	plural.Many:  "many",
+	plural.Other: "other",
+}
+
+// LoadBundle parses a JSON document of the form {"<locale>": {"<key>": <message>}}
+func LoadBundle(r io.Reader) (Bundle, error) {
+	bundle := Bundle{}
+	if err := json.NewDecoder(r).Decode(&bundle); err != nil {
+		return nil, err
+	}
+	return bundle, nil
+}
+
+// UnmarshalJSON decodes a message from a string or an object of plural forms
+func (m *Message) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &m.Template); err == nil {
+		return nil
+	}
+
+	var forms map[string]string
+	if err := json.Unmarshal(data, &forms); err != nil {
+		return fmt.Errorf("errorsplural: message must be a string or an object of plural forms: %w", err)
+	}
+	m.Param = forms["param"]
+	delete(forms, "param")
+	m.Forms = forms
+	return nil
+}
+
+// Localize implements errors.Localizer
+func (b Bundle) Localize(locale, key string, params map[string]any) (string, bool) {
+	base, _, _ := strings.Cut(strings.ReplaceAll(locale, "_", "-"), "-")
+	for _, candidate := range []string{locale, base} {
+		if message, ok := b[candidate][key]; ok {
+			return errors.RenderTemplate(message.Select(candidate, params), params), true
+		}
+	}
+	return "", false
+}
+
+// Select returns the template of m to render in locale: the exact-value form matching the count,
+// then its CLDR plural category, then "other". Messages without forms return Template, as do
+// messages whose count parameter is missing or not a number.
+func (m Message) Select(locale string, params map[string]any) string {
+	if len(m.Forms) == 0 {
+		return m.Template
+	}
+
+	param := m.Param
+	if param == "" {
+		param = DefaultCountParam
+	}
+	digits, ok := decimal(params[param])
+	if !ok {
+		return m.fallback()
+	}
+
+	if template, ok := m.Forms["="+digits]; ok {
+		return template
+	}
+	if template, ok := m.Forms[Category(locale, digits)]; ok {
+		return template
+	}
+	return m.fallback()
+}
+
+// fallback returns the "other" form, or Template when there is none
+func (m Message) fallback() string {
+	if template, ok := m.Forms["other"]; ok {
+		return template
+	}
+	return m.Template
+}
+
+// Category returns the CLDR plural category ("zero", "one", "two", "few", "many" or "other") of
+// the decimal number digits, e.g. "1.5", in locale. Invalid input yields "other".
+func Category(locale, digits string) string {
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return "other"
+	}
+
+	digits = strings.TrimPrefix(digits, "-")
+	integer, fraction, _ := strings.Cut(digits, ".")
+	i, err := strconv.Atoi(integer)
+	if err != nil {
+		return "other"
+	}
+
+	// CLDR operands: v and f include trailing zeros of the fraction, w and t do not
+	trimmed := strings.TrimRight(fraction, "0")
+	f, _ := strconv.Atoi("0" + fraction)
+	t, _ := strconv.Atoi("0" + trimmed)
+	return formNames[plural.Cardinal.MatchPlural(tag, i, len(fraction), len(trimmed), f, t)]
+}
+
+// decimal formats a numeric parameter as a decimal number
+func decimal(value any) (string, bool) {
+	switch v := value.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprint(v), true
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case json.Number:
+		return v.String(), true
+	case string:
+		if _, err := strconv.ParseFloat(v, 64); err == nil {
+			return v, true
+		}
+	}
+	return "", false
+}
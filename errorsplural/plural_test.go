@@ -0,0 +1,101 @@
+package errorsplural
+
+import (
+	"strings"
+	"testing"
+
+	errors "github.com/andryhardiyanto/go-errors"
+)
+
+const bundleJSON = `{
+  "en": {
+    "ITEMS_REQUIRED": {"one": "Add at least {n} item", "other": "Add at least {n} items"},
+    "CART_FULL": {"param": "0", "=0": "Your cart is empty", "one": "{0} item in cart", "other": "{0} items in cart"},
+    "NOT_FOUND": "Not found"
+  },
+  "pl": {
+    "ITEMS_REQUIRED": {"one": "Dodaj co najmniej {n} produkt", "few": "Dodaj co najmniej {n} produkty", "many": "Dodaj co najmniej {n} produktów", "other": "Dodaj co najmniej {n} produktu"}
+  }
+}`
+
+func loadBundle(t *testing.T) Bundle {
+	t.Helper()
+
+	bundle, err := LoadBundle(strings.NewReader(bundleJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bundle
+}
+
+func TestLocalizePlurals(t *testing.T) {
+	bundle := loadBundle(t)
+
+	tests := []struct {
+		locale string
+		n      any
+		want   string
+	}{
+		{"en", 1, "Add at least 1 item"},
+		{"en-US", 3, "Add at least 3 items"},
+		{"en", 1.5, "Add at least 1.5 items"},
+		{"pl", 1, "Dodaj co najmniej 1 produkt"},
+		{"pl-PL", 3, "Dodaj co najmniej 3 produkty"},
+		{"pl", 5, "Dodaj co najmniej 5 produktów"},
+		{"pl", 22, "Dodaj co najmniej 22 produkty"},
+		{"pl", 1.5, "Dodaj co najmniej 1.5 produktu"},
+		{"en", "many", "Add at least many items"},
+	}
+
+	for _, tt := range tests {
+		got, ok := bundle.Localize(tt.locale, "ITEMS_REQUIRED", map[string]any{"n": tt.n})
+		if !ok || got != tt.want {
+			t.Errorf("Localize(%s, %v) = %q, want %q", tt.locale, tt.n, got, tt.want)
+		}
+	}
+
+	if _, ok := bundle.Localize("de", "ITEMS_REQUIRED", nil); ok {
+		t.Error("Unknown locales should report no message")
+	}
+}
+
+func TestTranslateWithPlurals(t *testing.T) {
+	errors.SetLocalizer(loadBundle(t))
+	t.Cleanup(func() { errors.SetLocalizer(nil) })
+
+	err := errors.Newf(409, "cart has %d items", "CART_FULL", 0)
+	if got := errors.Translate(err, "en").Message; got != "Your cart is empty" {
+		t.Errorf("Exact values should take precedence, got %q", got)
+	}
+
+	err = errors.Newf(409, "cart has %d items", "CART_FULL", 2)
+	if got := errors.Translate(err, "en").Message; got != "2 items in cart" {
+		t.Errorf("Expected the other form, got %q", got)
+	}
+
+	if got := errors.Translate(errors.ErrorNotFound(), "en").Message; got != "Not found" {
+		t.Errorf("Plain templates should still work, got %q", got)
+	}
+}
+
+func TestCategory(t *testing.T) {
+	for _, tt := range []struct{ locale, digits, want string }{
+		{"en", "1", "one"},
+		{"en", "1.0", "other"},
+		{"ar", "0", "zero"},
+		{"ar", "2", "two"},
+		{"ru", "21", "one"},
+		{"invalid locale!", "1", "other"},
+		{"en", "abc", "other"},
+	} {
+		if got := Category(tt.locale, tt.digits); got != tt.want {
+			t.Errorf("Category(%s, %s) = %s, want %s", tt.locale, tt.digits, got, tt.want)
+		}
+	}
+}
+
+func TestLoadBundleInvalid(t *testing.T) {
+	if _, err := LoadBundle(strings.NewReader(`{"en": {"X": 1}}`)); err == nil {
+		t.Error("Expected an error for a message that is neither a string nor forms")
+	}
+}
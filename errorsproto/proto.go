@@ -0,0 +1,193 @@
+// Package errorsproto encodes *errors.Error values as goerrors.v1.ErrorProto protobuf messages,
+// so errors can be embedded in asynchronous messages and parsed by consumers in any language.
+package errorsproto
+
+import (
+	_ "embed"
+	"fmt"
+	"sort"
+
+	errors "github.com/andryhardiyanto/go-errors"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// MessageName is the fully-qualified name of the message
+const MessageName = "goerrors.v1.ErrorProto"
+
+// Schema is the protobuf schema of ErrorProto, for code generation in other languages and for
+// schema registries
+//
+//go:embed error.proto
+var Schema string
+
+// ErrorProto field numbers
+const (
+	fieldType protowire.Number = iota + 1
+	fieldCode
+	fieldMessage
+	fieldViolations
+	fieldMetadata
+	fieldID
+)
+
+// Violation field numbers
+const (
+	violationType protowire.Number = iota + 1
+	violationField
+	violationMessage
+)
+
+// ToProto encodes err as an ErrorProto message with its type, code, message, violations, ID and
+// fields as metadata. Field values are formatted with fmt.Sprint; the internal message, stack
+// trace and cause are not encoded. A nil error encodes as an empty message.
+func ToProto(err *errors.Error) []byte {
+	if err == nil {
+		return []byte{}
+	}
+	e := err.Clone()
+
+	var b []byte
+	appendString := func(b []byte, num protowire.Number, s string) []byte {
+		if s == "" {
+			return b
+		}
+		b = protowire.AppendTag(b, num, protowire.BytesType)
+		return protowire.AppendString(b, s)
+	}
+
+	b = appendString(b, fieldType, e.Type)
+	if e.Code != 0 {
+		b = protowire.AppendTag(b, fieldCode, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(e.Code))
+	}
+	b = appendString(b, fieldMessage, e.Message)
+
+	for _, v := range e.Violations {
+		var violation []byte
+		violation = appendString(violation, violationType, string(v.Type))
+		violation = appendString(violation, violationField, v.Field)
+		violation = appendString(violation, violationMessage, v.Message)
+		b = protowire.AppendTag(b, fieldViolations, protowire.BytesType)
+		b = protowire.AppendBytes(b, violation)
+	}
+
+	// Map entries are written in key order so identical errors encode identically
+	keys := make([]string, 0, len(e.Fields))
+	for key := range e.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		var entry []byte
+		entry = appendString(entry, 1, key)
+		entry = appendString(entry, 2, fmt.Sprint(e.Fields[key]))
+		b = protowire.AppendTag(b, fieldMetadata, protowire.BytesType)
+		b = protowire.AppendBytes(b, entry)
+	}
+
+	b = appendString(b, fieldID, e.ID)
+	if b == nil {
+		b = []byte{}
+	}
+	return b
+}
+
+// FromProto decodes an ErrorProto message into an *errors.Error whose fields hold the metadata
+// as strings. The error has no stack trace. Unknown fields are skipped, so messages written by
+// newer schema versions still decode.
+func FromProto(b []byte) (*errors.Error, error) {
+	e := &errors.Error{}
+
+	err := consumeFields(b, func(num protowire.Number, typ protowire.Type, value []byte, varint uint64) error {
+		switch {
+		case num == fieldType && typ == protowire.BytesType:
+			e.Type = string(value)
+		case num == fieldCode && typ == protowire.VarintType:
+			e.Code = int64(varint)
+		case num == fieldMessage && typ == protowire.BytesType:
+			e.Message = string(value)
+		case num == fieldID && typ == protowire.BytesType:
+			e.ID = string(value)
+		case num == fieldViolations && typ == protowire.BytesType:
+			var v errors.ValidationError
+			err := consumeFields(value, func(num protowire.Number, typ protowire.Type, value []byte, _ uint64) error {
+				if typ != protowire.BytesType {
+					return nil
+				}
+				switch num {
+				case violationType:
+					v.Type = errors.ViolationErrorType(value)
+				case violationField:
+					v.Field = string(value)
+				case violationMessage:
+					v.Message = string(value)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			e.Violations = append(e.Violations, v)
+		case num == fieldMetadata && typ == protowire.BytesType:
+			var key, val string
+			err := consumeFields(value, func(num protowire.Number, typ protowire.Type, value []byte, _ uint64) error {
+				if typ != protowire.BytesType {
+					return nil
+				}
+				switch num {
+				case 1:
+					key = string(value)
+				case 2:
+					val = string(value)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			if e.Fields == nil {
+				e.Fields = make(map[string]any)
+			}
+			e.Fields[key] = val
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", MessageName, err)
+	}
+	return e, nil
+}
+
+// consumeFields calls fn for each field of the message b with the bytes of length-delimited
+// fields or the value of varint fields. Fields of other wire types are skipped.
+func consumeFields(b []byte, fn func(num protowire.Number, typ protowire.Type, value []byte, varint uint64) error) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		var (
+			value  []byte
+			varint uint64
+		)
+		switch typ {
+		case protowire.BytesType:
+			value, n = protowire.ConsumeBytes(b)
+		case protowire.VarintType:
+			varint, n = protowire.ConsumeVarint(b)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		if err := fn(num, typ, value, varint); err != nil {
+			return err
+		}
+	}
+	return nil
+}
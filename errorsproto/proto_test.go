@@ -0,0 +1,67 @@
+package errorsproto
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	errors "github.com/andryhardiyanto/go-errors"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestProtoRoundTrip(t *testing.T) {
+	original := errors.Violations([]errors.ValidationError{
+		{Type: errors.ViolationErrorTypeRequired, Field: "email", Message: "Email is required"},
+		{Type: errors.ViolationErrorTypeMin, Field: "age", Message: "Age must be at least 18"},
+	}).WithInternalMessage("secret").WithField("tenant", "acme").WithField(errors.FieldAttempt, 3)
+	original.ID = "abc"
+
+	b := ToProto(original)
+	if !bytes.Equal(b, ToProto(original)) {
+		t.Error("Identical errors should encode identically")
+	}
+	if bytes.Contains(b, []byte("secret")) {
+		t.Error("The internal message should not be encoded")
+	}
+
+	decoded, err := FromProto(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Type != "UNPROCESSABLE_ENTITY" || decoded.Code != 422 || decoded.Message != "Unprocessable entity" || decoded.ID != "abc" {
+		t.Errorf("Unexpected decoded error %s/%d/%s/%s", decoded.Type, decoded.Code, decoded.Message, decoded.ID)
+	}
+	if len(decoded.Violations) != 2 || !reflect.DeepEqual(decoded.Violations[1], errors.ValidationError{Type: errors.ViolationErrorTypeMin, Field: "age", Message: "Age must be at least 18"}) {
+		t.Errorf("Violations should round-trip, got %+v", decoded.Violations)
+	}
+	if decoded.Fields["tenant"] != "acme" || decoded.Fields[errors.FieldAttempt] != "3" {
+		t.Errorf("Fields should round-trip as strings, got %v", decoded.Fields)
+	}
+}
+
+func TestFromProtoUnknownFields(t *testing.T) {
+	b := ToProto(errors.ErrorNotFound())
+	b = protowire.AppendTag(b, 99, protowire.Fixed64Type)
+	b = protowire.AppendFixed64(b, 1)
+	b = protowire.AppendTag(b, 100, protowire.BytesType)
+	b = protowire.AppendString(b, "future")
+
+	decoded, err := FromProto(b)
+	if err != nil || decoded.Type != "NOT_FOUND" || decoded.Code != 404 {
+		t.Errorf("Unknown fields should be skipped, got %v, %v", decoded, err)
+	}
+
+	if _, err := FromProto([]byte{0x0a, 0x05, 'a'}); err == nil || !strings.Contains(err.Error(), MessageName) {
+		t.Errorf("Truncated messages should fail, got %v", err)
+	}
+}
+
+func TestSchema(t *testing.T) {
+	if !strings.Contains(Schema, "message ErrorProto") || !strings.Contains(Schema, "package goerrors.v1;") {
+		t.Errorf("Schema should embed error.proto, got %q", Schema)
+	}
+	if len(ToProto(nil)) != 0 {
+		t.Error("nil should encode as an empty message")
+	}
+}
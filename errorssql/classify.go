@@ -0,0 +1,90 @@
+package errorssql
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	stderrors "errors"
+	"reflect"
+
+	errors "github.com/andryhardiyanto/go-errors"
+)
+
+// SQLSTATE codes recognized by ClassifySQL
+const (
+	SQLStateUniqueViolation      = "23505"
+	SQLStateSerializationFailure = "40001"
+	SQLStateDeadlockDetected     = "40P01"
+)
+
+// MySQL error numbers recognized by ClassifySQL
+const (
+	MySQLDuplicateEntry   = 1062
+	MySQLLockWaitTimeout  = 1205
+	MySQLDeadlockDetected = 1213
+)
+
+// ClassifySQL maps a database error to an *errors.Error:
+//
+//   - sql.ErrNoRows becomes NOT_FOUND (404)
+//   - unique constraint violations (SQLSTATE 23505, MySQL 1062) become CONFLICT (409)
+//   - serialization failures and deadlocks (SQLSTATE 40001 and 40P01, MySQL 1205 and 1213) become
+//     a retryable CONFLICT, since retrying the transaction may succeed
+//   - lost connections (sql.ErrConnDone, driver.ErrBadConn) become a retryable SERVICE_UNAVAILABLE (503)
+//   - anything else is classified by errors.Wrap
+//
+// The SQLSTATE is recorded under FieldSQLState. SQLSTATE codes are read from any error with a
+// SQLState() string method (pgx, lib/pq) and MySQL numbers from the Number field of the driver's
+// error, so no driver is imported. An *errors.Error already in err's chain is returned as is,
+// and nil stays nil.
+func ClassifySQL(err error) *errors.Error {
+	if err == nil {
+		return nil
+	}
+
+	var e *errors.Error
+	if stderrors.As(err, &e) && e != nil {
+		return e
+	}
+
+	state, number := SQLState(err), mysqlNumber(err)
+	switch {
+	case stderrors.Is(err, sql.ErrNoRows):
+		e = errors.WrapWith(err, 404, "NOT_FOUND", "Not found")
+	case state == SQLStateUniqueViolation || number == MySQLDuplicateEntry:
+		e = errors.WrapWith(err, 409, "CONFLICT", "Conflict")
+	case state == SQLStateSerializationFailure || state == SQLStateDeadlockDetected ||
+		number == MySQLLockWaitTimeout || number == MySQLDeadlockDetected:
+		e = errors.WrapWith(err, 409, "CONFLICT", "Conflict").WithRetryable(true)
+	case stderrors.Is(err, sql.ErrConnDone) || stderrors.Is(err, driver.ErrBadConn):
+		e = errors.WrapWith(err, 503, "SERVICE_UNAVAILABLE", "Service Unavailable").WithRetryable(true)
+	default:
+		e = errors.Wrap(err)
+	}
+
+	if state != "" {
+		e.WithField(FieldSQLState, state)
+	}
+	return e
+}
+
+// mysqlNumber returns the error number of the first error in err's chain with an unsigned
+// integer Number field, as go-sql-driver/mysql's *MySQLError has, or 0
+func mysqlNumber(err error) uint64 {
+	for _, inner := range errors.Chain(err) {
+		v := reflect.ValueOf(inner)
+		if v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				continue
+			}
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Struct {
+			continue
+		}
+
+		if field := v.FieldByName("Number"); field.IsValid() && field.CanUint() {
+			return field.Uint()
+		}
+	}
+	return 0
+}
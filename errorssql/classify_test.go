@@ -0,0 +1,67 @@
+package errorssql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	stderrors "errors"
+	"fmt"
+	"testing"
+
+	errors "github.com/andryhardiyanto/go-errors"
+)
+
+// mysqlError mirrors go-sql-driver/mysql's *MySQLError
+type mysqlError struct {
+	Number  uint16
+	Message string
+}
+
+func (e *mysqlError) Error() string { return e.Message }
+
+func TestClassifySQL(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		errorType string
+		code      int64
+		retryable bool
+		sqlState  string
+	}{
+		{"no rows", fmt.Errorf("finding user: %w", sql.ErrNoRows), "NOT_FOUND", 404, false, ""},
+		{"postgres unique", &pgError{code: "23505", message: "duplicate key"}, "CONFLICT", 409, false, "23505"},
+		{"mysql duplicate", &mysqlError{Number: 1062, Message: "Duplicate entry"}, "CONFLICT", 409, false, ""},
+		{"serialization", fmt.Errorf("commit: %w", &pgError{code: "40001"}), "CONFLICT", 409, true, "40001"},
+		{"postgres deadlock", &pgError{code: "40P01"}, "CONFLICT", 409, true, "40P01"},
+		{"mysql deadlock", &mysqlError{Number: 1213}, "CONFLICT", 409, true, ""},
+		{"connection", driver.ErrBadConn, "SERVICE_UNAVAILABLE", 503, true, ""},
+		{"other", &pgError{code: "42P01", message: "relation does not exist"}, "INTERNAL_SERVER_ERROR", 500, false, "42P01"},
+		{"timeout", fmt.Errorf("query: %w", context.DeadlineExceeded), "GATEWAY_TIMEOUT", 504, true, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := ClassifySQL(tt.err)
+			if e.Type != tt.errorType || e.Code != tt.code || e.Retryable != tt.retryable {
+				t.Errorf("Got %s/%d/%v, want %s/%d/%v", e.Type, e.Code, e.Retryable, tt.errorType, tt.code, tt.retryable)
+			}
+			if !stderrors.Is(e, tt.err) {
+				t.Error("The driver error should stay reachable")
+			}
+			if state, _ := e.Fields[FieldSQLState].(string); state != tt.sqlState {
+				t.Errorf("Expected SQLSTATE %q, got %q", tt.sqlState, state)
+			}
+		})
+	}
+}
+
+func TestClassifySQLKeepsErrors(t *testing.T) {
+	if ClassifySQL(nil) != nil {
+		t.Error("nil should stay nil")
+	}
+
+	conflict := errors.ErrorConflict()
+	if ClassifySQL(fmt.Errorf("saving: %w", conflict)) != conflict {
+		t.Error("Errors already classified should be returned as is")
+	}
+}
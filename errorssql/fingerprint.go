@@ -0,0 +1,136 @@
+// Package errorssql maps database errors to *errors.Error and annotates them with safe query metadata.
+package errorssql
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+	"unicode"
+
+	errors "github.com/andryhardiyanto/go-errors"
+)
+
+// Field keys set by WithQuery
+const (
+	FieldQueryFingerprint = "db_query_fingerprint"
+	FieldQueryHash        = "db_query_hash"
+)
+
+// valueLists matches lists of stripped literals or placeholders such as "(?, ?, ?)"
+var valueLists = regexp.MustCompile(`\(\?(?:, \?)+\)`)
+
+// WithQuery attaches the fingerprint and hash of query to err instead of the raw query,
+// so literal parameter values never reach logs. It returns err for chaining.
+func WithQuery(err *errors.Error, query string) *errors.Error {
+	fingerprint := QueryFingerprint(query)
+	return err.
+		WithField(FieldQueryFingerprint, fingerprint).
+		WithField(FieldQueryHash, hash(fingerprint))
+}
+
+// QueryHash returns a short stable hash of the query's fingerprint, suitable as a grouping key
+func QueryHash(query string) string {
+	return hash(QueryFingerprint(query))
+}
+
+// QueryFingerprint normalizes query: string and numeric literals become "?", comments are removed,
+// whitespace is collapsed, keywords are lower-cased and lists of values collapse to "(?+)".
+// Placeholders ($1, ?, :name) and double-quoted identifiers are kept.
+func QueryFingerprint(query string) string {
+	var b strings.Builder
+	runes := []rune(query)
+	pendingSpace := false
+
+	last := ' '
+	write := func(s string) {
+		// No space after an opening parenthesis or before a closing one or a comma
+		if pendingSpace && b.Len() > 0 && last != '(' && s != ")" && s != "," {
+			b.WriteByte(' ')
+		}
+		pendingSpace = false
+		b.WriteString(s)
+		last = rune(s[len(s)-1])
+	}
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		switch {
+		case unicode.IsSpace(r):
+			pendingSpace = true
+
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+			pendingSpace = true
+
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			i += 2
+			for i+1 < len(runes) && !(runes[i] == '*' && runes[i+1] == '/') {
+				i++
+			}
+			i++
+			pendingSpace = true
+
+		case r == '\'':
+			// String literal; '' is an escaped quote
+			for i++; i < len(runes); i++ {
+				if runes[i] == '\'' {
+					if i+1 < len(runes) && runes[i+1] == '\'' {
+						i++
+						continue
+					}
+					break
+				}
+			}
+			write("?")
+
+		case r == '"' || r == '`':
+			start := i
+			for i++; i < len(runes) && runes[i] != r; i++ {
+			}
+			end := min(i+1, len(runes))
+			write(string(runes[start:end]))
+
+		case unicode.IsDigit(r) && !isIdentifierPart(runes, i-1):
+			for i+1 < len(runes) && (unicode.IsDigit(runes[i+1]) || runes[i+1] == '.') {
+				i++
+			}
+			write("?")
+
+		case r == '$' || r == ':' || isIdentifierStart(r):
+			start := i
+			for i+1 < len(runes) && isIdentifierRune(runes[i+1]) {
+				i++
+			}
+			write(strings.ToLower(string(runes[start : i+1])))
+
+		default:
+			write(string(r))
+			pendingSpace = r == ','
+		}
+	}
+
+	return valueLists.ReplaceAllString(b.String(), "(?+)")
+}
+
+// isIdentifierPart reports whether the rune at i belongs to an identifier or placeholder
+func isIdentifierPart(runes []rune, i int) bool {
+	return i >= 0 && (isIdentifierRune(runes[i]) || runes[i] == '$')
+}
+
+func isIdentifierStart(r rune) bool {
+	return unicode.IsLetter(r) || r == '_'
+}
+
+func isIdentifierRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.'
+}
+
+// hash returns the first 16 hex characters of the SHA-256 of s
+func hash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:8])
+}
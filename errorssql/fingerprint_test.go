@@ -0,0 +1,49 @@
+package errorssql
+
+import (
+	"testing"
+
+	errors "github.com/andryhardiyanto/go-errors"
+)
+
+func TestQueryFingerprint(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{
+			query: "SELECT * FROM users WHERE email = 'a@b.c' AND age > 18",
+			want:  "select * from users where email = ? and age > ?",
+		},
+		{
+			query: "select id\n  from   users -- lookup\n where id IN (1, 2, 3) /* batch */ limit 10",
+			want:  "select id from users where id in (?+) limit ?",
+		},
+		{
+			query: `UPDATE "Users" SET name = 'O''Brien' WHERE id = $1 AND t2.col3 = :name`,
+			want:  `update "Users" set name = ? where id = $1 and t2.col3 = :name`,
+		},
+		{
+			query: "INSERT INTO orders (id, sku) VALUES (?, ?)",
+			want:  "insert into orders (id, sku) values (?+)",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := QueryFingerprint(tt.query); got != tt.want {
+			t.Errorf("QueryFingerprint(%q)\n got: %q\nwant: %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestWithQuery(t *testing.T) {
+	err := WithQuery(errors.ErrorInternalServerError(), "SELECT * FROM users WHERE email = 'secret@example.com'")
+
+	fingerprint, _ := err.Fields[FieldQueryFingerprint].(string)
+	if fingerprint != "select * from users where email = ?" {
+		t.Errorf("Unexpected fingerprint %q", fingerprint)
+	}
+	if err.Fields[FieldQueryHash] != QueryHash("select * from users where email = 'other@example.com'") {
+		t.Error("Queries differing only in literals should share a hash")
+	}
+}
@@ -0,0 +1,85 @@
+package errorssql
+
+import (
+	stderrors "errors"
+	"fmt"
+	"strings"
+
+	errors "github.com/andryhardiyanto/go-errors"
+)
+
+// Field keys set by MigrationError
+const (
+	FieldMigrationVersion   = "db_migration_version"
+	FieldMigrationStatement = "db_migration_statement"
+	FieldSQLState           = "db_sqlstate"
+)
+
+// sqlStater is implemented by driver errors exposing an SQLSTATE code, e.g. pgx's *pgconn.PgError
+// and lib/pq's *pq.Error
+type sqlStater interface {
+	SQLState() string
+}
+
+// SQLState returns the SQLSTATE code of the first error in err's chain that reports one,
+// or "" when there is none
+func SQLState(err error) string {
+	var stater sqlStater
+	if stderrors.As(err, &stater) {
+		return stater.SQLState()
+	}
+	return ""
+}
+
+// MigrationError reports that statement number index (starting at 1) of migration version failed.
+// The statement itself is attached as a fingerprint (see WithQuery) and the SQLSTATE of err, if any,
+// under FieldSQLState. An index of 0 means the failing statement is unknown.
+func MigrationError(version string, index int, statement string, err error) *errors.Error {
+	message := fmt.Sprintf("Migration %s failed", version)
+	if index > 0 {
+		message = fmt.Sprintf("Migration %s failed at statement %d", version, index)
+	}
+
+	return errors.NewWith(500, message, "MIGRATION_FAILED", errors.OverrideCause(err), func(e *errors.Error) {
+		e.WithField(FieldMigrationVersion, version)
+		if index > 0 {
+			e.WithField(FieldMigrationStatement, index)
+		}
+		if statement != "" {
+			WithQuery(e, statement)
+		}
+		if state := SQLState(err); state != "" {
+			e.WithField(FieldSQLState, state)
+		}
+	})
+}
+
+// RenderMigrationReport renders a migration failure as an aligned multi-line block for CI logs.
+// Errors that are not migration errors are rendered with their message only.
+func RenderMigrationReport(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var e *errors.Error
+	if !stderrors.As(err, &e) || e.Type != "MIGRATION_FAILED" {
+		return fmt.Sprintf("migration failed: %s\n", err)
+	}
+
+	var b strings.Builder
+	b.WriteString("migration failed\n")
+	line := func(label string, value any) {
+		if value != nil && value != "" {
+			fmt.Fprintf(&b, "  %-10s %v\n", label+":", value)
+		}
+	}
+	line("version", e.Fields[FieldMigrationVersion])
+	line("statement", e.Fields[FieldMigrationStatement])
+	line("sqlstate", e.Fields[FieldSQLState])
+	line("query", e.Fields[FieldQueryFingerprint])
+	if e.Err != nil {
+		line("cause", e.Err.Error())
+	}
+
+	return b.String()
+}
@@ -0,0 +1,48 @@
+package errorssql
+
+import (
+	"fmt"
+	"testing"
+)
+
+type pgError struct {
+	code, message string
+}
+
+func (e *pgError) Error() string    { return e.message }
+func (e *pgError) SQLState() string { return e.code }
+
+func TestMigrationError(t *testing.T) {
+	cause := fmt.Errorf("exec: %w", &pgError{code: "42P07", message: `relation "users" already exists`})
+	err := MigrationError("20240101120000", 2, "CREATE TABLE users (id int)", cause)
+
+	if err.Type != "MIGRATION_FAILED" || err.Message != "Migration 20240101120000 failed at statement 2" {
+		t.Errorf("Unexpected error %s: %s", err.Type, err.Message)
+	}
+	if err.Fields[FieldSQLState] != "42P07" || err.Fields[FieldMigrationStatement] != 2 {
+		t.Errorf("Unexpected fields %v", err.Fields)
+	}
+	if err.Unwrap() != cause {
+		t.Error("MigrationError should wrap the cause")
+	}
+
+	expected := `migration failed
+  version:   20240101120000
+  statement: 2
+  sqlstate:  42P07
+  query:     create table users (id int)
+  cause:     exec: relation "users" already exists
+`
+	if got := RenderMigrationReport(err); got != expected {
+		t.Errorf("Unexpected report:\n%s", got)
+	}
+}
+
+func TestRenderMigrationReportOtherError(t *testing.T) {
+	if got := RenderMigrationReport(fmt.Errorf("connection refused")); got != "migration failed: connection refused\n" {
+		t.Errorf("Unexpected report %q", got)
+	}
+	if SQLState(fmt.Errorf("plain")) != "" {
+		t.Error("SQLState should be empty without a driver error")
+	}
+}
@@ -0,0 +1,103 @@
+package errorssql
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+
+	errors "github.com/andryhardiyanto/go-errors"
+)
+
+// Field keys set by InTx
+const (
+	FieldTx              = "db_tx"
+	FieldTxIsolation     = "db_tx_isolation"
+	FieldTxRollback      = "db_tx_rollback"
+	FieldTxRollbackError = "db_tx_rollback_error"
+)
+
+// Rollback outcomes recorded under FieldTxRollback
+const (
+	RollbackSucceeded = "succeeded"
+	RollbackFailed    = "failed"
+)
+
+// TxBeginner starts transactions; *sql.DB and *sql.Conn implement it
+type TxBeginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+// InTx runs fn in a transaction named name, committing when fn succeeds and rolling back otherwise.
+// Any error is returned as a new *errors.Error wrapping it, with the classification of the nearest
+// *errors.Error in its chain (see errors.Ensure), annotated with the transaction name, isolation
+// level and rollback outcome; the error returned by fn is not modified. A failed rollback is
+// attached under FieldTxRollbackError and stays reachable with errors.Is, errors.As and
+// RollbackError without changing the error message. A panic in fn rolls back and re-panics.
+func InTx(ctx context.Context, db TxBeginner, name string, opts *sql.TxOptions, fn func(ctx context.Context, tx *sql.Tx) error) (err error) {
+	isolation := sql.LevelDefault
+	if opts != nil {
+		isolation = opts.Isolation
+	}
+
+	annotate := func(cause, rollback error) *errors.Error {
+		return errors.Ensure(&txError{cause: cause, rollback: rollback}).
+			WithField(FieldTx, name).
+			WithField(FieldTxIsolation, isolation.String())
+	}
+
+	tx, beginErr := db.BeginTx(ctx, opts)
+	if beginErr != nil {
+		return annotate(beginErr, nil)
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			_ = tx.Rollback()
+			panic(recovered)
+		}
+	}()
+
+	if fnErr := fn(ctx, tx); fnErr != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil && !stderrors.Is(rollbackErr, sql.ErrTxDone) {
+			return annotate(fnErr, rollbackErr).
+				WithField(FieldTxRollback, RollbackFailed).
+				WithField(FieldTxRollbackError, rollbackErr.Error())
+		}
+		return annotate(fnErr, nil).WithField(FieldTxRollback, RollbackSucceeded)
+	}
+
+	if commitErr := tx.Commit(); commitErr != nil {
+		return annotate(commitErr, nil)
+	}
+
+	return nil
+}
+
+// RollbackError returns the rollback failure attached to an error returned by InTx, or nil
+func RollbackError(err error) error {
+	var failure *txError
+	if !stderrors.As(err, &failure) {
+		return nil
+	}
+	return failure.rollback
+}
+
+// txError is the cause of the errors InTx returns: the error of the failed step, with its text,
+// and the failed rollback, if any. Unwrap follows the cause while Is and As also match the
+// rollback error.
+type txError struct {
+	cause    error
+	rollback error
+}
+
+func (f *txError) Error() string { return f.cause.Error() }
+
+func (f *txError) Unwrap() error { return f.cause }
+
+func (f *txError) Is(target error) bool {
+	return f.rollback != nil && stderrors.Is(f.rollback, target)
+}
+
+func (f *txError) As(target any) bool {
+	return f.rollback != nil && stderrors.As(f.rollback, target)
+}
@@ -0,0 +1,153 @@
+package errorssql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	stderrors "errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	errors "github.com/andryhardiyanto/go-errors"
+)
+
+// fakeDriver implements just enough of database/sql/driver to exercise transactions
+type (
+	fakeDriver struct {
+		rollbackErr error
+		commits     atomic.Int32
+		rollbacks   atomic.Int32
+	}
+	fakeConn struct{ d *fakeDriver }
+	fakeTx   struct{ d *fakeDriver }
+)
+
+var driverSeq atomic.Int32
+
+func (d *fakeDriver) Open(string) (driver.Conn, error) { return fakeConn{d}, nil }
+func (c fakeConn) Prepare(string) (driver.Stmt, error) { return nil, fmt.Errorf("not supported") }
+func (c fakeConn) Close() error                        { return nil }
+func (c fakeConn) Begin() (driver.Tx, error)           { return fakeTx{c.d}, nil }
+func (t fakeTx) Commit() error                         { t.d.commits.Add(1); return nil }
+func (t fakeTx) Rollback() error                       { t.d.rollbacks.Add(1); return t.d.rollbackErr }
+
+func openFake(t *testing.T, rollbackErr error) (*sql.DB, *fakeDriver) {
+	t.Helper()
+
+	d := &fakeDriver{rollbackErr: rollbackErr}
+	name := fmt.Sprintf("fake-%d", driverSeq.Add(1))
+	sql.Register(name, d)
+
+	db, err := sql.Open(name, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db, d
+}
+
+func TestInTxCommit(t *testing.T) {
+	db, d := openFake(t, nil)
+
+	err := InTx(context.Background(), db, "create_order", nil, func(ctx context.Context, tx *sql.Tx) error {
+		return nil
+	})
+	if err != nil || d.commits.Load() != 1 {
+		t.Errorf("Expected a commit, got %v", err)
+	}
+}
+
+func TestInTxRollback(t *testing.T) {
+	db, _ := openFake(t, nil)
+
+	err := InTx(context.Background(), db, "create_order", nil, func(ctx context.Context, tx *sql.Tx) error {
+		return errors.ErrorConflict()
+	})
+
+	var e *errors.Error
+	if !stderrors.As(err, &e) || e.Type != "CONFLICT" {
+		t.Fatalf("Error classification should be kept, got %v", err)
+	}
+	if e.Fields[FieldTx] != "create_order" || e.Fields[FieldTxIsolation] != "Default" || e.Fields[FieldTxRollback] != RollbackSucceeded {
+		t.Errorf("Unexpected annotations %v", e.Fields)
+	}
+}
+
+func TestInTxRollbackFailure(t *testing.T) {
+	rollbackErr := fmt.Errorf("connection lost")
+	db, _ := openFake(t, rollbackErr)
+
+	fnErr := fmt.Errorf("insert failed")
+	err := InTx(context.Background(), db, "transfer", &sql.TxOptions{}, func(ctx context.Context, tx *sql.Tx) error {
+		return fnErr
+	})
+
+	var e *errors.Error
+	if !stderrors.As(err, &e) || !stderrors.Is(err, fnErr) {
+		t.Fatalf("Expected wrapped fn error, got %v", err)
+	}
+	if e.Fields[FieldTxRollback] != RollbackFailed || e.Fields[FieldTxRollbackError] != "connection lost" {
+		t.Errorf("Rollback failure should be attached, got %v", e.Fields)
+	}
+	if !stderrors.Is(err, rollbackErr) || RollbackError(err) != rollbackErr {
+		t.Error("Rollback error should be reachable from the returned error")
+	}
+	if err.Error() != "insert failed" {
+		t.Errorf("Message should stay that of the fn error, got %q", err.Error())
+	}
+}
+
+func TestInTxRollbackFailureKeepsClassification(t *testing.T) {
+	db, _ := openFake(t, sql.ErrConnDone)
+
+	err := InTx(context.Background(), db, "transfer", nil, func(ctx context.Context, tx *sql.Tx) error {
+		return errors.ErrorConflict()
+	})
+
+	if !stderrors.Is(err, errors.ErrorConflict()) || !stderrors.Is(err, sql.ErrConnDone) {
+		t.Errorf("Both the fn error and the rollback error should match, got %v", err)
+	}
+	if err.Error() != errors.ErrorConflict().Message {
+		t.Errorf("Message should be kept, got %q", err.Error())
+	}
+	if RollbackError(errors.ErrorConflict()) != nil {
+		t.Error("Errors without a failed rollback should report none")
+	}
+}
+
+func TestInTxLeavesCallerErrorUntouched(t *testing.T) {
+	db, _ := openFake(t, sql.ErrConnDone)
+
+	conflict := errors.ErrorConflict()
+	err := InTx(context.Background(), db, "save_order", nil, func(ctx context.Context, tx *sql.Tx) error {
+		return fmt.Errorf("saving: %w", conflict)
+	})
+
+	var e *errors.Error
+	if !stderrors.As(err, &e) || e == conflict || e.Type != "CONFLICT" {
+		t.Fatalf("A new error with the classification of the fn error should be returned, got %v", err)
+	}
+	if err.Error() != "saving: Conflict" {
+		t.Errorf("The wrapping context of the fn error should be kept, got %q", err.Error())
+	}
+	if conflict.Fields != nil || conflict.Err != nil {
+		t.Errorf("The fn error should not be modified, got %v %v", conflict.Fields, conflict.Err)
+	}
+	if !stderrors.Is(err, conflict) || RollbackError(err) != sql.ErrConnDone {
+		t.Error("The fn error and the rollback error should stay reachable")
+	}
+}
+
+func TestInTxPanic(t *testing.T) {
+	db, d := openFake(t, nil)
+
+	defer func() {
+		if recover() != "boom" || d.rollbacks.Load() != 1 {
+			t.Error("Panics should roll back and propagate")
+		}
+	}()
+	_ = InTx(context.Background(), db, "panic", nil, func(ctx context.Context, tx *sql.Tx) error {
+		panic("boom")
+	})
+}
@@ -0,0 +1,159 @@
+package errorstest
+
+import (
+	stderrors "errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	errors "github.com/andryhardiyanto/go-errors"
+)
+
+// AssertType fails the test unless err's chain holds an *errors.Error of errorType, see
+// errors.IsType. The first *errors.Error in the chain is returned for further assertions, or
+// nil when there is none.
+func AssertType(t testing.TB, err error, errorType string) *errors.Error {
+	t.Helper()
+
+	e := find(t, err, fmt.Sprintf("type %q", errorType))
+	if e != nil && !errors.IsType(err, errorType) {
+		t.Errorf("errorstest: error type = %q, want %q\n%s", e.Type, errorType, describe(e))
+	}
+	return e
+}
+
+// AssertCode fails the test unless err's chain holds an *errors.Error with code, see
+// errors.IsCode. The first *errors.Error in the chain is returned, or nil when there is none.
+func AssertCode(t testing.TB, err error, code int64) *errors.Error {
+	t.Helper()
+
+	e := find(t, err, fmt.Sprintf("code %d", code))
+	if e != nil && !errors.IsCode(err, code) {
+		t.Errorf("errorstest: error code = %d, want %d\n%s", e.Code, code, describe(e))
+	}
+	return e
+}
+
+// AssertViolation fails the test unless an *errors.Error in err's chain has a violation of
+// violationType on field. The violations present are listed on failure. The first
+// *errors.Error in the chain is returned, or nil when there is none.
+func AssertViolation(t testing.TB, err error, field string, violationType errors.ViolationErrorType) *errors.Error {
+	t.Helper()
+
+	e := find(t, err, fmt.Sprintf("violation %s [%s]", field, violationType))
+	if e == nil {
+		return nil
+	}
+
+	var present []errors.ValidationError
+	for _, inner := range errors.Chain(err) {
+		if appErr, ok := inner.(*errors.Error); ok {
+			present = append(present, appErr.Clone().Violations...)
+		}
+	}
+	if !slices.ContainsFunc(present, func(v errors.ValidationError) bool {
+		return v.Field == field && v.Type == violationType
+	}) {
+		t.Errorf("errorstest: no violation %s [%s], got:%s", field, violationType, violationLines(present))
+	}
+	return e
+}
+
+// AssertError fails the test unless the first *errors.Error in err's chain matches want in
+// type, code, business code, message and violations (field, type and message), and holds the
+// fields set on want with equal values. The failure lists each difference as -want +got.
+// The first *errors.Error is returned, or nil when there is none.
+func AssertError(t testing.TB, err error, want *errors.Error) *errors.Error {
+	t.Helper()
+
+	e := find(t, err, fmt.Sprintf("%s (%d)", want.Type, want.Code))
+	if e == nil {
+		return nil
+	}
+	if diff := Diff(want, e); diff != "" {
+		t.Errorf("errorstest: error mismatch (-want +got):\n%s", diff)
+	}
+	return e
+}
+
+// Diff returns the differences AssertError reports between want and got, one per line as
+// "name: -want +got", or "" when they match
+func Diff(want, got *errors.Error) string {
+	w, g := want.Clone(), got.Clone()
+
+	var lines []string
+	add := func(name string, want, got any) {
+		if !reflect.DeepEqual(want, got) {
+			lines = append(lines, fmt.Sprintf("  %s: -%#v +%#v", name, want, got))
+		}
+	}
+
+	add("type", w.Type, g.Type)
+	add("code", w.Code, g.Code)
+	add("business_code", w.BusinessCode, g.BusinessCode)
+	add("message", w.Message, g.Message)
+
+	for i := range max(len(w.Violations), len(g.Violations)) {
+		var wantV, gotV string
+		if i < len(w.Violations) {
+			wantV = violationString(w.Violations[i])
+		}
+		if i < len(g.Violations) {
+			gotV = violationString(g.Violations[i])
+		}
+		add(fmt.Sprintf("violations[%d]", i), wantV, gotV)
+	}
+
+	keys := make([]string, 0, len(w.Fields))
+	for key := range w.Fields {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		add("fields["+key+"]", w.Fields[key], g.Fields[key])
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// find returns the first *errors.Error in err's chain, failing the test when err is nil or
+// holds none; expected describes what was expected
+func find(t testing.TB, err error, expected string) *errors.Error {
+	t.Helper()
+
+	if err == nil {
+		t.Errorf("errorstest: got no error, want %s", expected)
+		return nil
+	}
+	var e *errors.Error
+	if !stderrors.As(err, &e) || e == nil {
+		t.Errorf("errorstest: got %T %q, want an *errors.Error with %s", err, err, expected)
+		return nil
+	}
+	return e
+}
+
+// describe renders the classification of e for failure messages
+func describe(e *errors.Error) string {
+	c := e.Clone()
+	return fmt.Sprintf("  got %s (%d): %s%s", c.Type, c.Code, c.Message, violationLines(c.Violations))
+}
+
+// violationLines renders violations one per line, or " none"
+func violationLines(violations []errors.ValidationError) string {
+	if len(violations) == 0 {
+		return " none"
+	}
+	var b strings.Builder
+	for _, v := range violations {
+		b.WriteString("\n    " + violationString(v))
+	}
+	return b.String()
+}
+
+// violationString renders v as "field [TYPE]: message"
+func violationString(v errors.ValidationError) string {
+	return fmt.Sprintf("%s [%s]: %s", v.Field, v.Type, v.Message)
+}
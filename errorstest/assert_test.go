@@ -0,0 +1,94 @@
+package errorstest
+
+import (
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	errors "github.com/andryhardiyanto/go-errors"
+)
+
+func TestAssertType(t *testing.T) {
+	tb := &recordingTB{}
+	if e := AssertType(tb, fmt.Errorf("loading: %w", errors.ErrorNotFound()), "NOT_FOUND"); e == nil || len(tb.failures) != 0 {
+		t.Errorf("Matching type should pass, got %v %v", e, tb.failures)
+	}
+
+	tb = &recordingTB{}
+	AssertType(tb, errors.ErrorConflict(), "NOT_FOUND")
+	if len(tb.failures) != 1 || !strings.Contains(tb.failures[0], `error type = "CONFLICT", want "NOT_FOUND"`) {
+		t.Errorf("Other types should fail, got %v", tb.failures)
+	}
+
+	tb = &recordingTB{}
+	AssertType(tb, nil, "NOT_FOUND")
+	AssertType(tb, stderrors.New("plain"), "NOT_FOUND")
+	if len(tb.failures) != 2 || !strings.Contains(tb.failures[0], "got no error") || !strings.Contains(tb.failures[1], "*errors.errorString") {
+		t.Errorf("Missing *errors.Error should fail, got %v", tb.failures)
+	}
+}
+
+func TestAssertCode(t *testing.T) {
+	tb := &recordingTB{}
+	AssertCode(tb, errors.ErrorForbidden(), 403)
+	AssertCode(tb, errors.ErrorForbidden(), 401)
+	if len(tb.failures) != 1 || !strings.Contains(tb.failures[0], "error code = 403, want 401") {
+		t.Errorf("Unexpected failures %v", tb.failures)
+	}
+}
+
+func TestAssertViolation(t *testing.T) {
+	err := errors.Violations([]errors.ValidationError{
+		{Type: errors.ViolationErrorTypeRequired, Field: "email", Message: "Email is required"},
+	})
+
+	tb := &recordingTB{}
+	AssertViolation(tb, fmt.Errorf("signup: %w", err), "email", errors.ViolationErrorTypeRequired)
+	if len(tb.failures) != 0 {
+		t.Errorf("Present violation should pass, got %v", tb.failures)
+	}
+
+	AssertViolation(tb, err, "name", errors.ViolationErrorTypeRequired)
+	if len(tb.failures) != 1 || !strings.Contains(tb.failures[0], "no violation name [REQUIRED], got:\n    email [REQUIRED]: Email is required") {
+		t.Errorf("Missing violation should list the present ones, got %v", tb.failures)
+	}
+}
+
+func TestAssertError(t *testing.T) {
+	got := errors.Violations([]errors.ValidationError{
+		{Type: errors.ViolationErrorTypeRequired, Field: "email", Message: "Email is required"},
+	}).WithField("tenant", "acme")
+
+	tb := &recordingTB{}
+	want := &errors.Error{
+		Type:       "UNPROCESSABLE_ENTITY",
+		Code:       422,
+		Message:    "Unprocessable entity",
+		Violations: []errors.ValidationError{{Type: errors.ViolationErrorTypeRequired, Field: "email", Message: "Email is required"}},
+		Fields:     map[string]any{"tenant": "acme"},
+	}
+	AssertError(tb, got, want)
+	if len(tb.failures) != 0 {
+		t.Errorf("Matching error should pass, got %v", tb.failures)
+	}
+
+	want.Code = 400
+	want.Violations = append(want.Violations, errors.ValidationError{Type: errors.ViolationErrorTypeRequired, Field: "name", Message: "Name is required"})
+	AssertError(tb, got, want)
+	if len(tb.failures) != 1 {
+		t.Fatalf("Mismatch should fail once, got %v", tb.failures)
+	}
+	for _, line := range []string{
+		"error mismatch (-want +got)",
+		"  code: -400 +422",
+		`  violations[1]: -"name [REQUIRED]: Name is required" +""`,
+	} {
+		if !strings.Contains(tb.failures[0], line) {
+			t.Errorf("Failure should contain %q, got:\n%s", line, tb.failures[0])
+		}
+	}
+	if strings.Contains(tb.failures[0], "type:") || strings.Contains(tb.failures[0], "violations[0]") {
+		t.Errorf("Matching attributes should not be listed, got:\n%s", tb.failures[0])
+	}
+}
@@ -0,0 +1,36 @@
+// Package errorstest provides test helpers for code producing *errors.Error values.
+package errorstest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	errors "github.com/andryhardiyanto/go-errors"
+)
+
+// AssertOnlyTypes serves req with handler and fails the test when the response is an error whose
+// type is not one of allowed. It guards public endpoints against leaking new internal error
+// types. The parsed error is returned for further assertions, or nil for a successful response.
+func AssertOnlyTypes(t testing.TB, handler http.Handler, req *http.Request, allowed ...string) *errors.Error {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	resp := rec.Result()
+	defer resp.Body.Close()
+
+	e, err := errors.ParseResponse(resp)
+	if err != nil {
+		t.Fatalf("errorstest: reading %s %s response: %v", req.Method, req.URL, err)
+		return nil
+	}
+	if e != nil && !slices.Contains(allowed, e.Type) {
+		t.Errorf("errorstest: %s %s responded %d with error type %q, allowed types are %q",
+			req.Method, req.URL, resp.StatusCode, e.Type, allowed)
+	}
+
+	return e
+}
@@ -0,0 +1,57 @@
+package errorstest
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	errors "github.com/andryhardiyanto/go-errors"
+)
+
+// recordingTB captures failures instead of failing the test
+type recordingTB struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Errorf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func (r *recordingTB) Fatalf(format string, args ...any) {
+	r.Errorf(format, args...)
+}
+
+func respond(err *errors.Error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err == nil {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		errors.WriteJSON(w, err)
+	})
+}
+
+func TestAssertOnlyTypes(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+
+	tb := &recordingTB{}
+	if e := AssertOnlyTypes(tb, respond(errors.ErrorNotFound()), req, "NOT_FOUND", "FORBIDDEN"); e == nil || e.Type != "NOT_FOUND" || len(tb.failures) != 0 {
+		t.Errorf("Allowed types should pass, got %v %v", e, tb.failures)
+	}
+
+	tb = &recordingTB{}
+	AssertOnlyTypes(tb, respond(errors.New(500, "Database down", "DATABASE_UNAVAILABLE")), req, "NOT_FOUND")
+	if len(tb.failures) != 1 || !strings.Contains(tb.failures[0], `"DATABASE_UNAVAILABLE"`) {
+		t.Errorf("Unexpected types should fail, got %v", tb.failures)
+	}
+
+	tb = &recordingTB{}
+	if e := AssertOnlyTypes(tb, respond(nil), req); e != nil || len(tb.failures) != 0 {
+		t.Errorf("Successful responses should pass, got %v %v", e, tb.failures)
+	}
+}
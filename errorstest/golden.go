@@ -0,0 +1,180 @@
+package errorstest
+
+import (
+	"bytes"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"testing"
+
+	errors "github.com/andryhardiyanto/go-errors"
+)
+
+// UpdateGoldenEnv is the environment variable that makes AssertGolden rewrite golden files
+// instead of comparing, e.g. ERRORSTEST_UPDATE=1 go test ./...
+const UpdateGoldenEnv = "ERRORSTEST_UPDATE"
+
+// Placeholders replacing volatile values in rendered output
+const (
+	IDPlaceholder        = "<id>"
+	TimestampPlaceholder = "<timestamp>"
+	EnvelopePlaceholder  = "<envelope>"
+)
+
+type (
+	// RenderOption customizes Render and DumpResponse
+	RenderOption func(*renderOptions)
+
+	renderOptions struct {
+		stripFrames bool
+	}
+)
+
+// StripFrames drops stack traces from the output, so golden files do not change when code moves
+func StripFrames() RenderOption {
+	return func(o *renderOptions) {
+		o.stripFrames = true
+	}
+}
+
+// Render returns a deterministic rendering of the JSON form of the first *errors.Error in err's
+// chain for golden-file tests: indented, with object keys sorted, IDs and timestamps replaced by
+// IDPlaceholder and TimestampPlaceholder, and stack frames reduced to "file.go:line function" without directories, or stripped with
+// StripFrames. Errors without an *errors.Error are rendered wrapped. Render(nil) returns "null".
+func Render(err error, opts ...RenderOption) []byte {
+	var e *errors.Error
+	if !stderrors.As(err, &e) || e == nil {
+		e = errors.Wrap(err)
+	}
+	if e == nil {
+		return []byte("null\n")
+	}
+
+	data, marshalErr := json.Marshal(e)
+	if marshalErr != nil {
+		return []byte(fmt.Sprintf("<unrenderable: %v>\n", marshalErr))
+	}
+	return normalize(data, opts)
+}
+
+// DumpResponse returns a deterministic rendering of an error response for golden-file tests: the
+// status line, the headers sorted by name, with EnvelopeHeader replaced by EnvelopePlaceholder,
+// and the body, normalized like Render when it is JSON. The body is consumed but not closed.
+func DumpResponse(resp *http.Response, opts ...RenderOption) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "HTTP %d %s\n", resp.StatusCode, http.StatusText(resp.StatusCode))
+
+	keys := make([]string, 0, len(resp.Header))
+	for key := range resp.Header {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		value := strings.Join(resp.Header[key], ", ")
+		if key == errors.EnvelopeHeader {
+			value = EnvelopePlaceholder
+		}
+		fmt.Fprintf(&b, "%s: %s\n", key, value)
+	}
+	b.WriteString("\n")
+
+	body, _ := io.ReadAll(resp.Body)
+	if json.Valid(body) {
+		body = normalize(body, opts)
+	}
+	b.Write(body)
+	return b.Bytes()
+}
+
+// AssertGolden fails the test when got differs from the contents of the golden file at path,
+// showing both. With UpdateGoldenEnv set the file is written instead.
+func AssertGolden(t testing.TB, path string, got []byte) {
+	t.Helper()
+
+	if os.Getenv(UpdateGoldenEnv) != "" {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("errorstest: updating golden file: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("errorstest: reading golden file (set %s=1 to create it): %v", UpdateGoldenEnv, err)
+		return
+	}
+	if !bytes.Equal(want, got) {
+		t.Errorf("errorstest: output differs from %s (set %s=1 to update)\n--- want\n%s\n--- got\n%s", path, UpdateGoldenEnv, want, got)
+	}
+}
+
+// normalize re-encodes the JSON document data with volatile values replaced
+func normalize(data []byte, opts []RenderOption) []byte {
+	var o renderOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	var doc any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return data
+	}
+	normalizeValue(doc, o)
+
+	var out bytes.Buffer
+	encoder := json.NewEncoder(&out)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return data
+	}
+	return out.Bytes()
+}
+
+// normalizeValue replaces volatile values in the objects of v
+func normalizeValue(v any, o renderOptions) {
+	switch v := v.(type) {
+	case map[string]any:
+		if id, ok := v["id"].(string); ok && id != "" {
+			v["id"] = IDPlaceholder
+		}
+		if _, ok := v["timestamp"].(string); ok {
+			v["timestamp"] = TimestampPlaceholder
+		}
+		if frames, ok := v["stack_traces"].([]any); ok {
+			if o.stripFrames {
+				delete(v, "stack_traces")
+			} else {
+				for i, frame := range frames {
+					if entry, ok := frame.(string); ok {
+						frames[i] = normalizeFrame(entry)
+					}
+				}
+			}
+		}
+		for _, value := range v {
+			normalizeValue(value, o)
+		}
+	case []any:
+		for _, value := range v {
+			normalizeValue(value, o)
+		}
+	}
+}
+
+// normalizeFrame removes the directories from a "file:line function" stack entry
+func normalizeFrame(entry string) string {
+	space := strings.LastIndex(entry, " ")
+	if space < 0 {
+		return entry
+	}
+	return path.Base(entry[:space]) + entry[space:]
+}
@@ -0,0 +1,99 @@
+package errorstest
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	errors "github.com/andryhardiyanto/go-errors"
+)
+
+func TestRender(t *testing.T) {
+	e := errors.ErrorNotFound().WithField("user_id", 42)
+	e.ID = "err_8f14e45f"
+	e.StackTraces = []string{"/home/ci/src/my app/store.go:12 app/store.Find", "/home/ci/src/app/main.go:5 main.main"}
+
+	want := `{
+  "code": 404,
+  "fields": {
+    "user_id": 42
+  },
+  "id": "<id>",
+  "message": "Not found",
+  "stack_traces": [
+    "store.go:12 app/store.Find",
+    "main.go:5 main.main"
+  ],
+  "timestamp": "<timestamp>",
+  "type": "NOT_FOUND",
+  "violations": []
+}
+`
+	if got := string(Render(e)); got != want {
+		t.Errorf("Render =\n%s\nwant\n%s", got, want)
+	}
+	if got := string(Render(e, StripFrames())); strings.Contains(got, "stack_traces") {
+		t.Errorf("StripFrames should drop the stack, got\n%s", got)
+	}
+	if string(Render(nil)) != "null\n" {
+		t.Error("Render(nil) should be null")
+	}
+
+	// Errors created at different places render identically without frames
+	if string(Render(errors.ErrorConflict(), StripFrames())) != string(Render(errors.ErrorConflict(), StripFrames())) {
+		t.Error("Render should be deterministic")
+	}
+}
+
+func TestDumpResponse(t *testing.T) {
+	e := errors.ErrorTooManyRequests().WithRetryAfter(30 * time.Second)
+	e.ID = "err_1"
+	rec := httptest.NewRecorder()
+	errors.WriteJSON(rec, e)
+
+	got := string(DumpResponse(rec.Result(), StripFrames()))
+	for _, part := range []string{
+		"HTTP 429 Too Many Requests\n",
+		"Content-Type: application/json\nRetry-After: 30\nX-Error-Envelope: <envelope>\n\n{",
+		`"id": "<id>"`,
+	} {
+		if !strings.Contains(got, part) {
+			t.Errorf("DumpResponse should contain %q, got\n%s", part, got)
+		}
+	}
+}
+
+func TestAssertGolden(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "not_found.golden")
+	got := Render(errors.ErrorNotFound(), StripFrames())
+
+	tb := &recordingTB{}
+	AssertGolden(tb, path, got)
+	if len(tb.failures) != 1 || !strings.Contains(tb.failures[0], UpdateGoldenEnv) {
+		t.Errorf("A missing golden file should fail with a hint, got %v", tb.failures)
+	}
+
+	t.Setenv(UpdateGoldenEnv, "1")
+	AssertGolden(tb, path, got)
+	if written, _ := os.ReadFile(path); string(written) != string(got) {
+		t.Errorf("The golden file should be written, got %s", written)
+	}
+
+	t.Setenv(UpdateGoldenEnv, "")
+	tb = &recordingTB{}
+	AssertGolden(tb, path, got)
+	AssertGolden(tb, path, Render(errors.ErrorConflict(), StripFrames()))
+	if len(tb.failures) != 1 || !strings.Contains(tb.failures[0], "--- want") {
+		t.Errorf("Only the differing output should fail, got %v", tb.failures)
+	}
+}
+
+func TestRenderTypedNil(t *testing.T) {
+	var e *errors.Error
+	if string(Render(e)) != "null\n" {
+		t.Error("Render of a nil *errors.Error should be null")
+	}
+}
@@ -0,0 +1,12 @@
+module github.com/andryhardiyanto/go-errors/errorstwirp
+
+go 1.26.2
+
+require (
+	github.com/andryhardiyanto/go-errors v0.0.0
+	github.com/twitchtv/twirp v8.1.3+incompatible
+)
+
+require github.com/pkg/errors v0.9.1 // indirect
+
+replace github.com/andryhardiyanto/go-errors => ../
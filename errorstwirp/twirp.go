@@ -0,0 +1,226 @@
+// Package errorstwirp converts between *errors.Error and Twirp errors.
+package errorstwirp
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"strconv"
+	"strings"
+	"time"
+
+	errors "github.com/andryhardiyanto/go-errors"
+	"github.com/twitchtv/twirp"
+)
+
+// Metadata keys set by ToTwirp
+const (
+	MetaType       = "type"
+	MetaCode       = "code"
+	MetaID         = "id"
+	MetaRetryAfter = "retry_after"
+	MetaViolations = "violations"
+
+	// MetaFieldPrefix prefixes the keys of fields, e.g. "field.dependency"
+	MetaFieldPrefix = "field."
+)
+
+type (
+	// Option customizes ToTwirp
+	Option func(*options)
+
+	options struct {
+		fields []string
+	}
+)
+
+// WithFields carries the given fields of the error as metadata, JSON-encoded under
+// MetaFieldPrefix + key. Fields are internal details, so only the listed keys are sent.
+func WithFields(keys ...string) Option {
+	return func(o *options) {
+		o.fields = append(o.fields, keys...)
+	}
+}
+
+// codeByStatus maps HTTP-style error codes to Twirp error codes
+var codeByStatus = map[int64]twirp.ErrorCode{
+	400: twirp.InvalidArgument,
+	401: twirp.Unauthenticated,
+	403: twirp.PermissionDenied,
+	404: twirp.NotFound,
+	408: twirp.DeadlineExceeded,
+	409: twirp.AlreadyExists,
+	412: twirp.FailedPrecondition,
+	422: twirp.InvalidArgument,
+	429: twirp.ResourceExhausted,
+	499: twirp.Canceled,
+	500: twirp.Internal,
+	501: twirp.Unimplemented,
+	503: twirp.Unavailable,
+	504: twirp.DeadlineExceeded,
+}
+
+// codeByGRPC maps gRPC codes, which Twirp error codes mirror, to Twirp error codes
+var codeByGRPC = map[uint32]twirp.ErrorCode{
+	1:  twirp.Canceled,
+	2:  twirp.Unknown,
+	3:  twirp.InvalidArgument,
+	4:  twirp.DeadlineExceeded,
+	5:  twirp.NotFound,
+	6:  twirp.AlreadyExists,
+	7:  twirp.PermissionDenied,
+	8:  twirp.ResourceExhausted,
+	9:  twirp.FailedPrecondition,
+	10: twirp.Aborted,
+	11: twirp.OutOfRange,
+	12: twirp.Unimplemented,
+	13: twirp.Internal,
+	14: twirp.Unavailable,
+	15: twirp.DataLoss,
+	16: twirp.Unauthenticated,
+}
+
+// statusByCode maps Twirp error codes to HTTP-style error codes and types
+var statusByCode = map[twirp.ErrorCode]struct {
+	code      int64
+	errorType string
+}{
+	twirp.Canceled:           {499, "CLIENT_CLOSED_REQUEST"},
+	twirp.Unknown:            {500, "INTERNAL_SERVER_ERROR"},
+	twirp.InvalidArgument:    {400, "BAD_REQUEST"},
+	twirp.Malformed:          {400, "BAD_REQUEST"},
+	twirp.DeadlineExceeded:   {504, "GATEWAY_TIMEOUT"},
+	twirp.NotFound:           {404, "NOT_FOUND"},
+	twirp.BadRoute:           {404, "NOT_FOUND"},
+	twirp.AlreadyExists:      {409, "CONFLICT"},
+	twirp.PermissionDenied:   {403, "FORBIDDEN"},
+	twirp.Unauthenticated:    {401, "UNAUTHORIZED"},
+	twirp.ResourceExhausted:  {429, "TOO_MANY_REQUEST"},
+	twirp.FailedPrecondition: {412, "PRECONDITION_FAILED"},
+	twirp.Aborted:            {409, "CONFLICT"},
+	twirp.OutOfRange:         {400, "BAD_REQUEST"},
+	twirp.Unimplemented:      {501, "NOT_IMPLEMENTED"},
+	twirp.Internal:           {500, "INTERNAL_SERVER_ERROR"},
+	twirp.Unavailable:        {503, "SERVICE_UNAVAILABLE"},
+	twirp.DataLoss:           {500, "INTERNAL_SERVER_ERROR"},
+}
+
+// Code returns the Twirp error code for err: the one matching its GRPCCode when set, otherwise a
+// mapping of its HTTP status (see errors.HTTPStatus) where other 4xx statuses become
+// FailedPrecondition and everything else Internal
+func Code(err *errors.Error) twirp.ErrorCode {
+	if code, ok := codeByGRPC[err.GRPCCode]; ok {
+		return code
+	}
+	status := int64(errors.HTTPStatus(err))
+	if code, ok := codeByStatus[status]; ok {
+		return code
+	}
+	if status >= 400 && status < 500 {
+		return twirp.FailedPrecondition
+	}
+	return twirp.Internal
+}
+
+// ToTwirp converts the Public() copy of err into a Twirp error. The type, code, ID and retry
+// delay travel as metadata, violations JSON-encoded under MetaViolations, and fields only when
+// listed with WithFields.
+func ToTwirp(err *errors.Error, opts ...Option) twirp.Error {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	public := err.Public()
+	twerr := twirp.NewError(Code(public), public.Message).
+		WithMeta(MetaType, public.Type).
+		WithMeta(MetaCode, strconv.FormatInt(public.Code, 10))
+	if public.ID != "" {
+		twerr = twerr.WithMeta(MetaID, public.ID)
+	}
+	if public.RetryAfter > 0 {
+		twerr = twerr.WithMeta(MetaRetryAfter, public.RetryAfter.String())
+	}
+	if len(public.Violations) > 0 {
+		if violations, marshalErr := json.Marshal(public.Violations); marshalErr == nil {
+			twerr = twerr.WithMeta(MetaViolations, string(violations))
+		}
+	}
+	for _, key := range o.fields {
+		value, ok := err.Fields[key]
+		if !ok {
+			continue
+		}
+		if encoded, marshalErr := json.Marshal(value); marshalErr == nil {
+			twerr = twerr.WithMeta(MetaFieldPrefix+key, string(encoded))
+		}
+	}
+
+	return twerr
+}
+
+// FromTwirp converts a Twirp error into an *errors.Error, restoring the type, code, ID, retry
+// delay, violations and fields when the error carries this package's metadata. A nil error or
+// one without a code returns nil.
+func FromTwirp(twerr twirp.Error) *errors.Error {
+	if twerr == nil || twerr.Code() == twirp.NoError {
+		return nil
+	}
+
+	mapped, ok := statusByCode[twerr.Code()]
+	if !ok {
+		mapped = statusByCode[twirp.Unknown]
+	}
+
+	return errors.NewWith(mapped.code, twerr.Msg(), mapped.errorType, restoreTwirp(twerr))
+}
+
+// FromError converts err into an *errors.Error: *errors.Error values in the chain are returned
+// as is, Twirp errors are converted with FromTwirp and anything else is wrapped
+func FromError(err error) *errors.Error {
+	if err == nil {
+		return nil
+	}
+
+	var e *errors.Error
+	if stderrors.As(err, &e) {
+		return e
+	}
+	var twerr twirp.Error
+	if stderrors.As(err, &twerr) {
+		return FromTwirp(twerr)
+	}
+	return errors.Wrap(err)
+}
+
+// restoreTwirp applies the cause and this package's metadata carried by twerr
+func restoreTwirp(twerr twirp.Error) errors.Override {
+	return func(e *errors.Error) {
+		e.Err = twerr
+
+		meta := twerr.MetaMap()
+		if errorType := meta[MetaType]; errorType != "" {
+			e.Type = errorType
+		}
+		if code, err := strconv.ParseInt(meta[MetaCode], 10, 64); err == nil {
+			e.Code = code
+		}
+		e.ID = meta[MetaID]
+		if retryAfter, err := time.ParseDuration(meta[MetaRetryAfter]); err == nil {
+			e.WithRetryAfter(retryAfter)
+		}
+		if violations := meta[MetaViolations]; violations != "" {
+			_ = json.Unmarshal([]byte(violations), &e.Violations)
+		}
+		for key, value := range meta {
+			field, ok := strings.CutPrefix(key, MetaFieldPrefix)
+			if !ok {
+				continue
+			}
+			var decoded any
+			if err := json.Unmarshal([]byte(value), &decoded); err != nil {
+				decoded = value
+			}
+			e.WithField(field, decoded)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package errorstwirp
+
+import (
+	stderrors "errors"
+	"fmt"
+	"testing"
+	"time"
+
+	errors "github.com/andryhardiyanto/go-errors"
+	"github.com/twitchtv/twirp"
+)
+
+func TestTwirpRoundTrip(t *testing.T) {
+	original := errors.Violations([]errors.ValidationError{
+		{Type: errors.ViolationErrorTypeRequired, Field: "email", Message: "Email is required"},
+	}).WithRetryAfter(time.Second).WithInternalMessage("secret").WithField("tenant", "acme").WithField("secret_key", "x")
+	original.ID = "abc"
+
+	twerr := ToTwirp(original, WithFields("tenant"))
+	if twerr.Code() != twirp.InvalidArgument || twerr.Msg() != "Unprocessable entity" {
+		t.Errorf("Unexpected Twirp error %v/%s", twerr.Code(), twerr.Msg())
+	}
+	if twerr.Meta(MetaFieldPrefix+"secret_key") != "" {
+		t.Error("Fields not listed with WithFields should not be sent")
+	}
+
+	decoded := FromTwirp(twerr)
+	if decoded.Type != "UNPROCESSABLE_ENTITY" || decoded.Code != 422 || decoded.ID != "abc" {
+		t.Errorf("Unexpected decoded error %s/%d/%s", decoded.Type, decoded.Code, decoded.ID)
+	}
+	if len(decoded.Violations) != 1 || decoded.Violations[0].Field != "email" || decoded.Violations[0].Type != errors.ViolationErrorTypeRequired {
+		t.Errorf("Violations should round-trip, got %+v", decoded.Violations)
+	}
+	if decoded.RetryAfter != time.Second || decoded.InternalMessage != "" {
+		t.Error("Retry delay should round-trip and internal details should not")
+	}
+	if decoded.Fields["tenant"] != "acme" {
+		t.Errorf("Listed fields should round-trip, got %v", decoded.Fields)
+	}
+}
+
+func TestFromTwirpPlain(t *testing.T) {
+	e := FromTwirp(twirp.NewError(twirp.Unavailable, "upstream down"))
+	if e.Type != "SERVICE_UNAVAILABLE" || e.Code != 503 || e.Message != "upstream down" {
+		t.Errorf("Unexpected error %s/%d/%s", e.Type, e.Code, e.Message)
+	}
+	if FromTwirp(nil) != nil {
+		t.Error("nil should convert to nil")
+	}
+}
+
+func TestCode(t *testing.T) {
+	e := errors.New(10423, "Insufficient funds", "INSUFFICIENT_FUNDS")
+	if Code(e) != twirp.Internal {
+		t.Errorf("Non-HTTP code should map to Internal, got %v", Code(e))
+	}
+
+	e.Status = 402
+	if Code(e) != twirp.FailedPrecondition {
+		t.Errorf("Other 4xx statuses should map to FailedPrecondition, got %v", Code(e))
+	}
+
+	e.GRPCCode = 10
+	if Code(e) != twirp.Aborted {
+		t.Errorf("GRPCCode should take precedence, got %v", Code(e))
+	}
+}
+
+func TestFromError(t *testing.T) {
+	if FromError(nil) != nil {
+		t.Error("nil should convert to nil")
+	}
+
+	original := errors.ErrorNotFound()
+	if FromError(fmt.Errorf("lookup: %w", original)) != original {
+		t.Error("*errors.Error in the chain should be returned as is")
+	}
+
+	twerr := twirp.NewError(twirp.NotFound, "missing")
+	e := FromError(fmt.Errorf("call: %w", twerr))
+	if e.Code != 404 || !stderrors.Is(e, twerr) {
+		t.Errorf("Twirp errors should be converted, got %d", e.Code)
+	}
+
+	if FromError(stderrors.New("boom")).Code != 500 {
+		t.Error("Other errors should be wrapped")
+	}
+}
@@ -0,0 +1,140 @@
+// Package errorsvalidator translates go-playground/validator errors into *errors.Error values.
+package errorsvalidator
+
+import (
+	stderrors "errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	errors "github.com/andryhardiyanto/go-errors"
+	"github.com/go-playground/validator/v10"
+)
+
+// tagTypes maps validator tags to the package's violation types
+var tagTypes = map[string]errors.ViolationErrorType{
+	"required":    errors.ViolationErrorTypeRequired,
+	"required_if": errors.ViolationErrorTypeRequiredIf,
+	"email":       errors.ViolationErrorTypeEmail,
+	"min":         errors.ViolationErrorTypeMin,
+	"max":         errors.ViolationErrorTypeMax,
+	"oneof":       errors.ViolationErrorTypeOneOf,
+	"uuid":        errors.ViolationErrorTypeUUID,
+	"uuid3":       errors.ViolationErrorTypeUUID,
+	"uuid4":       errors.ViolationErrorTypeUUID,
+	"uuid5":       errors.ViolationErrorTypeUUID,
+	"datetime":    errors.ViolationErrorTypeDateTime,
+	"len":         errors.ViolationErrorTypeLen,
+	"gt":          errors.ViolationErrorTypeGT,
+	"gte":         errors.ViolationErrorTypeGTE,
+	"lt":          errors.ViolationErrorTypeLT,
+	"lte":         errors.ViolationErrorTypeLTE,
+	"url":         errors.ViolationErrorTypeURL,
+	"http_url":    errors.ViolationErrorTypeURL,
+	"numeric":     errors.ViolationErrorTypeNumeric,
+	"number":      errors.ViolationErrorTypeNumeric,
+	"alphanum":    errors.ViolationErrorTypeAlphanum,
+	"e164":        errors.ViolationErrorTypePhone,
+	"unique":      errors.ViolationErrorTypeUnique,
+}
+
+type (
+	// Option customizes how validator errors are translated
+	Option func(*options)
+
+	options struct {
+		docsURL func(fieldErr validator.FieldError) string
+	}
+)
+
+// WithDocsURL sets a resolver returning the documentation URL attached to each violation.
+// An empty result leaves the violation without a docs URL.
+func WithDocsURL(resolver func(fieldErr validator.FieldError) string) Option {
+	return func(o *options) {
+		o.docsURL = resolver
+	}
+}
+
+// FromValidator converts the error returned by validator.Struct into a 422 *errors.Error.
+// validator.ValidationErrors become violations, any other error is wrapped, and nil returns nil.
+func FromValidator(err error, opts ...Option) *errors.Error {
+	if err == nil {
+		return nil
+	}
+
+	var validationErrors validator.ValidationErrors
+	if !stderrors.As(err, &validationErrors) {
+		return errors.Wrap(err)
+	}
+
+	return errors.Violations(Violations(validationErrors, opts...))
+}
+
+// Violations converts validator.ValidationErrors into a slice of errors.ValidationError.
+// The tag parameter is recorded in Params as errors.NewViolation does, e.g. {"min": 18}.
+func Violations(validationErrors validator.ValidationErrors, opts ...Option) []errors.ValidationError {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	violations := make([]errors.ValidationError, 0, len(validationErrors))
+	for _, fieldErr := range validationErrors {
+		path := FieldPath(fieldErr)
+		v := errors.NewViolation(ViolationType(fieldErr.Tag()), path, fieldErr.Param())
+		v.Pointer = errors.JSONPointer(path)
+		v.Message = message(fieldErr)
+		if o.docsURL != nil {
+			v.DocsURL = o.docsURL(fieldErr)
+		}
+		violations = append(violations, v)
+	}
+	return violations
+}
+
+// FieldPath returns the path of the failing field relative to the validated struct,
+// e.g. "address.city" or "items[0].sku", built from the field error's namespace
+func FieldPath(fieldErr validator.FieldError) string {
+	_, path, ok := strings.Cut(fieldErr.Namespace(), ".")
+	if !ok {
+		return fieldErr.Field()
+	}
+	return path
+}
+
+// ViolationType returns the violation type for a validator tag.
+// Tags without a dedicated constant are upper-cased, e.g. "len" becomes "LEN".
+func ViolationType(tag string) errors.ViolationErrorType {
+	if t, ok := tagTypes[tag]; ok {
+		return t
+	}
+	return errors.ViolationErrorType(strings.ToUpper(tag))
+}
+
+// JSONTagName reports the JSON name of a struct field and is meant to be passed to
+// validator.Validate.RegisterTagNameFunc so that violations use JSON field names.
+func JSONTagName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return field.Name
+	}
+	return name
+}
+
+// message builds the default message of a field error from the template of its violation type
+// (see errors.RegisterViolationType), or a generic one when the type has none
+func message(fieldErr validator.FieldError) string {
+	field := fieldErr.Field()
+	param := fieldErr.Param()
+
+	if template, ok := errors.LookupViolationType(ViolationType(fieldErr.Tag())); ok && template != "" {
+		return errors.RenderTemplate(template, map[string]any{"field": field, "param": param})
+	}
+	if param != "" {
+		return fmt.Sprintf("%s failed on the '%s=%s' rule", field, fieldErr.Tag(), param)
+	}
+	return fmt.Sprintf("%s failed on the '%s' rule", field, fieldErr.Tag())
+}
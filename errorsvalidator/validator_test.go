@@ -0,0 +1,186 @@
+package errorsvalidator
+
+import (
+	"fmt"
+	"testing"
+
+	errors "github.com/andryhardiyanto/go-errors"
+	"github.com/go-playground/validator/v10"
+)
+
+type user struct {
+	Email string `json:"email" validate:"required,email"`
+	Age   int    `json:"age" validate:"min=18"`
+	Role  string `json:"role,omitempty" validate:"oneof=admin member"`
+	ID    string `validate:"uuid"`
+}
+
+func newValidate() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(JSONTagName)
+	return v
+}
+
+func TestFromValidator(t *testing.T) {
+	err := FromValidator(newValidate().Struct(user{Age: 10, Role: "root", ID: "x"}))
+	if err == nil {
+		t.Fatal("FromValidator should return an error")
+	}
+
+	if err.Code != 422 {
+		t.Errorf("Expected code 422, got %d", err.Code)
+	}
+
+	expected := []errors.ValidationError{
+		{Type: errors.ViolationErrorTypeRequired, Field: "email"},
+		{Type: errors.ViolationErrorTypeMin, Field: "age"},
+		{Type: errors.ViolationErrorTypeOneOf, Field: "role"},
+		{Type: errors.ViolationErrorTypeUUID, Field: "ID"},
+	}
+	if len(err.Violations) != len(expected) {
+		t.Fatalf("Expected %d violations, got %d", len(expected), len(err.Violations))
+	}
+	for i, v := range expected {
+		got := err.Violations[i]
+		if got.Type != v.Type || got.Field != v.Field {
+			t.Errorf("Violation %d: expected %s/%s, got %s/%s", i, v.Type, v.Field, got.Type, got.Field)
+		}
+		if got.Message == "" {
+			t.Errorf("Violation %d should have a message", i)
+		}
+	}
+}
+
+type (
+	address struct {
+		City string `json:"city" validate:"required"`
+	}
+	item struct {
+		SKU string `json:"sku" validate:"required"`
+	}
+	order struct {
+		Address address `json:"address"`
+		Items   []item  `json:"items" validate:"dive"`
+	}
+)
+
+func TestFromValidatorNestedFields(t *testing.T) {
+	err := FromValidator(newValidate().Struct(order{Items: []item{{SKU: "a"}, {}}}))
+	if err == nil || len(err.Violations) != 2 {
+		t.Fatalf("Expected 2 violations, got %v", err)
+	}
+
+	if err.Violations[0].Field != "address.city" || err.Violations[1].Field != "items[1].sku" {
+		t.Errorf("Fields should be paths from the struct root, got %q and %q", err.Violations[0].Field, err.Violations[1].Field)
+	}
+	if err.Violations[0].Pointer != "/address/city" || err.Violations[1].Pointer != "/items/1/sku" {
+		t.Errorf("Pointers should match the paths, got %q and %q", err.Violations[0].Pointer, err.Violations[1].Pointer)
+	}
+	if err.Violations[0].Message != "city is required" {
+		t.Errorf("Messages should name the leaf field, got %q", err.Violations[0].Message)
+	}
+	if fieldErr := newValidate().Var("", "required").(validator.ValidationErrors)[0]; FieldPath(fieldErr) != "" {
+		t.Errorf("Variables have no path, got %q", FieldPath(fieldErr))
+	}
+}
+
+func TestFromValidatorNonValidationError(t *testing.T) {
+	if FromValidator(nil) != nil {
+		t.Error("FromValidator(nil) should return nil")
+	}
+
+	original := fmt.Errorf("boom")
+	err := FromValidator(original)
+	if err.Code != 500 || err.Unwrap() != original {
+		t.Errorf("Expected wrapped 500 error, got %d", err.Code)
+	}
+}
+
+func TestViolationTypeUnknownTag(t *testing.T) {
+	if got := ViolationType("alphanum"); got != "ALPHANUM" {
+		t.Errorf("Expected ALPHANUM, got %s", got)
+	}
+}
+
+func TestFromValidatorDocsURL(t *testing.T) {
+	docs := WithDocsURL(func(fieldErr validator.FieldError) string {
+		return "https://docs.example.com/users#" + fieldErr.Field()
+	})
+
+	err := FromValidator(newValidate().Struct(user{Email: "a@b.c", Age: 20, Role: "admin", ID: "x"}), docs)
+	if len(err.Violations) != 1 {
+		t.Fatalf("Expected 1 violation, got %d", len(err.Violations))
+	}
+	if err.Violations[0].DocsURL != "https://docs.example.com/users#ID" {
+		t.Errorf("Unexpected docs URL %q", err.Violations[0].DocsURL)
+	}
+}
+
+func TestFromValidatorExpandedTags(t *testing.T) {
+	type product struct {
+		Code  string   `json:"code" validate:"len=8"`
+		Price int      `json:"price" validate:"gt=0"`
+		Site  string   `json:"site" validate:"url"`
+		Phone string   `json:"phone" validate:"e164"`
+		Tags  []string `json:"tags" validate:"unique"`
+		Color string   `json:"color" validate:"hexcolor"`
+	}
+
+	err := FromValidator(newValidate().Struct(product{Code: "x", Site: "nope", Phone: "123", Tags: []string{"a", "a"}, Color: "red"}))
+	expected := []struct {
+		violationType errors.ViolationErrorType
+		message       string
+	}{
+		{errors.ViolationErrorTypeLen, "code must have a length of 8"},
+		{errors.ViolationErrorTypeGT, "price must be greater than 0"},
+		{errors.ViolationErrorTypeURL, "site must be a valid URL"},
+		{errors.ViolationErrorTypePhone, "phone must be a valid phone number"},
+		{errors.ViolationErrorTypeUnique, "tags must contain unique values"},
+		{"HEXCOLOR", "color failed on the 'hexcolor' rule"},
+	}
+	if err == nil || len(err.Violations) != len(expected) {
+		t.Fatalf("Expected %d violations, got %v", len(expected), err)
+	}
+	for i, want := range expected {
+		if got := err.Violations[i]; got.Type != want.violationType || got.Message != want.message {
+			t.Errorf("Violation %d: expected %s %q, got %s %q", i, want.violationType, want.message, got.Type, got.Message)
+		}
+	}
+
+	errors.RegisterViolationType("HEXCOLOR", "{field} must be a hex color")
+	err = FromValidator(newValidate().Struct(product{Code: "12345678", Price: 1, Site: "https://x.io", Phone: "+14155552671", Color: "red"}))
+	if err == nil || len(err.Violations) != 1 || err.Violations[0].Message != "color must be a hex color" {
+		t.Errorf("Registered types should provide the message, got %v", err)
+	}
+}
+
+func TestFromValidatorDateTime(t *testing.T) {
+	type event struct {
+		StartsAt string `json:"starts_at" validate:"datetime=2006-01-02T15:04"`
+	}
+
+	err := FromValidator(newValidate().Struct(event{StartsAt: "tomorrow"}))
+	if err == nil || len(err.Violations) != 1 {
+		t.Fatalf("Expected one violation, got %v", err)
+	}
+	got := err.Violations[0]
+	if got.Type != errors.ViolationErrorTypeDateTime || got.Message != "starts_at must be a valid date and time in format 2006-01-02T15:04" {
+		t.Errorf("Expected a DATETIME violation, got %s %q", got.Type, got.Message)
+	}
+}
+
+func TestFromValidatorParams(t *testing.T) {
+	err := FromValidator(newValidate().Struct(user{Email: "a@b.co", Age: 10, Role: "root", ID: "2f1c8f0e-8a9b-4c3d-9e8f-7a6b5c4d3e2f"}))
+	if err == nil || len(err.Violations) != 2 {
+		t.Fatalf("Expected 2 violations, got %v", err)
+	}
+	if got := err.Violations[0].Params; len(got) != 1 || got["min"] != int64(18) {
+		t.Errorf("Expected the min parameter, got %v", got)
+	}
+	if got := err.Violations[1].Params["oneof"]; fmt.Sprint(got) != "[admin member]" {
+		t.Errorf("Expected the allowed values, got %v", got)
+	}
+	if required := FromValidator(newValidate().Struct(user{})); required.Violations[0].Params != nil {
+		t.Errorf("Tags without a parameter should have no params, got %v", required.Violations[0].Params)
+	}
+}
@@ -0,0 +1,10 @@
+module github.com/andryhardiyanto/go-errors/errorsyaml
+
+go 1.26.2
+
+require (
+	github.com/andryhardiyanto/go-errors v0.0.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+replace github.com/andryhardiyanto/go-errors => ../
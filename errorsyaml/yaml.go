@@ -0,0 +1,36 @@
+// Package errorsyaml loads error catalog files written in YAML.
+// It lives in its own module so the core package does not depend on a YAML parser.
+package errorsyaml
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	errors "github.com/andryhardiyanto/go-errors"
+	"gopkg.in/yaml.v3"
+)
+
+// Decode is the errors.CatalogDecoder for YAML catalog files. Unknown keys are rejected.
+func Decode(reader io.Reader, v any) error {
+	decoder := yaml.NewDecoder(reader)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(v); err != nil && err != io.EOF {
+		return err
+	}
+	return nil
+}
+
+// LoadRegistry reads an error catalog file and returns a registry holding its definitions.
+// The format is chosen from the extension: .json, .yaml or .yml.
+func LoadRegistry(path string) (*errors.Registry, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return errors.LoadRegistry(path)
+	case ".yaml", ".yml":
+		return errors.LoadRegistryWith(path, Decode)
+	default:
+		return nil, fmt.Errorf("errorsyaml: unsupported catalog file extension %q", filepath.Ext(path))
+	}
+}
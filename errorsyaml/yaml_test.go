@@ -0,0 +1,56 @@
+package errorsyaml
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	errors "github.com/andryhardiyanto/go-errors"
+)
+
+const testCatalog = `
+errors:
+  - type: USER_NOT_FOUND
+    code: 404
+    message: "User {id} not found"
+    grpc_code: 5
+    messages:
+      id: "Pengguna {id} tidak ditemukan"
+  - type: PAYMENT_GATEWAY_UNAVAILABLE
+    code: 503
+    message: "Payment gateway unavailable"
+    retryable: true
+`
+
+func TestLoadRegistry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errors.yaml")
+	if err := os.WriteFile(path, []byte(testCatalog), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := LoadRegistry(path)
+	if err != nil {
+		t.Fatalf("LoadRegistry returned error: %v", err)
+	}
+
+	def, ok := r.Lookup("PAYMENT_GATEWAY_UNAVAILABLE")
+	if !ok || !def.Retryable || def.HTTPStatus != 503 {
+		t.Errorf("Unexpected definition %+v", def)
+	}
+	if def, _ := r.Lookup("USER_NOT_FOUND"); def.GRPCCode != 5 || def.Messages["id"] == "" {
+		t.Errorf("Unexpected definition %+v", def)
+	}
+	if _, err := LoadRegistry("errors.toml"); err == nil {
+		t.Error("Unsupported extensions should be rejected")
+	}
+}
+
+func TestDecode(t *testing.T) {
+	if err := errors.NewRegistry().Load(strings.NewReader("errors:\n  - type: X\n    cod: 1\n"), Decode); err == nil {
+		t.Error("Unknown keys should be rejected")
+	}
+	if err := errors.NewRegistry().Load(strings.NewReader(""), Decode); err != nil {
+		t.Errorf("An empty document should load, got %v", err)
+	}
+}
@@ -0,0 +1,120 @@
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+	"time"
+)
+
+// Field keys set by RetriesExhausted
+const (
+	FieldAttemptErrors = "attempt_errors"
+	FieldElapsedMs     = "elapsed_ms"
+)
+
+// AttemptGroup counts the attempts of a retried operation that failed with the same fingerprint
+type AttemptGroup struct {
+	Fingerprint string `json:"fingerprint"`
+	Type        string `json:"type"`
+	Code        int64  `json:"code"`
+	Message     string `json:"message"`
+	Count       int    `json:"count"`
+}
+
+// RetriesExhausted returns a RETRIES_EXHAUSTED error for op after every attempt failed, so
+// post-mortems see the whole retry history rather than only the last failure. The attempts are
+// grouped by fingerprint under FieldAttemptErrors with their counts, FieldAttempt records how
+// many were made and FieldElapsedMs the total time spent including backoff. The error keeps the
+// code of the last attempt and is not retryable, even when the attempts were: IsRetryable does
+// not look at the attempts, while errors.Is and errors.As still match them, and AttemptErrors
+// returns the first error of each group. Nil attempts are skipped; without any error
+// RetriesExhausted returns nil.
+func RetriesExhausted(op string, attempts []error, elapsed time.Duration) *Error {
+	var (
+		distinct []error
+		groups   []AttemptGroup
+		index    = make(map[string]int)
+		last     *Error
+		lastErr  error
+		count    int
+	)
+	for _, err := range attempts {
+		if isNil(err) {
+			continue
+		}
+		count++
+
+		var e *Error
+		if !stderrors.As(err, &e) || e == nil {
+			e = wrapError(err, err, 0)
+		}
+		last, lastErr = e, err
+
+		fingerprint := e.Fingerprint()
+		if i, ok := index[fingerprint]; ok {
+			groups[i].Count++
+			continue
+		}
+		s := e.snapshot()
+		index[fingerprint] = len(groups)
+		groups = append(groups, AttemptGroup{Fingerprint: fingerprint, Type: s.Type, Code: s.Code, Message: s.Message, Count: 1})
+		distinct = append(distinct, err)
+	}
+	if count == 0 {
+		return nil
+	}
+
+	return created(&Error{
+		Type:        "RETRIES_EXHAUSTED",
+		Code:        last.snapshot().Code,
+		Op:          op,
+		Message:     "Retries exhausted",
+		StackTraces: captureStackTrace(1),
+		Err:         &exhaustedAttempts{attempts: distinct, last: lastErr, count: count},
+		Fields: map[string]any{
+			FieldAttempt:       count,
+			FieldAttemptErrors: groups,
+			FieldElapsedMs:     float64(elapsed) / float64(time.Millisecond),
+		},
+	})
+}
+
+// AttemptErrors returns the first error of each group of attempts recorded by RetriesExhausted
+// in err's chain, or nil
+func AttemptErrors(err error) []error {
+	var exhausted *exhaustedAttempts
+	if !stderrors.As(err, &exhausted) {
+		return nil
+	}
+	return append([]error(nil), exhausted.attempts...)
+}
+
+// exhaustedAttempts holds the distinct attempt errors. It has no Unwrap method so that chain
+// walks such as IsRetryable stop at it, while Is and As still match the attempts.
+type exhaustedAttempts struct {
+	attempts []error
+	last     error
+	count    int
+}
+
+func (a *exhaustedAttempts) Error() string {
+	return fmt.Sprintf("%d attempts failed, last: %v", a.count, a.last)
+}
+
+func (a *exhaustedAttempts) Is(target error) bool {
+	for _, err := range a.attempts {
+		if stderrors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *exhaustedAttempts) As(target any) bool {
+	for _, err := range a.attempts {
+		if stderrors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package errors
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestRetriesExhausted(t *testing.T) {
+	// Attempts failing at the same call site share a fingerprint
+	var unavailable []error
+	for range 2 {
+		unavailable = append(unavailable, ErrorServiceUnavailable())
+	}
+	attempts := []error{
+		unavailable[0],
+		nil,
+		unavailable[1],
+		fmt.Errorf("read: %w", io.ErrUnexpectedEOF),
+		Wrap(context.DeadlineExceeded),
+	}
+
+	e := RetriesExhausted("payments.Charge", attempts, 1500*time.Millisecond)
+	if e.Type != "RETRIES_EXHAUSTED" || e.Code != 504 || e.Op != "payments.Charge" || e.Retryable {
+		t.Errorf("Unexpected error %+v", e)
+	}
+	if e.Fields[FieldAttempt] != 4 || e.Fields[FieldElapsedMs] != 1500.0 {
+		t.Errorf("Unexpected fields %v", e.Fields)
+	}
+
+	groups := e.Fields[FieldAttemptErrors].([]AttemptGroup)
+	if len(groups) != 3 || groups[0].Type != "SERVICE_UNAVAILABLE" || groups[0].Count != 2 || groups[1].Code != 500 || groups[2].Type != "GATEWAY_TIMEOUT" {
+		t.Errorf("Attempts should be grouped by fingerprint, got %+v", groups)
+	}
+	if errs := AttemptErrors(fmt.Errorf("checkout: %w", e)); len(errs) != 3 || errs[0] != unavailable[0] {
+		t.Errorf("Expected the first error of each group, got %v", errs)
+	}
+
+	if IsRetryable(e) {
+		t.Error("Exhausted retries should be permanent")
+	}
+	if !stderrors.Is(e, io.ErrUnexpectedEOF) || !stderrors.Is(e, context.DeadlineExceeded) || !IsType(e, "RETRIES_EXHAUSTED") {
+		t.Error("Attempts should stay reachable with errors.Is")
+	}
+	if e.Error() != "4 attempts failed, last: context deadline exceeded" {
+		t.Errorf("Unexpected message %q", e.Error())
+	}
+}
+
+func TestRetriesExhaustedWithoutErrors(t *testing.T) {
+	if RetriesExhausted("op", nil, time.Second) != nil || RetriesExhausted("op", []error{nil}, 0) != nil {
+		t.Error("Without errors there is nothing to report")
+	}
+	if AttemptErrors(ErrorNotFound()) != nil {
+		t.Error("Other errors have no attempts")
+	}
+}
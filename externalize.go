@@ -0,0 +1,105 @@
+package errors
+
+import (
+	stderrors "errors"
+	"net/http"
+	"strings"
+)
+
+// BetaHeader is the request header through which clients opt in to beta error types.
+// Its value is "*" (or "true") for every beta type, or a comma-separated list of types.
+const BetaHeader = "X-Error-Beta"
+
+type (
+	// ExternalizeOption customizes Registry.Externalize
+	ExternalizeOption func(*externalizeConfig)
+
+	externalizeConfig struct {
+		allBeta    bool
+		beta       map[string]bool
+		obfuscator *Obfuscator
+	}
+)
+
+// WithBeta opts in to the given beta types
+func WithBeta(types ...string) ExternalizeOption {
+	return func(c *externalizeConfig) {
+		for _, t := range types {
+			c.beta[t] = true
+		}
+	}
+}
+
+// WithAllBeta opts in to every beta type
+func WithAllBeta() ExternalizeOption {
+	return func(c *externalizeConfig) {
+		c.allBeta = true
+	}
+}
+
+// WithBetaHeader opts in to the beta types listed in the request's BetaHeader
+func WithBetaHeader(header http.Header) ExternalizeOption {
+	return func(c *externalizeConfig) {
+		for _, value := range strings.Split(header.Get(BetaHeader), ",") {
+			switch value = strings.TrimSpace(value); value {
+			case "":
+			case "*", "true":
+				c.allBeta = true
+			default:
+				c.beta[value] = true
+			}
+		}
+	}
+}
+
+// WithObfuscator replaces the externalized type with its public code from o, see Obfuscator
+func WithObfuscator(o *Obfuscator) ExternalizeOption {
+	return func(c *externalizeConfig) {
+		c.obfuscator = o
+	}
+}
+
+// Externalize prepares err for clients: it returns the Public copy of the first *Error in the chain
+// (or of DefaultError for other errors) and replaces beta types the caller has not opted in to
+// with their fallback type, codes, transport statuses, retryability and message. Violations are kept. With WithObfuscator the resulting
+// type is obfuscated last.
+func (r *Registry) Externalize(err error, opts ...ExternalizeOption) *Error {
+	if isNil(err) {
+		return nil
+	}
+
+	cfg := externalizeConfig{beta: make(map[string]bool)}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	var e *Error
+	if !stderrors.As(err, &e) {
+		e = DefaultError()
+	}
+	public := e.Public()
+
+	// Follow fallbacks until a stable or opted-in type is reached; the bound guards against cycles
+	for range 8 {
+		def, ok := r.Lookup(public.Type)
+		if !ok || !def.Beta || cfg.allBeta || cfg.beta[def.Type] {
+			break
+		}
+
+		fallback, ok := r.Lookup(def.Fallback)
+		if !ok {
+			fallback = Definition{Type: "INTERNAL_SERVER_ERROR", Code: 500, Message: "An internal server error occurred"}
+		}
+		public.Type, public.Code, public.Message = fallback.Type, fallback.Code, fallback.Message
+		public.BusinessCode, public.Status, public.GRPCCode = fallback.BusinessCode, fallback.HTTPStatus, fallback.GRPCCode
+		public.Retryable = fallback.Retryable
+		if !public.Retryable {
+			public.RetryAfter = 0
+		}
+	}
+
+	if cfg.obfuscator != nil {
+		return cfg.obfuscator.Obfuscate(public)
+	}
+	return public
+}
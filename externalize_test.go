@@ -0,0 +1,82 @@
+package errors
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func newBetaRegistry() *Registry {
+	r := NewRegistry()
+	r.MustRegister(
+		Definition{Type: "PAYMENT_FAILED", Code: 402, Message: "Payment failed"},
+		Definition{Type: "PAYMENT_REQUIRES_ACTION", Code: 402, Message: "Payment requires action", Beta: true, Fallback: "PAYMENT_FAILED"},
+	)
+	return r
+}
+
+func TestExternalizeBetaFallback(t *testing.T) {
+	r := newBetaRegistry()
+	err := r.New("PAYMENT_REQUIRES_ACTION").WithInternalMessage("3DS challenge")
+
+	public := r.Externalize(err)
+	if public.Type != "PAYMENT_FAILED" || public.Message != "Payment failed" {
+		t.Errorf("Beta type should map to its fallback, got %s", public.Type)
+	}
+	if public.InternalMessage != "" {
+		t.Error("Externalize should return a public copy")
+	}
+	if err.Type != "PAYMENT_REQUIRES_ACTION" {
+		t.Error("Externalize should not modify the original error")
+	}
+
+	if got := r.Externalize(err, WithBeta("PAYMENT_REQUIRES_ACTION")).Type; got != "PAYMENT_REQUIRES_ACTION" {
+		t.Errorf("Opted-in callers should see the beta type, got %s", got)
+	}
+
+	header := http.Header{}
+	header.Set(BetaHeader, "OTHER, PAYMENT_REQUIRES_ACTION")
+	if got := r.Externalize(err, WithBetaHeader(header)).Type; got != "PAYMENT_REQUIRES_ACTION" {
+		t.Errorf("Header opt-in should expose the beta type, got %s", got)
+	}
+	header.Set(BetaHeader, "*")
+	if got := r.Externalize(err, WithBetaHeader(header)).Type; got != "PAYMENT_REQUIRES_ACTION" {
+		t.Errorf("Wildcard opt-in should expose the beta type, got %s", got)
+	}
+}
+
+func TestExternalizeFallbackReplacesPublicFields(t *testing.T) {
+	r := NewRegistry()
+	r.MustRegister(
+		Definition{Type: "BAD_REQUEST", Code: 400, Message: "Bad request"},
+		Definition{Type: "QUOTA_SOFT_LIMIT", Code: 429, Message: "Soft quota reached", BusinessCode: "Q-1",
+			HTTPStatus: http.StatusTooManyRequests, GRPCCode: 8, Retryable: true, Beta: true, Fallback: "BAD_REQUEST"},
+	)
+	err := r.New("QUOTA_SOFT_LIMIT").WithRetryAfter(time.Minute).AddViolation(ValidationError{Field: "plan", Message: "upgrade"})
+
+	public := r.Externalize(err)
+	if public.Type != "BAD_REQUEST" || public.Code != 400 || public.Message != "Bad request" {
+		t.Errorf("Expected the fallback classification, got %s %d %q", public.Type, public.Code, public.Message)
+	}
+	if public.BusinessCode != "" || public.Status != http.StatusBadRequest || public.GRPCCode != 0 || public.Retryable || public.RetryAfter != 0 {
+		t.Errorf("The beta business code, statuses and retry hints should not leak, got %q %d %d %v %v",
+			public.BusinessCode, public.Status, public.GRPCCode, public.Retryable, public.RetryAfter)
+	}
+	if HTTPStatus(public) != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", HTTPStatus(public))
+	}
+	if len(public.Violations) != 1 {
+		t.Error("Violations should be kept")
+	}
+
+	var typedNil *Error
+	if r.Externalize(typedNil) != nil {
+		t.Error("A typed nil should externalize to nil")
+	}
+}
+
+func TestRegisterBetaRequiresFallback(t *testing.T) {
+	if err := NewRegistry().Register(Definition{Type: "NEW_THING", Code: 400, Beta: true}); err == nil {
+		t.Error("Beta types without fallback should be rejected")
+	}
+}
@@ -0,0 +1,119 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Format implements fmt.Formatter. %s and %v print Error(), %q a quoted Error(), and %+v a verbose
+// report with the type, code, message, operation, previous messages, violations, fields, the goroutine
+// (see SetCaptureGoroutine), the wrapped cause (itself formatted with %+v) and the stack trace.
+func (e *Error) Format(f fmt.State, verb rune) {
+	if e == nil {
+		_, _ = io.WriteString(f, "<nil>")
+		return
+	}
+
+	switch verb {
+	case 'v':
+		if f.Flag('+') {
+			s := e.snapshot()
+			s.formatVerbose(f)
+			return
+		}
+		_, _ = io.WriteString(f, e.Error())
+	case 's':
+		_, _ = io.WriteString(f, e.Error())
+	case 'q':
+		_, _ = fmt.Fprintf(f, "%q", e.Error())
+	default:
+		_, _ = fmt.Fprintf(f, "%%!%c(*errors.Error=%s)", verb, e.Error())
+	}
+}
+
+// formatVerbose writes the %+v report
+func (e *Error) formatVerbose(w io.Writer) {
+	_, _ = fmt.Fprintf(w, "%s (%d): %s", e.Type, e.Code, e.Message)
+	if e.Op != "" {
+		_, _ = fmt.Fprintf(w, "\nop: %s", e.Op)
+	}
+	if e.InternalMessage != "" {
+		_, _ = fmt.Fprintf(w, "\ninternal: %s", e.InternalMessage)
+	}
+
+	if len(e.MessageHistory) > 0 {
+		_, _ = io.WriteString(w, "\nprevious messages:")
+		for i := len(e.MessageHistory) - 1; i >= 0; i-- {
+			_, _ = fmt.Fprintf(w, "\n\t%s", e.MessageHistory[i])
+		}
+	}
+
+	if len(e.Notes) > 0 {
+		_, _ = io.WriteString(w, "\nnotes:")
+		for _, note := range e.Notes {
+			_, _ = fmt.Fprintf(w, "\n\t%s", note)
+		}
+	}
+
+	if len(e.Violations) > 0 {
+		_, _ = io.WriteString(w, "\nviolations:")
+		for _, v := range e.Violations {
+			_, _ = fmt.Fprintf(w, "\n\t%s [%s]: %s", v.Field, v.Type, v.Message)
+		}
+	}
+
+	if len(e.Fields) > 0 {
+		keys := make([]string, 0, len(e.Fields))
+		for key := range e.Fields {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		_, _ = io.WriteString(w, "\nfields:")
+		for _, key := range keys {
+			_, _ = fmt.Fprintf(w, "\n\t%s=%v", key, e.Fields[key])
+		}
+	}
+
+	if e.Goroutine != nil {
+		keys := make([]string, 0, len(e.Goroutine.Labels))
+		for key := range e.Goroutine.Labels {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		_, _ = fmt.Fprintf(w, "\ngoroutine: %d", e.Goroutine.ID)
+		for _, key := range keys {
+			_, _ = fmt.Fprintf(w, " %s=%s", key, e.Goroutine.Labels[key])
+		}
+	}
+
+	if e.Err != nil {
+		_, _ = fmt.Fprintf(w, "\ncaused by: %+v", e.Err)
+	}
+
+	if len(e.StackTraces) > 0 {
+		_, _ = io.WriteString(w, "\nstack:")
+		// Source lines of the innermost frames, see SetSourceContext
+		var sources []SourceFrame
+		if context := int(sourceContext.Load()); context > 0 {
+			sources = sourceFrames(e.StackTraces, context, sourceContextFrames)
+		}
+		for _, entry := range e.StackTraces {
+			_, _ = fmt.Fprintf(w, "\n\t%s", entry)
+			if len(sources) == 0 || parseFrame(entry) == nil {
+				continue
+			}
+			source := sources[0]
+			sources = sources[1:]
+			for _, line := range source.Lines {
+				marker := " "
+				if line.Number == source.Line {
+					marker = ">"
+				}
+				_, _ = fmt.Fprintf(w, "\n\t  %s %5d | %s", marker, line.Number, line.Text)
+			}
+		}
+	}
+}
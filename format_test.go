@@ -0,0 +1,53 @@
+package errors
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	err := Violations([]ValidationError{
+		{Type: ViolationErrorTypeRequired, Field: "email", Message: "Email is required"},
+	}).WithField("user_id", 42)
+
+	if got := fmt.Sprintf("%v", err); got != "Unprocessable entity" {
+		t.Errorf("Unexpected %%v output %q", got)
+	}
+	if got := fmt.Sprintf("%q", err); got != `"Unprocessable entity"` {
+		t.Errorf("Unexpected %%q output %q", got)
+	}
+
+	verbose := fmt.Sprintf("%+v", err)
+	for _, want := range []string{
+		"UNPROCESSABLE_ENTITY (422): Unprocessable entity",
+		"\temail [REQUIRED]: Email is required",
+		"\tuser_id=42",
+		"stack:\n\t",
+		"TestFormat",
+	} {
+		if !strings.Contains(verbose, want) {
+			t.Errorf("%%+v output should contain %q, got:\n%s", want, verbose)
+		}
+	}
+}
+
+func TestFormatCause(t *testing.T) {
+	inner := ErrorNotFound()
+	err := Wrap(fmt.Errorf("loading user: %w", inner))
+
+	verbose := fmt.Sprintf("%+v", err)
+	if !strings.Contains(verbose, "caused by: loading user: Not found") {
+		t.Errorf("%%+v output should include the cause, got:\n%s", verbose)
+	}
+
+	verbose = fmt.Sprintf("%+v", Wrap(inner))
+	if !strings.Contains(verbose, "caused by: NOT_FOUND (404)") {
+		t.Errorf("A wrapped *Error should be formatted verbosely, got:\n%s", verbose)
+	}
+
+	var nilErr *Error
+	if got := fmt.Sprintf("%v", nilErr); got != "<nil>" {
+		t.Errorf("Unexpected nil output %q", got)
+	}
+}
@@ -0,0 +1,81 @@
+package errors
+
+import (
+	"bytes"
+	"context"
+	"maps"
+	"runtime"
+	"runtime/pprof"
+	"strconv"
+	"sync/atomic"
+)
+
+// Goroutine identifies the goroutine an error was created on
+type Goroutine struct {
+	ID     uint64            `json:"id"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// captureGoroutines enables goroutine capture, see SetCaptureGoroutine
+var captureGoroutines atomic.Bool
+
+// SetCaptureGoroutine sets whether errors created afterwards record the ID of their goroutine
+// and, when created with a context (see WithContext), its pprof labels, e.g. set with pprof.Do.
+// It helps debug concurrent pipelines where the stack alone does not identify the worker, at
+// the cost of reading the goroutine header on every creation. It is off by default.
+func SetCaptureGoroutine(enabled bool) {
+	captureGoroutines.Store(enabled)
+}
+
+// captureGoroutine returns the current goroutine, or nil when capture is off
+func captureGoroutine() *Goroutine {
+	if !captureGoroutines.Load() {
+		return nil
+	}
+
+	// The stack header reads "goroutine 42 [running]:"
+	var buf [64]byte
+	header := bytes.TrimPrefix(buf[:runtime.Stack(buf[:], false)], []byte("goroutine "))
+	end := bytes.IndexByte(header, ' ')
+	if end < 0 {
+		return nil
+	}
+	id, err := strconv.ParseUint(string(header[:end]), 10, 64)
+	if err != nil {
+		return nil
+	}
+	return &Goroutine{ID: id}
+}
+
+// withLabels records the pprof labels carried by ctx on the error's goroutine, capturing it
+// first when the error has none. It does nothing when capture is off or ctx has no labels.
+func (e *Error) withLabels(ctx context.Context) *Error {
+	if !captureGoroutines.Load() {
+		return e
+	}
+
+	labels := make(map[string]string)
+	pprof.ForLabels(ctx, func(key, value string) bool {
+		labels[key] = value
+		return true
+	})
+	if len(labels) == 0 {
+		return e
+	}
+
+	current := captureGoroutine()
+	return e.update(func(e *Error) {
+		g := current
+		if e.Goroutine != nil {
+			g = &Goroutine{ID: e.Goroutine.ID, Labels: maps.Clone(e.Goroutine.Labels)}
+		}
+		if g == nil {
+			return
+		}
+		if g.Labels == nil {
+			g.Labels = make(map[string]string, len(labels))
+		}
+		maps.Copy(g.Labels, labels)
+		e.Goroutine = g
+	})
+}
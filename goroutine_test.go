@@ -0,0 +1,75 @@
+package errors
+
+import (
+	"context"
+	"fmt"
+	"runtime/pprof"
+	"strings"
+	"testing"
+)
+
+func TestCaptureGoroutine(t *testing.T) {
+	if e := ErrorNotFound(); e.Goroutine != nil {
+		t.Fatalf("Goroutines should not be captured by default, got %+v", e.Goroutine)
+	}
+
+	SetCaptureGoroutine(true)
+	t.Cleanup(func() { SetCaptureGoroutine(false) })
+
+	e := ErrorNotFound()
+	if e.Goroutine == nil || e.Goroutine.ID == 0 {
+		t.Fatalf("The goroutine should be captured, got %+v", e.Goroutine)
+	}
+
+	other := make(chan *Error)
+	go func() { other <- ErrorNotFound() }()
+	if g := (<-other).Goroutine; g == nil || g.ID == e.Goroutine.ID {
+		t.Errorf("Errors created on another goroutine should record it, got %+v", g)
+	}
+
+	if wrapped := Wrap(fmt.Errorf("loading: %w", e)); wrapped.Goroutine != e.Goroutine {
+		t.Error("Wrap should keep the goroutine of the inner error")
+	}
+	if c := e.Clone(); c.Goroutine == e.Goroutine || c.Goroutine.ID != e.Goroutine.ID {
+		t.Error("Clone should copy the goroutine")
+	}
+	if e.Public().Goroutine != nil {
+		t.Error("Public should strip the goroutine")
+	}
+}
+
+func TestGoroutineLabels(t *testing.T) {
+	SetCaptureGoroutine(true)
+	t.Cleanup(func() { SetCaptureGoroutine(false) })
+
+	var e *Error
+	pprof.Do(context.Background(), pprof.Labels("worker", "3", "stage", "decode"), func(ctx context.Context) {
+		e = NewWithContext(ctx, 500, "Failed", "INTERNAL")
+	})
+	if e.Goroutine == nil || e.Goroutine.Labels["worker"] != "3" || e.Goroutine.Labels["stage"] != "decode" {
+		t.Fatalf("The pprof labels should be recorded, got %+v", e.Goroutine)
+	}
+
+	verbose := fmt.Sprintf("%+v", e)
+	if !strings.Contains(verbose, fmt.Sprintf("goroutine: %d stage=decode worker=3", e.Goroutine.ID)) {
+		t.Errorf("The verbose report should show the goroutine, got\n%s", verbose)
+	}
+	var logged bool
+	for _, attr := range e.LogValue().Group() {
+		logged = logged || attr.Key == "goroutine"
+	}
+	if !logged {
+		t.Error("Logs should include the goroutine")
+	}
+
+	text, _ := e.MarshalText()
+	var decoded Error
+	if err := decoded.UnmarshalText(text); err != nil || decoded.Goroutine == nil || decoded.Goroutine.Labels["worker"] != "3" {
+		t.Errorf("The text encoding should keep the goroutine, got %+v (%v)", decoded.Goroutine, err)
+	}
+
+	SetCaptureGoroutine(false)
+	if plain := ErrorNotFound().WithContext(pprof.WithLabels(context.Background(), pprof.Labels("worker", "1"))); plain.Goroutine != nil {
+		t.Error("Labels should not be recorded when capture is off")
+	}
+}
@@ -0,0 +1,206 @@
+package errors
+
+import (
+	stderrors "errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Stage is the point in an error's life at which hooks are called
+type Stage uint8
+
+// Stages at which hooks are called
+const (
+	// StageCreated is when a constructor such as New, Wrap or ErrorNotFound returns a new error
+	StageCreated Stage = iota + 1
+	// StageRendered is when an error is written as an HTTP response
+	StageRendered
+)
+
+type (
+	// ErrorHook observes errors, e.g. to count them. Hooks are called synchronously, so they
+	// should be fast, and must not keep the error after returning: Clone it to retain it.
+	ErrorHook interface {
+		OnError(stage Stage, e *Error)
+	}
+
+	// ErrorHookFunc adapts a function to the ErrorHook interface
+	ErrorHookFunc func(stage Stage, e *Error)
+
+	// HookOption customizes the registration of a hook
+	HookOption func(*registeredHook)
+
+	// HookHandle controls a hook registered with RegisterHook
+	HookHandle struct {
+		registered *registeredHook
+	}
+
+	// registeredHook is an ErrorHook with its order and state, and the identity used to remove it
+	registeredHook struct {
+		hook     ErrorHook
+		priority int
+		disabled atomic.Bool
+	}
+)
+
+var (
+	hooksMu     sync.RWMutex
+	hooks       []*registeredHook
+	hooksActive atomic.Bool
+)
+
+// OnError calls f(stage, e)
+func (f ErrorHookFunc) OnError(stage Stage, e *Error) {
+	f(stage, e)
+}
+
+// String returns the name of the stage
+func (s Stage) String() string {
+	switch s {
+	case StageCreated:
+		return "created"
+	case StageRendered:
+		return "rendered"
+	}
+	return "unknown"
+}
+
+// WithHookPriority orders the hook among the others: hooks with a lower priority are called
+// first, and hooks of equal priority in the order they were registered. The default is 0.
+func WithHookPriority(priority int) HookOption {
+	return func(h *registeredHook) {
+		h.priority = priority
+	}
+}
+
+// OnError registers hook to be called at every stage of every error, and returns a function
+// removing it. Without hooks, errors pay a single atomic load.
+func OnError(hook ErrorHook, opts ...HookOption) (remove func()) {
+	registered := addHook(hook, opts)
+	return func() { removeHook(registered) }
+}
+
+// RegisterHook registers fn to be called on every error returned by a constructor (see
+// StageCreated), e.g. to audit errors or to enrich them with fields. fn may modify the error.
+// The returned handle disables, re-enables and removes the hook.
+func RegisterHook(fn func(*Error), opts ...HookOption) *HookHandle {
+	hook := ErrorHookFunc(func(stage Stage, e *Error) {
+		if stage == StageCreated {
+			fn(e)
+		}
+	})
+	return &HookHandle{registered: addHook(hook, opts)}
+}
+
+// Enable resumes calling the hook
+func (h *HookHandle) Enable() {
+	h.registered.disabled.Store(false)
+}
+
+// Disable stops calling the hook until Enable is called, keeping its place in the order
+func (h *HookHandle) Disable() {
+	h.registered.disabled.Store(true)
+}
+
+// Enabled reports whether the hook is called
+func (h *HookHandle) Enabled() bool {
+	return !h.registered.disabled.Load()
+}
+
+// Remove unregisters the hook
+func (h *HookHandle) Remove() {
+	removeHook(h.registered)
+}
+
+// addHook inserts hook after the hooks of lower or equal priority
+func addHook(hook ErrorHook, opts []HookOption) *registeredHook {
+	registered := &registeredHook{hook: hook}
+	for _, opt := range opts {
+		opt(registered)
+	}
+
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	i := len(hooks)
+	for i > 0 && hooks[i-1].priority > registered.priority {
+		i--
+	}
+	hooks = slices.Insert(slices.Clone(hooks), i, registered)
+	hooksActive.Store(true)
+
+	return registered
+}
+
+// removeHook unregisters a hook; removing it twice is a no-op
+func removeHook(registered *registeredHook) {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	for i, h := range hooks {
+		if h == registered {
+			hooks = append(hooks[:i:i], hooks[i+1:]...)
+			break
+		}
+	}
+	hooksActive.Store(len(hooks) > 0)
+}
+
+// NotifyRendered calls the hooks for StageRendered. WriteJSON and DefaultResponder call it;
+// custom writers and framework integrations call it after writing an error response.
+func NotifyRendered(e *Error) {
+	notify(StageRendered, e)
+}
+
+// created calls the hooks for StageCreated and returns e
+func created(e *Error) *Error {
+	if e.Timestamp.IsZero() {
+		e.Timestamp = time.Now()
+	}
+	if e.Goroutine == nil {
+		e.Goroutine = captureGoroutine()
+	}
+	notify(StageCreated, e)
+	return e
+}
+
+// createdFrom calls the hooks for StageCreated unless err's chain already holds an *Error,
+// which called them when it was created and whose Timestamp, RequestID, TraceID and Goroutine
+// e takes where unset, and returns e
+func createdFrom(err error, e *Error) *Error {
+	var inner *Error
+	if !stderrors.As(err, &inner) || inner == nil {
+		return created(e)
+	}
+	s := inner.snapshot()
+	if e.Timestamp.IsZero() {
+		e.Timestamp = s.Timestamp
+	}
+	if e.RequestID == "" {
+		e.RequestID = s.RequestID
+	}
+	if e.TraceID == "" {
+		e.TraceID = s.TraceID
+	}
+	if e.Goroutine == nil {
+		e.Goroutine = s.Goroutine
+	}
+	return e
+}
+
+// notify calls every enabled hook, in order, with stage and e
+func notify(stage Stage, e *Error) {
+	if e == nil || !hooksActive.Load() {
+		return
+	}
+
+	hooksMu.RLock()
+	current := hooks
+	hooksMu.RUnlock()
+
+	for _, h := range current {
+		if !h.disabled.Load() {
+			h.hook.OnError(stage, e)
+		}
+	}
+}
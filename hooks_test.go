@@ -0,0 +1,174 @@
+package errors
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// recordingHook records the stage and type of every error it observes
+type recordingHook struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (h *recordingHook) OnError(stage Stage, e *Error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events = append(h.events, stage.String()+":"+e.Type)
+}
+
+func (h *recordingHook) recorded() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]string(nil), h.events...)
+}
+
+func TestOnErrorCreated(t *testing.T) {
+	hook := &recordingHook{}
+	remove := OnError(hook)
+	defer remove()
+
+	New(400, "bad", "BAD_INPUT")
+	ErrorNotFound()
+	Wrap(fmt.Errorf("boom"))
+	FromHTTPStatus(418)
+	FromHTTPStatus(404)
+
+	want := []string{"created:BAD_INPUT", "created:NOT_FOUND", "created:INTERNAL_SERVER_ERROR", "created:IM_A_TEAPOT", "created:NOT_FOUND"}
+	if got := hook.recorded(); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestOnErrorCreatedSeesFinalError(t *testing.T) {
+	var seen []string
+	handle := RegisterHook(func(e *Error) {
+		seen = append(seen, fmt.Sprintf("%s/%d/%s/%d", e.Type, e.Code, e.Message, len(e.Violations)))
+	})
+	defer handle.Remove()
+
+	NewWith(500, "boom", "INTERNAL_SERVER_ERROR", func(e *Error) { e.Type, e.Code = "QUOTA_EXCEEDED", 429 })
+	FromHTTPStatus(405, OverrideMessage("not here"))
+	NewViolationBuilder().Add("email", ViolationErrorTypeRequired, "Email is required").Err()
+
+	want := []string{"QUOTA_EXCEEDED/429/boom/0", "METHOD_NOT_ALLOWED/405/not here/0", "UNPROCESSABLE_ENTITY/422/Unprocessable entity/1"}
+	if fmt.Sprint(seen) != fmt.Sprint(want) {
+		t.Errorf("Hooks should observe the final error, expected %v, got %v", want, seen)
+	}
+}
+
+func TestOnErrorWrapDoesNotRecount(t *testing.T) {
+	inner := ErrorConflict()
+
+	hook := &recordingHook{}
+	remove := OnError(hook)
+	defer remove()
+
+	Wrap(inner)
+	Wrapf(inner, "saving")
+	WrapOp("store.Save", inner)
+	WrapOp("store.Save", fmt.Errorf("plain"))
+
+	if got := hook.recorded(); len(got) != 1 || got[0] != "created:INTERNAL_SERVER_ERROR" {
+		t.Errorf("Only the plain error should be reported, got %v", got)
+	}
+}
+
+func TestOnErrorRendered(t *testing.T) {
+	e := ErrorBadRequest()
+
+	hook := &recordingHook{}
+	remove := OnError(hook)
+	defer remove()
+
+	WriteJSON(httptest.NewRecorder(), e)
+
+	if got := hook.recorded(); len(got) != 1 || got[0] != "rendered:BAD_REQUEST" {
+		t.Errorf("Expected one rendered event, got %v", got)
+	}
+}
+
+func TestOnErrorRemove(t *testing.T) {
+	first, second := &recordingHook{}, &recordingHook{}
+	removeFirst := OnError(first)
+	removeSecond := OnError(ErrorHookFunc(second.OnError))
+
+	ErrorForbidden()
+	removeFirst()
+	ErrorForbidden()
+	removeSecond()
+	ErrorForbidden()
+
+	if len(first.recorded()) != 1 || len(second.recorded()) != 2 {
+		t.Errorf("Removed hooks should not be called, got %v and %v", first.recorded(), second.recorded())
+	}
+	if hooksActive.Load() {
+		t.Error("Hooks should be inactive once all are removed")
+	}
+}
+
+func TestStageString(t *testing.T) {
+	if StageCreated.String() != "created" || StageRendered.String() != "rendered" || Stage(0).String() != "unknown" {
+		t.Error("Unexpected stage names")
+	}
+}
+
+func TestRegisterHookOrdering(t *testing.T) {
+	var order []string
+	record := func(name string) func(*Error) {
+		return func(e *Error) { order = append(order, name) }
+	}
+
+	late := RegisterHook(record("late"), WithHookPriority(10))
+	defer late.Remove()
+	first := RegisterHook(record("first"))
+	defer first.Remove()
+	early := RegisterHook(record("early"), WithHookPriority(-10))
+	defer early.Remove()
+	second := RegisterHook(record("second"))
+	defer second.Remove()
+
+	ErrorConflict()
+
+	if want := "[early first second late]"; fmt.Sprint(order) != want {
+		t.Errorf("Expected %s, got %v", want, order)
+	}
+}
+
+func TestRegisterHookEnableDisable(t *testing.T) {
+	calls := 0
+	handle := RegisterHook(func(e *Error) { calls++ })
+	defer handle.Remove()
+
+	ErrorConflict()
+	handle.Disable()
+	ErrorConflict()
+	if handle.Enabled() || calls != 1 {
+		t.Errorf("Disabled hooks should not be called, got %d calls", calls)
+	}
+
+	handle.Enable()
+	ErrorConflict()
+	WriteJSON(httptest.NewRecorder(), ErrorBadRequest())
+	if !handle.Enabled() || calls != 3 {
+		t.Errorf("Enabled hooks should be called on creation only, got %d calls", calls)
+	}
+
+	handle.Remove()
+	handle.Remove()
+	ErrorConflict()
+	if calls != 3 {
+		t.Errorf("Removed hooks should not be called, got %d calls", calls)
+	}
+}
+
+func TestRegisterHookEnrichment(t *testing.T) {
+	handle := RegisterHook(func(e *Error) { e.WithField("region", "eu-west-1") })
+	defer handle.Remove()
+
+	if e := New(400, "bad", "BAD_INPUT"); e.Fields["region"] != "eu-west-1" {
+		t.Errorf("Hooks should be able to enrich errors, got %v", e.Fields)
+	}
+}
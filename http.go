@@ -0,0 +1,157 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+// EnvelopeHeader is the response header duplicating the compact envelope (see EncodeCompact) of an
+// error response, so clients can recover the error when an intermediary replaces the body
+const EnvelopeHeader = "X-Error-Envelope"
+
+// maxEnvelopeHeader bounds the EnvelopeHeader value; larger envelopes are sent without violations
+const maxEnvelopeHeader = 4 << 10
+
+// Responder writes err as the response to r
+type Responder func(w http.ResponseWriter, r *http.Request, err *Error)
+
+// WriteJSON writes the envelope of err (see NewEnvelope) with every supported capability as JSON
+// with Code as the status, or 500 when Code is not an HTTP status, and the headers from ResponseHeaders.
+func WriteJSON(w http.ResponseWriter, err *Error) {
+	writeEnvelope(w, err, SupportedCapabilities)
+}
+
+// writeEnvelope writes the envelope of err reduced to caps
+func writeEnvelope(w http.ResponseWriter, err *Error, caps Capability) {
+	for key, values := range ResponseHeaders(err) {
+		w.Header()[key] = values
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(HTTPStatus(err))
+	_ = json.NewEncoder(w).Encode(NewEnvelope(err, caps))
+	NotifyRendered(err)
+}
+
+// EnvelopeHeaderValue returns the EnvelopeHeader value for err: the compact envelope of its
+// Public() copy, without violations when it would exceed 4KB
+func EnvelopeHeaderValue(err *Error) string {
+	public := err.Public()
+	token := EncodeCompact(public)
+	if len(token) > maxEnvelopeHeader {
+		public.Violations = nil
+		token = EncodeCompact(public)
+	}
+	return token
+}
+
+// ResponseHeaders returns the headers every error response carries: EnvelopeHeader, Cache-Control
+// from the cache policy (see CacheControl) and, when RetryAfter is set, Retry-After in whole seconds
+func ResponseHeaders(err *Error) http.Header {
+	header := http.Header{}
+	header.Set(EnvelopeHeader, EnvelopeHeaderValue(err))
+	header.Set("Cache-Control", CacheControl(err))
+	if retryAfter := err.snapshot().RetryAfter; retryAfter > 0 {
+		header.Set("Retry-After", strconv.FormatInt(int64(math.Ceil(retryAfter.Seconds())), 10))
+	}
+	return header
+}
+
+// DefaultResponder writes errors like WriteJSON, reduced to the capabilities r advertises
+func DefaultResponder(w http.ResponseWriter, r *http.Request, err *Error) {
+	writeEnvelope(w, err, RequestCapabilities(r))
+}
+
+// Recoverer is a net/http (and chi) middleware that converts panics into ErrorPanic()
+// carrying the panic value and the panicking goroutine's stack, written by DefaultResponder
+func Recoverer(next http.Handler) http.Handler {
+	return RecovererWith(DefaultResponder)(next)
+}
+
+// RecovererWith returns a Recoverer that delegates rendering to responder
+func RecovererWith(responder Responder) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					responder(w, r, FromPanic(recovered))
+				}
+			}()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// FromPanic converts a recovered panic value into ErrorPanic() wrapping the value (or the value
+// formatted as an error), with the stack of the function that called FromPanic. It must be called
+// from the deferred function that recovered, so the stack includes the panicking frames.
+// http.ErrAbortHandler, which net/http uses to abort a response silently, is panicked again.
+func FromPanic(recovered any) *Error {
+	if recovered == http.ErrAbortHandler {
+		panic(recovered)
+	}
+
+	e := &Error{
+		Type:        "PANIC",
+		Code:        500,
+		Message:     "Panic",
+		StackTraces: captureStackTrace(1),
+	}
+	if err, ok := recovered.(error); ok {
+		e.Err = err
+	} else {
+		e.Err = fmt.Errorf("%v", recovered)
+	}
+	return created(e)
+}
+
+// statusMappings maps domain codes to HTTP statuses, see RegisterStatusMapping
+var (
+	statusMappingsMu sync.RWMutex
+	statusMappings   = map[int64]int{}
+)
+
+// RegisterStatusMapping maps a domain error code that is not an HTTP status to the status
+// errors with that code respond with, e.g. RegisterStatusMapping(10423, 422), decoupling
+// business codes from transport statuses. A status of 0 removes the mapping.
+func RegisterStatusMapping(code int64, status int) {
+	statusMappingsMu.Lock()
+	defer statusMappingsMu.Unlock()
+
+	if status == 0 {
+		delete(statusMappings, code)
+		return
+	}
+	statusMappings[code] = status
+}
+
+// HTTPStatus returns the HTTP status for e: its Status when set, otherwise the status registered
+// for its Code with RegisterStatusMapping, otherwise its Code when that is a valid HTTP status,
+// otherwise 500
+func HTTPStatus(e *Error) int {
+	s := e.snapshot()
+	if s.Status != 0 {
+		return s.Status
+	}
+
+	statusMappingsMu.RLock()
+	status, ok := statusMappings[s.Code]
+	statusMappingsMu.RUnlock()
+	if ok {
+		return status
+	}
+
+	if s.Code >= 100 && s.Code <= 599 {
+		return int(s.Code)
+	}
+	return http.StatusInternalServerError
+}
+
+// HTTPStatus returns the HTTP status the error responds with, see the package function HTTPStatus
+func (e *Error) HTTPStatus() int {
+	return HTTPStatus(e)
+}
@@ -0,0 +1,107 @@
+package errors
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRecoverer(t *testing.T) {
+	handler := Recoverer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("Expected 500, got %d", rec.Code)
+	}
+	body := map[string]any{}
+	_ = json.Unmarshal(rec.Body.Bytes(), &body)
+	if body["type"] != "PANIC" || strings.Contains(rec.Body.String(), "boom") {
+		t.Errorf("Unexpected body %s", rec.Body.String())
+	}
+}
+
+func TestRecovererWithResponder(t *testing.T) {
+	var captured *Error
+	handler := RecovererWith(func(w http.ResponseWriter, r *http.Request, err *Error) {
+		captured = err
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(ErrorNotFound())
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Code != http.StatusServiceUnavailable || captured == nil {
+		t.Fatalf("Responder should be used, got %d", rec.Code)
+	}
+	if captured.Type != "PANIC" || captured.Unwrap().Error() != "Not found" {
+		t.Errorf("Unexpected error %v", captured)
+	}
+
+	found := false
+	for _, frame := range captured.StackTraces {
+		if strings.Contains(frame, "TestRecovererWithResponder") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Stack should include the panicking handler: %v", captured.StackTraces)
+	}
+}
+
+func TestRecovererAbortHandler(t *testing.T) {
+	handler := Recoverer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	defer func() {
+		if recover() != http.ErrAbortHandler {
+			t.Error("http.ErrAbortHandler should be re-panicked")
+		}
+	}()
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
+
+func TestStatusMapping(t *testing.T) {
+	RegisterStatusMapping(10423, http.StatusUnprocessableEntity)
+	RegisterStatusMapping(404, http.StatusGone)
+	defer RegisterStatusMapping(10423, 0)
+	defer RegisterStatusMapping(404, 0)
+
+	tests := []struct {
+		err  *Error
+		want int
+	}{
+		{New(10423, "Insufficient funds", "INSUFFICIENT_FUNDS"), http.StatusUnprocessableEntity},
+		{New(404, "Not found", "NOT_FOUND"), http.StatusGone},
+		{New(409, "Conflict", "CONFLICT"), http.StatusConflict},
+		{New(10500, "Unknown", "UNKNOWN"), http.StatusInternalServerError},
+		{&Error{Code: 10423, Status: http.StatusPaymentRequired}, http.StatusPaymentRequired},
+	}
+	for _, tt := range tests {
+		if got := tt.err.HTTPStatus(); got != tt.want {
+			t.Errorf("HTTPStatus() of code %d = %d, want %d", tt.err.Code, got, tt.want)
+		}
+		if got := HTTPStatus(tt.err); got != tt.want {
+			t.Errorf("HTTPStatus(err) of code %d = %d, want %d", tt.err.Code, got, tt.want)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	WriteJSON(rec, New(10423, "Insufficient funds", "INSUFFICIENT_FUNDS"))
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("WriteJSON should use the mapped status, got %d", rec.Code)
+	}
+
+	RegisterStatusMapping(10423, 0)
+	if got := New(10423, "Insufficient funds", "INSUFFICIENT_FUNDS").HTTPStatus(); got != http.StatusInternalServerError {
+		t.Errorf("Removed mapping should fall back to 500, got %d", got)
+	}
+}
@@ -0,0 +1,141 @@
+package errors
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// redacted replaces sensitive header and query values
+const redacted = "[REDACTED]"
+
+// sensitiveHeaders are always redacted from HTTP snapshots
+var sensitiveHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+	"X-Api-Key":           true,
+	"X-Auth-Token":        true,
+}
+
+// sensitiveQueryParams are always redacted from snapshot URLs
+var sensitiveQueryParams = map[string]bool{
+	"access_token": true,
+	"api_key":      true,
+	"password":     true,
+	"secret":       true,
+	"token":        true,
+}
+
+// HTTPSnapshot is a sanitized, size-capped copy of an HTTP request or response
+type HTTPSnapshot struct {
+	Method        string            `json:"method,omitempty"`
+	URL           string            `json:"url,omitempty"`
+	Status        int               `json:"status,omitempty"`
+	Headers       map[string]string `json:"headers,omitempty"`
+	Body          string            `json:"body,omitempty"`
+	BodyTruncated bool              `json:"body_truncated,omitempty"`
+}
+
+// WithHTTPRequest stores a sanitized snapshot of r under the "http_request" field and returns the error for chaining.
+// At most maxBody bytes of the body are captured; the body remains fully readable afterwards.
+// Credentials, cookies and sensitive query parameters are always redacted.
+func (e *Error) WithHTTPRequest(r *http.Request, maxBody int) *Error {
+	if r == nil {
+		return e
+	}
+
+	snapshot := HTTPSnapshot{
+		Method:  r.Method,
+		Headers: sanitizeHeaders(r.Header),
+	}
+	if r.URL != nil {
+		snapshot.URL = sanitizeURL(r.URL)
+	}
+	snapshot.Body, snapshot.BodyTruncated = peekBody(&r.Body, maxBody)
+
+	return e.WithField("http_request", snapshot)
+}
+
+// WithHTTPResponse stores a sanitized snapshot of resp under the "http_response" field and returns the error for chaining.
+// It is meant for upstream failures; at most maxBody bytes of the body are captured and the body remains readable.
+func (e *Error) WithHTTPResponse(resp *http.Response, maxBody int) *Error {
+	if resp == nil {
+		return e
+	}
+
+	snapshot := HTTPSnapshot{
+		Status:  resp.StatusCode,
+		Headers: sanitizeHeaders(resp.Header),
+	}
+	if resp.Request != nil {
+		snapshot.Method = resp.Request.Method
+		if resp.Request.URL != nil {
+			snapshot.URL = sanitizeURL(resp.Request.URL)
+		}
+	}
+	snapshot.Body, snapshot.BodyTruncated = peekBody(&resp.Body, maxBody)
+
+	return e.WithField("http_response", snapshot)
+}
+
+// sanitizeHeaders flattens headers and redacts sensitive values
+func sanitizeHeaders(header http.Header) map[string]string {
+	if len(header) == 0 {
+		return nil
+	}
+
+	result := make(map[string]string, len(header))
+	for name, values := range header {
+		if sensitiveHeaders[http.CanonicalHeaderKey(name)] {
+			result[name] = redacted
+			continue
+		}
+		result[name] = strings.Join(values, ", ")
+	}
+	return result
+}
+
+// sanitizeURL removes user credentials and redacts sensitive query parameters
+func sanitizeURL(u *url.URL) string {
+	clean := *u
+	clean.User = nil
+
+	query := clean.Query()
+	changed := false
+	for key := range query {
+		if sensitiveQueryParams[strings.ToLower(key)] {
+			query.Set(key, redacted)
+			changed = true
+		}
+	}
+	if changed {
+		clean.RawQuery = query.Encode()
+	}
+
+	return clean.String()
+}
+
+// peekBody reads up to max bytes of body and replaces it with a reader yielding the full content
+func peekBody(body *io.ReadCloser, max int) (string, bool) {
+	if *body == nil || *body == http.NoBody || max <= 0 {
+		return "", false
+	}
+
+	buf := make([]byte, max+1)
+	n, _ := io.ReadFull(*body, buf)
+	buf = buf[:n]
+
+	*body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(buf), *body), *body}
+
+	if n > max {
+		return string(buf[:max]), true
+	}
+	return string(buf), false
+}
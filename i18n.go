@@ -0,0 +1,143 @@
+package errors
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type (
+	// Localizer renders the message registered under key for locale.
+	// It reports false when no message exists so the original message is kept.
+	Localizer interface {
+		Localize(locale, key string, params map[string]any) (string, bool)
+	}
+
+	// Bundle is a simple Localizer mapping locale to message key to template.
+	// Templates use {name} placeholders filled from the parameters.
+	Bundle map[string]map[string]string
+)
+
+var (
+	localizerMu sync.RWMutex
+	localizer   Localizer = Bundle{}
+)
+
+// SetLocalizer replaces the Localizer used by Translate. Passing nil disables translation.
+func SetLocalizer(l Localizer) {
+	if l == nil {
+		l = Bundle{}
+	}
+
+	localizerMu.Lock()
+	defer localizerMu.Unlock()
+	localizer = l
+}
+
+// LoadBundle parses a JSON document of the form {"<locale>": {"<key>": "<template>"}}
+func LoadBundle(r io.Reader) (Bundle, error) {
+	bundle := Bundle{}
+	if err := json.NewDecoder(r).Decode(&bundle); err != nil {
+		return nil, err
+	}
+	return bundle, nil
+}
+
+// Localize implements Localizer. A regional locale such as "id-ID" falls back to its base language "id".
+func (b Bundle) Localize(locale, key string, params map[string]any) (string, bool) {
+	for _, candidate := range []string{locale, baseLanguage(locale)} {
+		if template, ok := b[candidate][key]; ok {
+			return renderTemplate(template, params), true
+		}
+	}
+	return "", false
+}
+
+// RenderTemplate fills the {name} placeholders of template from params, as Bundle does.
+// It lets custom Localizers share the placeholder syntax.
+func RenderTemplate(template string, params map[string]any) string {
+	return renderTemplate(template, params)
+}
+
+// Translate returns a copy of err with its message and violation messages rendered in locale
+// by the configured Localizer. err itself is never modified. Errors that are not *Error are wrapped first.
+//
+// The error message key is MessageKey, or Type when empty; template parameters are the error's
+// fields plus MessageParams by position ("0", "1", ...). A violation's key is its MessageKey, or
+// "violation.<TYPE>" when empty, with the "field" parameter and its Params.
+func Translate(err error, locale string) *Error {
+	if err == nil {
+		return nil
+	}
+
+	var e *Error
+	if !stderrors.As(err, &e) {
+		e = Wrap(err)
+	}
+
+	localizerMu.RLock()
+	l := localizer
+	localizerMu.RUnlock()
+
+	// The clone is a consistent copy with its own violations and fields, so it is both read
+	// and localized in place while e may be updated concurrently
+	translated := e.Clone()
+	if message, ok := l.Localize(locale, translated.messageKey(), translated.messageParams()); ok {
+		translated.Message = message
+	}
+
+	for i, v := range translated.Violations {
+		if message, ok := l.Localize(locale, v.messageKey(), v.messageParams()); ok {
+			translated.Violations[i].Message = message
+		}
+	}
+
+	return translated
+}
+
+// messageKey returns the key used to localize the error message
+func (e *Error) messageKey() string {
+	if e.MessageKey != "" {
+		return e.MessageKey
+	}
+	return e.Type
+}
+
+// messageParams returns the template parameters used to localize the error message
+func (e *Error) messageParams() map[string]any {
+	params := make(map[string]any, len(e.Fields)+len(e.MessageParams))
+	for key, value := range e.Fields {
+		params[key] = value
+	}
+	for i, value := range e.MessageParams {
+		params[strconv.Itoa(i)] = value
+	}
+	return params
+}
+
+// messageKey returns the key used to localize the violation message
+func (v ValidationError) messageKey() string {
+	if v.MessageKey != "" {
+		return v.MessageKey
+	}
+	return "violation." + string(v.Type)
+}
+
+// messageParams returns the template parameters used to localize the violation message
+func (v ValidationError) messageParams() map[string]any {
+	params := make(map[string]any, len(v.Params)+1)
+	for key, value := range v.Params {
+		params[key] = value
+	}
+	params["field"] = v.Field
+	return params
+}
+
+// baseLanguage returns the language part of a locale, e.g. "id" for "id-ID"
+func baseLanguage(locale string) string {
+	base, _, _ := strings.Cut(strings.ReplaceAll(locale, "_", "-"), "-")
+	return base
+}
@@ -0,0 +1,80 @@
+package errors
+
+import (
+	"strings"
+	"testing"
+)
+
+const testBundleJSON = `{
+	"id": {
+		"NOT_FOUND": "Data tidak ditemukan",
+		"USER_NOT_FOUND": "Pengguna {0} tidak ditemukan",
+		"violation.REQUIRED": "{field} wajib diisi"
+	}
+}`
+
+func TestTranslate(t *testing.T) {
+	bundle, err := LoadBundle(strings.NewReader(testBundleJSON))
+	if err != nil {
+		t.Fatalf("LoadBundle returned error: %v", err)
+	}
+	SetLocalizer(bundle)
+	defer SetLocalizer(nil)
+
+	original := ErrorNotFound()
+	translated := Translate(original, "id-ID")
+	if translated.Message != "Data tidak ditemukan" {
+		t.Errorf("Unexpected message %q", translated.Message)
+	}
+	if original.Message != "Not found" {
+		t.Error("Translate should not modify the original error")
+	}
+
+	userErr := Newf(404, "User %s not found", "NOT_FOUND", "42")
+	userErr.MessageKey = "USER_NOT_FOUND"
+	if got := Translate(userErr, "id").Message; got != "Pengguna 42 tidak ditemukan" {
+		t.Errorf("Unexpected message %q", got)
+	}
+
+	violations := Violations([]ValidationError{
+		{Type: ViolationErrorTypeRequired, Field: "email", Message: "Email is required"},
+		{Type: ViolationErrorTypeEmail, Field: "email", Message: "Invalid email format"},
+	})
+	translated = Translate(violations, "id")
+	if translated.Violations[0].Message != "email wajib diisi" {
+		t.Errorf("Unexpected violation message %q", translated.Violations[0].Message)
+	}
+	if translated.Violations[1].Message != "Invalid email format" || violations.Violations[0].Message != "Email is required" {
+		t.Error("Missing keys should keep the original message and the original should be untouched")
+	}
+
+	if got := Translate(original, "fr").Message; got != "Not found" {
+		t.Errorf("Unknown locale should keep the original message, got %q", got)
+	}
+}
+
+func TestTranslateDoesNotShareState(t *testing.T) {
+	original := Newf(404, "User %s not found", "USER_NOT_FOUND", "42").WithField("a", 1)
+
+	translated := Translate(original, "id")
+	translated.WithField("b", 2)
+	translated.MessageParams[0] = "43"
+
+	if _, ok := original.Fields["b"]; ok || original.MessageParams[0] != "42" {
+		t.Error("Translate should not share fields or params with the original")
+	}
+}
+
+func TestTranslateViolationParams(t *testing.T) {
+	bundle, err := LoadBundle(strings.NewReader(`{"id": {"violation.MIN": "{field} minimal {min}"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetLocalizer(bundle)
+	defer SetLocalizer(nil)
+
+	e := Violations([]ValidationError{NewViolation(ViolationErrorTypeMin, "age", "18")})
+	if got := Translate(e, "id").Violations[0].Message; got != "age minimal 18" {
+		t.Errorf("Violation params should fill the template, got %q", got)
+	}
+}
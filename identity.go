@@ -0,0 +1,175 @@
+package errors
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type (
+	// Fingerprinter computes the grouping key of an error.
+	// Errors with the same fingerprint are considered occurrences of the same problem.
+	Fingerprinter interface {
+		Fingerprint(e *Error) string
+	}
+
+	// FingerprinterFunc adapts a function to the Fingerprinter interface
+	FingerprinterFunc func(e *Error) string
+
+	// IDGenerator creates unique identifiers for error occurrences
+	IDGenerator interface {
+		NewID() string
+	}
+
+	// IDGeneratorFunc adapts a function to the IDGenerator interface
+	IDGeneratorFunc func() string
+
+	defaultFingerprinter struct{}
+	defaultIDGenerator   struct{}
+)
+
+// modulePath is the path of this module. The default fingerprinter skips the frames of its
+// integration packages.
+const modulePath = "github.com/andryhardiyanto/go-errors"
+
+var (
+	identityMu    sync.RWMutex
+	fingerprinter Fingerprinter = defaultFingerprinter{}
+	idGenerator   IDGenerator   = defaultIDGenerator{}
+)
+
+// Fingerprint calls f(e)
+func (f FingerprinterFunc) Fingerprint(e *Error) string {
+	return f(e)
+}
+
+// NewID calls f()
+func (f IDGeneratorFunc) NewID() string {
+	return f()
+}
+
+// SetFingerprinter replaces the fingerprinter used by Error.Fingerprint.
+// Passing nil restores the default.
+func SetFingerprinter(f Fingerprinter) {
+	if f == nil {
+		f = defaultFingerprinter{}
+	}
+
+	identityMu.Lock()
+	defer identityMu.Unlock()
+	fingerprinter = f
+}
+
+// SetIDGenerator replaces the generator used by Error.EnsureID.
+// Passing nil restores the default.
+func SetIDGenerator(g IDGenerator) {
+	if g == nil {
+		g = defaultIDGenerator{}
+	}
+
+	identityMu.Lock()
+	defer identityMu.Unlock()
+	idGenerator = g
+}
+
+// Fingerprint returns the grouping key of the error computed by the configured Fingerprinter
+func (e *Error) Fingerprint() string {
+	if e == nil {
+		return ""
+	}
+
+	identityMu.RLock()
+	f := fingerprinter
+	identityMu.RUnlock()
+
+	s := e.snapshot()
+	return f.Fingerprint(&s)
+}
+
+// EnsureID assigns an ID from the configured IDGenerator if the error has none, and returns it
+func (e *Error) EnsureID() string {
+	if e == nil {
+		return ""
+	}
+
+	if id := e.snapshot().ID; id != "" {
+		return id
+	}
+
+	identityMu.RLock()
+	g := idGenerator
+	identityMu.RUnlock()
+
+	id := g.NewID()
+	e.update(func(e *Error) {
+		// Another goroutine may have assigned an ID in the meantime
+		if e.ID == "" {
+			e.ID = id
+		}
+		id = e.ID
+	})
+	return id
+}
+
+// Fingerprint hashes the type, code, message template and the top relevant stack frame. Without
+// a template the message is normalized by normalizeMessage, so "User 42 not found" and "User 7
+// not found" group together. Frames of this module's integration packages are skipped, so
+// errors converted by, e.g., errorsgrpc group by their caller rather than all together.
+func (defaultFingerprinter) Fingerprint(e *Error) string {
+	message := e.MessageTemplate
+	if message == "" {
+		message = normalizeMessage(e.Message)
+	}
+
+	h := sha256.New()
+	h.Write([]byte(e.Type))
+	h.Write([]byte{0})
+	h.Write([]byte(strconv.FormatInt(e.Code, 10)))
+	h.Write([]byte{0})
+	h.Write([]byte(message))
+	h.Write([]byte{0})
+	h.Write([]byte(fingerprintFrame(e.StackTraces)))
+
+	return hex.EncodeToString(h.Sum(nil)[:8])
+}
+
+// fingerprintFrame returns the first stack trace entry outside this module's integration packages
+func fingerprintFrame(stack []string) string {
+	for _, entry := range stack {
+		if frame := parseFrame(entry); frame == nil || !strings.HasPrefix(frame.Function, modulePath+"/") {
+			return entry
+		}
+	}
+	return ""
+}
+
+// normalizeMessage replaces the variable parts of a message, quoted strings and words
+// containing digits such as IDs, numbers and timestamps, with placeholders
+func normalizeMessage(message string) string {
+	var b strings.Builder
+	for i, word := range strings.Fields(message) {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		core := strings.TrimRight(strings.TrimLeft(word, "([{"), ".,:;!?)]}")
+		prefix, suffix, _ := strings.Cut(word, core)
+		switch {
+		case len(core) >= 2 && strings.ContainsRune(`"'`+"`", rune(core[0])) && core[len(core)-1] == core[0]:
+			core = "?"
+		case strings.ContainsAny(core, "0123456789"):
+			core = "#"
+		}
+		b.WriteString(prefix + core + suffix)
+	}
+	return b.String()
+}
+
+// NewID returns 16 random bytes encoded as hex
+func (defaultIDGenerator) NewID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
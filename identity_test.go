@@ -0,0 +1,87 @@
+package errors
+
+import (
+	"testing"
+)
+
+func newFingerprintError() *Error {
+	return New(404, "Not found", "NOT_FOUND")
+}
+
+func TestDefaultFingerprint(t *testing.T) {
+	a, b := newFingerprintError(), newFingerprintError()
+	if a.Fingerprint() != b.Fingerprint() {
+		t.Error("Errors created at the same site should share a fingerprint")
+	}
+
+	if a.Fingerprint() == New(404, "Not found", "NOT_FOUND").Fingerprint() {
+		t.Error("Errors created at different sites should have different fingerprints")
+	}
+}
+
+func TestFingerprintIgnoresVariableMessages(t *testing.T) {
+	newError := func(message string) *Error {
+		return New(404, "Not found", "NOT_FOUND").WithMessage(message)
+	}
+
+	if newError("User 42 not found").Fingerprint() != newError("User 7 not found").Fingerprint() {
+		t.Error("Numbers in messages should not change the fingerprint")
+	}
+	if newError("User 42 not found").Fingerprint() == newError("Order 42 not found").Fingerprint() {
+		t.Error("Different messages should have different fingerprints")
+	}
+}
+
+func TestNormalizeMessage(t *testing.T) {
+	tests := map[string]string{
+		"User 42 not found": "User # not found",
+		"order 7f3c9a2e-1b4d-4c8e-9f00-2a6b8c1d3e5f: expired.": "order #: expired.",
+		`key "alice" is taken (attempt 3)`:                     "key ? is taken (attempt #)",
+		"Not found":                                            "Not found",
+	}
+	for message, want := range tests {
+		if got := normalizeMessage(message); got != want {
+			t.Errorf("normalizeMessage(%q) = %q, want %q", message, got, want)
+		}
+	}
+}
+
+func TestFingerprintFrameSkipsIntegrations(t *testing.T) {
+	stack := []string{
+		"/src/errorsgrpc/status.go:120 github.com/andryhardiyanto/go-errors/errorsgrpc.FromStatus",
+		"/src/app/billing.go:42 example.com/app.(*Billing).Charge",
+	}
+	if got := fingerprintFrame(stack); got != stack[1] {
+		t.Errorf("Integration frames should be skipped, got %q", got)
+	}
+	if got := fingerprintFrame(stack[1:]); got != stack[1] {
+		t.Errorf("Expected the first frame, got %q", got)
+	}
+}
+
+func TestSetFingerprinter(t *testing.T) {
+	SetFingerprinter(FingerprinterFunc(func(e *Error) string { return e.Type }))
+	defer SetFingerprinter(nil)
+
+	if got := ErrorNotFound().Fingerprint(); got != "NOT_FOUND" {
+		t.Errorf("Expected custom fingerprint, got %q", got)
+	}
+}
+
+func TestEnsureID(t *testing.T) {
+	err := ErrorNotFound()
+	id := err.EnsureID()
+	if len(id) != 32 || err.ID != id {
+		t.Errorf("Expected a 32 character hex ID, got %q", id)
+	}
+	if err.EnsureID() != id {
+		t.Error("EnsureID should keep an existing ID")
+	}
+
+	SetIDGenerator(IDGeneratorFunc(func() string { return "ksuid-1" }))
+	defer SetIDGenerator(nil)
+
+	if got := ErrorNotFound().EnsureID(); got != "ksuid-1" {
+		t.Errorf("Expected custom ID, got %q", got)
+	}
+}
@@ -0,0 +1,166 @@
+package errors
+
+import (
+	"math/rand/v2"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// FaultsEnv is the environment variable read by LoadFaultsFromEnv
+const FaultsEnv = "ERRORS_FAULTS"
+
+// FieldFaultPoint is the field key recording the injection point on injected errors
+const FieldFaultPoint = "fault_point"
+
+// Fault configures what Inject returns for a named point
+type Fault struct {
+	// Err is the injected error, cloned for each call when it is an *Error;
+	// nil injects ErrorInternalServerError()
+	Err error
+	// Probability is the chance in (0, 1] that a call fails; 0 means every call
+	Probability float64
+	// Count limits how many calls fail; 0 means no limit
+	Count int
+}
+
+// faultState is a configured fault and how many times it fired
+type faultState struct {
+	fault Fault
+	fired int
+}
+
+var (
+	faultMu      sync.Mutex
+	faults       = map[string]*faultState{}
+	faultRand    = rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	faultsActive atomic.Bool
+)
+
+// Inject returns the error configured for point with SetFault or LoadFaults, or nil. Place it on
+// error paths that tests and game days should exercise:
+//
+//	if err := errors.Inject("payments.charge"); err != nil {
+//		return err
+//	}
+//
+// Without configured faults it costs a single atomic load.
+func Inject(point string) error {
+	if !faultsActive.Load() {
+		return nil
+	}
+
+	faultMu.Lock()
+	defer faultMu.Unlock()
+
+	state := faults[point]
+	if state == nil || (state.fault.Count > 0 && state.fired >= state.fault.Count) {
+		return nil
+	}
+	if p := state.fault.Probability; p > 0 && p < 1 && faultRand.Float64() >= p {
+		return nil
+	}
+	state.fired++
+
+	// Each caller gets its own copy, since callers commonly enrich the error they receive
+	if e, ok := state.fault.Err.(*Error); ok {
+		return e.Clone()
+	}
+	if state.fault.Err != nil {
+		return state.fault.Err
+	}
+	return ErrorInternalServerError().WithField(FieldFaultPoint, point)
+}
+
+// SetFault configures the fault injected at point, replacing any previous one
+func SetFault(point string, fault Fault) {
+	faultMu.Lock()
+	defer faultMu.Unlock()
+	faults[point] = &faultState{fault: fault}
+	faultsActive.Store(true)
+}
+
+// ClearFaults removes every configured fault
+func ClearFaults() {
+	faultMu.Lock()
+	defer faultMu.Unlock()
+	faults = map[string]*faultState{}
+	faultsActive.Store(false)
+}
+
+// SeedFaults makes the probability rules deterministic, e.g. to reproduce a test run
+func SeedFaults(seed uint64) {
+	faultMu.Lock()
+	defer faultMu.Unlock()
+	faultRand = rand.New(rand.NewPCG(seed, seed))
+}
+
+// LoadFaults configures faults from a comma-separated list of point=status[:probability[:count]]
+// rules, e.g. "payments.charge=503:0.5,db.query=500::3". The injected error is FromHTTPStatus(status)
+// recording the point under FieldFaultPoint. Invalid rules are reported together and none is applied.
+func LoadFaults(rules string) error {
+	parsed := map[string]Fault{}
+	var problems []error
+
+	for _, rule := range strings.Split(rules, ",") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+
+		point, spec, _ := strings.Cut(rule, "=")
+		fault, reason := parseFault(point, spec)
+		if reason != "" {
+			problems = append(problems, ConfigEnvError("faults."+point, FaultsEnv, reason))
+			continue
+		}
+		parsed[point] = fault
+	}
+	if err := InvalidConfiguration(problems...); err != nil {
+		return err
+	}
+
+	for point, fault := range parsed {
+		SetFault(point, fault)
+	}
+	return nil
+}
+
+// LoadFaultsFromEnv calls LoadFaults with the value of FaultsEnv, doing nothing when it is unset
+func LoadFaultsFromEnv() error {
+	return LoadFaults(os.Getenv(FaultsEnv))
+}
+
+// parseFault parses the status[:probability[:count]] part of a rule, returning the reason it is invalid
+func parseFault(point, spec string) (Fault, string) {
+	if point == "" {
+		return Fault{}, "is missing the point name"
+	}
+
+	parts := strings.Split(spec, ":")
+	if len(parts) > 3 {
+		return Fault{}, "must be status[:probability[:count]]"
+	}
+
+	status, err := strconv.Atoi(parts[0])
+	if err != nil || status < 400 || status > 599 {
+		return Fault{}, "status must be between 400 and 599"
+	}
+
+	var fault Fault
+	if len(parts) > 1 && parts[1] != "" {
+		if fault.Probability, err = strconv.ParseFloat(parts[1], 64); err != nil || fault.Probability <= 0 || fault.Probability > 1 {
+			return Fault{}, "probability must be in (0, 1]"
+		}
+	}
+	if len(parts) > 2 && parts[2] != "" {
+		if fault.Count, err = strconv.Atoi(parts[2]); err != nil || fault.Count < 1 {
+			return Fault{}, "count must be a positive integer"
+		}
+	}
+
+	fault.Err = FromHTTPStatus(status).WithField(FieldFaultPoint, point)
+	return fault, ""
+}
@@ -0,0 +1,100 @@
+package errors
+
+import (
+	stderrors "errors"
+	"strings"
+	"testing"
+)
+
+func TestInjectCount(t *testing.T) {
+	t.Cleanup(ClearFaults)
+
+	if Inject("payments.charge") != nil {
+		t.Fatal("Nothing should be injected without configuration")
+	}
+
+	SetFault("payments.charge", Fault{Count: 2})
+	for i := 0; i < 2; i++ {
+		err := Inject("payments.charge")
+		if !IsInternalServerError(err) || err.(*Error).Fields[FieldFaultPoint] != "payments.charge" {
+			t.Fatalf("Expected an injected error, got %v", err)
+		}
+	}
+	if Inject("payments.charge") != nil || Inject("other") != nil {
+		t.Error("Faults should stop after Count calls and only apply to their point")
+	}
+}
+
+func TestInjectProbability(t *testing.T) {
+	t.Cleanup(ClearFaults)
+
+	custom := stderrors.New("connection reset")
+	SetFault("db.query", Fault{Err: custom, Probability: 0.5})
+
+	run := func() (failures int) {
+		SeedFaults(42)
+		for i := 0; i < 1000; i++ {
+			if err := Inject("db.query"); err != nil {
+				if err != custom {
+					t.Fatalf("Expected the configured error, got %v", err)
+				}
+				failures++
+			}
+		}
+		return failures
+	}
+
+	first := run()
+	if first < 400 || first > 600 {
+		t.Errorf("Expected about half the calls to fail, got %d", first)
+	}
+	if second := run(); second != first {
+		t.Errorf("Seeded runs should be reproducible, got %d and %d", first, second)
+	}
+}
+
+func TestLoadFaults(t *testing.T) {
+	t.Cleanup(ClearFaults)
+
+	if err := LoadFaults("payments.charge=503, db.query=500::1"); err != nil {
+		t.Fatal(err)
+	}
+
+	first, second := Inject("payments.charge"), Inject("payments.charge")
+	if !IsType(first, "SERVICE_UNAVAILABLE") || first == second {
+		t.Errorf("Expected a fresh 503 per call, got %v and %v", first, second)
+	}
+	if Inject("db.query") == nil || Inject("db.query") != nil {
+		t.Error("The count rule should apply")
+	}
+}
+
+func TestLoadFaultsFromEnv(t *testing.T) {
+	t.Cleanup(ClearFaults)
+
+	t.Setenv(FaultsEnv, "search=504:1")
+	if err := LoadFaultsFromEnv(); err != nil {
+		t.Fatal(err)
+	}
+	if !IsType(Inject("search"), "GATEWAY_TIMEOUT") {
+		t.Error("Faults should be loaded from the environment")
+	}
+}
+
+func TestLoadFaultsInvalid(t *testing.T) {
+	t.Cleanup(ClearFaults)
+
+	err := LoadFaults("ok=500,=500,bad=200,worse=500:2,worst=500:0.5:-1")
+	if err == nil {
+		t.Fatal("Expected invalid rules to be reported")
+	}
+	report := RenderConfigReport(err)
+	for _, want := range []string{"faults.bad", "faults.worse", "faults.worst", "missing the point name", FaultsEnv} {
+		if !strings.Contains(report, want) {
+			t.Errorf("Expected %q in the report:\n%s", want, report)
+		}
+	}
+	if Inject("ok") != nil {
+		t.Error("No rule should be applied when any is invalid")
+	}
+}
@@ -0,0 +1,117 @@
+package errors
+
+import (
+	"context"
+	stderrors "errors"
+	"log/slog"
+	"net/http"
+)
+
+type (
+	// ErrorFactory creates errors. Inject it instead of calling New and Wrap directly to substitute
+	// errors in tests; DefaultFactory uses the package functions.
+	ErrorFactory interface {
+		New(code int64, message, errorType string) *Error
+		Wrap(err error) *Error
+	}
+
+	// Classifier converts any error into an *Error, e.g. at a transport boundary
+	Classifier interface {
+		Classify(err error) *Error
+	}
+
+	// ClassifierFunc adapts a function to the Classifier interface
+	ClassifierFunc func(err error) *Error
+
+	// Writer writes an error as the response to r. Responder implements it.
+	Writer interface {
+		WriteError(w http.ResponseWriter, r *http.Request, err *Error)
+	}
+
+	// Reporter sends errors to logs, trackers or alerting
+	Reporter interface {
+		Report(ctx context.Context, err *Error)
+	}
+
+	// ReporterFunc adapts a function to the Reporter interface
+	ReporterFunc func(ctx context.Context, err *Error)
+
+	defaultFactory    struct{}
+	defaultClassifier struct{}
+	logReporter       struct{ logger *slog.Logger }
+)
+
+// Default implementations
+var (
+	// DefaultFactory creates errors with New and Wrap
+	DefaultFactory ErrorFactory = defaultFactory{}
+	// DefaultClassifier returns the first *Error in the error's chain, or wraps the error with Wrap
+	DefaultClassifier Classifier = defaultClassifier{}
+	// DefaultWriter writes errors with DefaultResponder
+	DefaultWriter Writer = Responder(DefaultResponder)
+	// NopReporter discards errors
+	NopReporter Reporter = ReporterFunc(func(context.Context, *Error) {})
+)
+
+// Classify calls f(err)
+func (f ClassifierFunc) Classify(err error) *Error {
+	return f(err)
+}
+
+// WriteError calls f(w, r, err)
+func (f Responder) WriteError(w http.ResponseWriter, r *http.Request, err *Error) {
+	f(w, r, err)
+}
+
+// Report calls f(ctx, err)
+func (f ReporterFunc) Report(ctx context.Context, err *Error) {
+	f(ctx, err)
+}
+
+// LogReporter returns a Reporter writing errors to logger with Log, at the level of their severity.
+// A nil logger uses slog.Default().
+func LogReporter(logger *slog.Logger) Reporter {
+	return logReporter{logger: logger}
+}
+
+// New is like the package function New, with the stack trace starting at the caller
+func (defaultFactory) New(code int64, message, errorType string) *Error {
+	return created(&Error{
+		Type:        errorType,
+		Code:        code,
+		Message:     message,
+		StackTraces: captureStackTrace(1),
+	})
+}
+
+// Wrap is like the package function Wrap
+func (defaultFactory) Wrap(err error) *Error {
+	if isNil(err) {
+		return nil
+	}
+	return createdFrom(err, wrapError(err, err, 1))
+}
+
+// Classify returns the first *Error in err's chain or wraps err; nil stays nil
+func (defaultClassifier) Classify(err error) *Error {
+	if err == nil {
+		return nil
+	}
+
+	var e *Error
+	if stderrors.As(err, &e) && e != nil {
+		return e
+	}
+	return Wrap(err)
+}
+
+// Report logs err with Log
+func (r logReporter) Report(ctx context.Context, err *Error) {
+	logger := r.logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if err != nil {
+		Log(ctx, logger, err)
+	}
+}
@@ -0,0 +1,96 @@
+package errors
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// service depends only on the interfaces, as an application would
+type service struct {
+	factory  ErrorFactory
+	reporter Reporter
+}
+
+func (s service) find(ctx context.Context, id string) error {
+	if id == "" {
+		err := s.factory.New(404, "User not found", "USER_NOT_FOUND")
+		s.reporter.Report(ctx, err)
+		return err
+	}
+	return nil
+}
+
+type stubFactory struct{}
+
+func (f stubFactory) New(code int64, message, errorType string) *Error {
+	return &Error{Type: "STUB", Code: code, Message: message}
+}
+
+func (f stubFactory) Wrap(err error) *Error {
+	return &Error{Type: "STUB", Code: 500, Err: err}
+}
+
+func TestInterfacesCanBeSubstituted(t *testing.T) {
+	var reported []*Error
+	s := service{
+		factory:  stubFactory{},
+		reporter: ReporterFunc(func(ctx context.Context, err *Error) { reported = append(reported, err) }),
+	}
+
+	err := s.find(context.Background(), "")
+	if e, ok := err.(*Error); !ok || e.Type != "STUB" || len(reported) != 1 {
+		t.Errorf("Expected the stub error to be reported, got %v and %v", err, reported)
+	}
+}
+
+func TestDefaultFactory(t *testing.T) {
+	err := DefaultFactory.New(404, "User not found", "USER_NOT_FOUND")
+	if err.Type != "USER_NOT_FOUND" || !strings.Contains(err.StackTraces[0], "TestDefaultFactory") {
+		t.Errorf("Expected a stack trace starting at the caller, got %v", err.StackTraces)
+	}
+
+	wrapped := DefaultFactory.Wrap(fmt.Errorf("boom"))
+	if wrapped.Code != 500 || !strings.Contains(wrapped.StackTraces[0], "TestDefaultFactory") {
+		t.Errorf("Unexpected wrapped error %+v", wrapped)
+	}
+}
+
+func TestDefaultClassifier(t *testing.T) {
+	conflict := ErrorConflict()
+	if got := DefaultClassifier.Classify(fmt.Errorf("saving: %w", conflict)); got != conflict {
+		t.Errorf("Expected the error in the chain, got %v", got)
+	}
+	if got := DefaultClassifier.Classify(fmt.Errorf("boom")); got.Code != 500 {
+		t.Errorf("Expected a wrapped error, got %v", got)
+	}
+	if DefaultClassifier.Classify(nil) != nil {
+		t.Error("nil should stay nil")
+	}
+	if got := ClassifierFunc(func(error) *Error { return ErrorGone() }).Classify(nil); got.Code != 410 {
+		t.Errorf("ClassifierFunc should call the function, got %v", got)
+	}
+}
+
+func TestDefaultWriter(t *testing.T) {
+	rec := httptest.NewRecorder()
+	DefaultWriter.WriteError(rec, httptest.NewRequest(http.MethodGet, "/", nil), ErrorNotFound())
+	if rec.Code != 404 || !strings.Contains(rec.Body.String(), `"type":"NOT_FOUND"`) {
+		t.Errorf("Unexpected response %d %s", rec.Code, rec.Body)
+	}
+}
+
+func TestLogReporter(t *testing.T) {
+	var buf bytes.Buffer
+	LogReporter(slog.New(slog.NewTextHandler(&buf, nil))).Report(context.Background(), ErrorInternalServerError())
+	if !strings.Contains(buf.String(), "level=ERROR") {
+		t.Errorf("Expected an error log line, got %q", buf.String())
+	}
+
+	NopReporter.Report(context.Background(), ErrorInternalServerError())
+}
@@ -0,0 +1,105 @@
+package errors
+
+import "fmt"
+
+// ErrorKind is a coarse category of errors, so policies (retry, alert, suppress) and business
+// logic can branch on what went wrong rather than on numeric codes. See Kind.
+type ErrorKind int
+
+const (
+	// KindNone is the kind of a nil error
+	KindNone ErrorKind = iota
+	KindValidation
+	KindNotFound
+	KindConflict
+	KindPermission
+	KindInternal
+	KindUnavailable
+	KindTimeout
+	KindRateLimit
+	// KindCanceled is a request abandoned by its caller, e.g. context.Canceled
+	KindCanceled
+)
+
+// kindInfo is the name and transport codes of a kind. gRPC codes are
+// google.golang.org/grpc/codes values.
+var kindInfo = map[ErrorKind]struct {
+	name     string
+	status   int
+	grpcCode uint32
+}{
+	KindNone:        {"none", 0, 0},
+	KindValidation:  {"validation", 400, 3},
+	KindNotFound:    {"not_found", 404, 5},
+	KindConflict:    {"conflict", 409, 6},
+	KindPermission:  {"permission", 403, 7},
+	KindInternal:    {"internal", 500, 13},
+	KindUnavailable: {"unavailable", 503, 14},
+	KindTimeout:     {"timeout", 504, 4},
+	KindRateLimit:   {"rate_limit", 429, 8},
+	KindCanceled:    {"canceled", 499, 1},
+}
+
+// Kind returns the kind of err, derived from the HTTP status (see HTTPStatus) of the first *Error
+// in its chain, so registry statuses and RegisterStatusMapping apply. Other errors get the kind
+// of their Wrap classification: internal, or timeout and canceled for context errors.
+// Kind(nil) returns KindNone.
+func Kind(err error) ErrorKind {
+	e := describe(err)
+	if e == nil {
+		return KindNone
+	}
+
+	switch status := HTTPStatus(e); status {
+	case 401, 403:
+		return KindPermission
+	case 404, 410:
+		return KindNotFound
+	case 409, 412, 423, 428:
+		return KindConflict
+	case 408, 504:
+		return KindTimeout
+	case 429:
+		return KindRateLimit
+	case 499:
+		return KindCanceled
+	case 502, 503:
+		return KindUnavailable
+	default:
+		if status >= 400 && status < 500 {
+			return KindValidation
+		}
+		return KindInternal
+	}
+}
+
+// IsKind reports whether err is of kind k, see Kind
+func IsKind(err error, k ErrorKind) bool {
+	return Kind(err) == k
+}
+
+// String returns the snake_case name of the kind, e.g. "not_found"
+func (k ErrorKind) String() string {
+	if info, ok := kindInfo[k]; ok {
+		return info.name
+	}
+	return fmt.Sprintf("kind(%d)", int(k))
+}
+
+// HTTPStatus returns the representative HTTP status of the kind, e.g. 404 for KindNotFound,
+// or 500 for unknown kinds. KindNone has no status and returns 0.
+func (k ErrorKind) HTTPStatus() int {
+	if info, ok := kindInfo[k]; ok {
+		return info.status
+	}
+	return 500
+}
+
+// GRPCCode returns the google.golang.org/grpc/codes value of the kind, e.g. 5 (NotFound) for
+// KindNotFound, or 13 (Internal) for unknown kinds. KindNone returns 0 (OK).
+func (k ErrorKind) GRPCCode() uint32 {
+	if info, ok := kindInfo[k]; ok {
+		return info.grpcCode
+	}
+	return 13
+}
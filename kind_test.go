@@ -0,0 +1,65 @@
+package errors
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestKind(t *testing.T) {
+	RegisterStatusMapping(10423, 422)
+	defer RegisterStatusMapping(10423, 0)
+
+	tests := []struct {
+		name string
+		err  error
+		want ErrorKind
+	}{
+		{"nil", nil, KindNone},
+		{"validation", Violations(nil), KindValidation},
+		{"bad request", ErrorBadRequest(), KindValidation},
+		{"not found", fmt.Errorf("loading: %w", ErrorNotFound()), KindNotFound},
+		{"gone", ErrorGone(), KindNotFound},
+		{"conflict", ErrorConflict(), KindConflict},
+		{"unauthorized", ErrorUnauthorized(), KindPermission},
+		{"forbidden", ErrorForbidden(), KindPermission},
+		{"rate limit", ErrorTooManyRequests(), KindRateLimit},
+		{"unavailable", ErrorServiceUnavailable(), KindUnavailable},
+		{"timeout", ErrorGatewayTimeout(), KindTimeout},
+		{"deadline", context.DeadlineExceeded, KindTimeout},
+		{"canceled", Wrap(context.Canceled), KindCanceled},
+		{"internal", ErrorInternalServerError(), KindInternal},
+		{"plain", fmt.Errorf("boom"), KindInternal},
+		{"mapped domain code", New(10423, "Insufficient funds", "INSUFFICIENT_FUNDS"), KindValidation},
+		{"status override", &Error{Code: 10500, Status: 404}, KindNotFound},
+	}
+	for _, tt := range tests {
+		if got := Kind(tt.err); got != tt.want {
+			t.Errorf("%s: Kind = %s, want %s", tt.name, got, tt.want)
+		}
+		if !IsKind(tt.err, tt.want) {
+			t.Errorf("%s: IsKind(%s) should hold", tt.name, tt.want)
+		}
+	}
+}
+
+func TestErrorKindCodes(t *testing.T) {
+	if KindNotFound.HTTPStatus() != 404 || KindNotFound.GRPCCode() != 5 || KindNotFound.String() != "not_found" {
+		t.Errorf("Unexpected KindNotFound mapping %d/%d/%s", KindNotFound.HTTPStatus(), KindNotFound.GRPCCode(), KindNotFound)
+	}
+	if KindRateLimit.HTTPStatus() != 429 || KindRateLimit.GRPCCode() != 8 {
+		t.Error("KindRateLimit should map to 429 and ResourceExhausted")
+	}
+
+	unknown := ErrorKind(99)
+	if unknown.HTTPStatus() != 500 || unknown.GRPCCode() != 13 || unknown.String() != "kind(99)" {
+		t.Errorf("Unknown kinds should map to internal, got %d/%d/%s", unknown.HTTPStatus(), unknown.GRPCCode(), unknown)
+	}
+
+	// Every kind's status maps back to the kind
+	for k := KindValidation; k <= KindCanceled; k++ {
+		if got := Kind(&Error{Code: int64(k.HTTPStatus())}); got != k {
+			t.Errorf("Kind of status %d = %s, want %s", k.HTTPStatus(), got, k)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package errors
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"time"
+)
+
+// wireVersion is the version of the text and binary encodings
+const wireVersion = 1
+
+// errorWire is the text and binary encoding of an error. Unlike the JSON form it carries the
+// internal details too, so an error survives caches, gob-based RPC and persistence layers.
+type errorWire struct {
+	Version         int               `json:"v"`
+	ID              string            `json:"id,omitempty"`
+	RequestID       string            `json:"request_id,omitempty"`
+	TraceID         string            `json:"trace_id,omitempty"`
+	Type            string            `json:"type"`
+	Code            int64             `json:"code"`
+	BusinessCode    string            `json:"business_code,omitempty"`
+	Status          int               `json:"status,omitempty"`
+	GRPCCode        uint32            `json:"grpc_code,omitempty"`
+	Op              string            `json:"op,omitempty"`
+	Message         string            `json:"message"`
+	InternalMessage string            `json:"internal_message,omitempty"`
+	MessageKey      string            `json:"message_key,omitempty"`
+	MessageTemplate string            `json:"message_template,omitempty"`
+	MessageParams   []any             `json:"message_params,omitempty"`
+	MessageHistory  []string          `json:"message_history,omitempty"`
+	Notes           []string          `json:"notes,omitempty"`
+	Violations      []ValidationError `json:"violations,omitempty"`
+	Fields          map[string]any    `json:"fields,omitempty"`
+	StackTraces     []string          `json:"stack_traces,omitempty"`
+	Retryable       bool              `json:"retryable,omitempty"`
+	RetryAfter      time.Duration     `json:"retry_after,omitempty"`
+	Sampling        *Sampling         `json:"sampling,omitempty"`
+	Severity        Severity          `json:"severity,omitempty"`
+	Timestamp       time.Time         `json:"timestamp,omitzero"`
+	Elapsed         time.Duration     `json:"elapsed,omitempty"`
+	Goroutine       *Goroutine        `json:"goroutine,omitempty"`
+	Cause           *errorWire        `json:"cause,omitempty"`
+	CauseMessage    string            `json:"cause_message,omitempty"`
+}
+
+func init() {
+	// Lets gob transmit *Error as an error interface value, e.g. in net/rpc replies
+	gob.RegisterName("*github.com/andryhardiyanto/go-errors.Error", &Error{})
+}
+
+// MarshalText implements encoding.TextMarshaler. The encoding is a versioned JSON document
+// carrying every field, internal details and the stack trace included, and the cause: a
+// wrapped *Error is encoded recursively, any other cause as its message. Unlike MarshalJSON it
+// is meant for storage and transport between trusted services, never for clients.
+func (e *Error) MarshalText() ([]byte, error) {
+	if e == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(toWire(e))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, decoding the encoding of MarshalText. A
+// cause that was not an *Error is restored as an error with the same message, and field values
+// come back as their JSON types (numbers as float64).
+func (e *Error) UnmarshalText(text []byte) error {
+	var w errorWire
+	if err := json.Unmarshal(text, &w); err != nil {
+		return err
+	}
+	if w.Version > wireVersion {
+		return fmt.Errorf("errors: unsupported encoding version %d", w.Version)
+	}
+	fromWire(e, &w)
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler with the encoding of MarshalText. Through
+// it, gob encodes *Error values in full; the package registers *Error with gob so it can also
+// be sent as an error interface value.
+func (e *Error) MarshalBinary() ([]byte, error) {
+	return e.MarshalText()
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, see UnmarshalText
+func (e *Error) UnmarshalBinary(data []byte) error {
+	return e.UnmarshalText(data)
+}
+
+// toWire returns the encoding of a snapshot of e
+func toWire(e *Error) *errorWire {
+	s := e.snapshot()
+	w := &errorWire{
+		Version:         wireVersion,
+		ID:              s.ID,
+		RequestID:       s.RequestID,
+		TraceID:         s.TraceID,
+		Type:            s.Type,
+		Code:            s.Code,
+		BusinessCode:    s.BusinessCode,
+		Status:          s.Status,
+		GRPCCode:        s.GRPCCode,
+		Op:              s.Op,
+		Message:         s.Message,
+		InternalMessage: s.InternalMessage,
+		MessageKey:      s.MessageKey,
+		MessageTemplate: s.MessageTemplate,
+		MessageParams:   s.MessageParams,
+		MessageHistory:  s.MessageHistory,
+		Notes:           s.Notes,
+		Violations:      s.Violations,
+		Fields:          s.Fields,
+		StackTraces:     s.StackTraces,
+		Retryable:       s.Retryable,
+		RetryAfter:      s.RetryAfter,
+		Sampling:        s.Sampling,
+		Severity:        s.Severity,
+		Timestamp:       s.Timestamp,
+		Elapsed:         s.Elapsed,
+		Goroutine:       s.Goroutine,
+	}
+
+	// A cause wrapping an *Error, e.g. fmt.Errorf("...: %w", e), keeps both its message and the *Error
+	var cause *Error
+	if stderrors.As(s.Err, &cause) && cause != nil {
+		w.Cause = toWire(cause)
+	}
+	if !isNil(s.Err) && s.Err != error(cause) {
+		w.CauseMessage = s.Err.Error()
+	}
+	return w
+}
+
+// fromWire sets the fields of e from the encoding w
+func fromWire(e *Error, w *errorWire) {
+	e.update(func(e *Error) {
+		*e = Error{
+			ID:              w.ID,
+			RequestID:       w.RequestID,
+			TraceID:         w.TraceID,
+			Type:            w.Type,
+			Code:            w.Code,
+			BusinessCode:    w.BusinessCode,
+			Status:          w.Status,
+			GRPCCode:        w.GRPCCode,
+			Op:              w.Op,
+			Message:         w.Message,
+			InternalMessage: w.InternalMessage,
+			MessageKey:      w.MessageKey,
+			MessageTemplate: w.MessageTemplate,
+			MessageParams:   w.MessageParams,
+			MessageHistory:  w.MessageHistory,
+			Notes:           w.Notes,
+			Violations:      w.Violations,
+			Fields:          w.Fields,
+			StackTraces:     w.StackTraces,
+			Retryable:       w.Retryable,
+			RetryAfter:      w.RetryAfter,
+			Sampling:        w.Sampling,
+			Severity:        w.Severity,
+			Timestamp:       w.Timestamp,
+			Elapsed:         w.Elapsed,
+			Goroutine:       w.Goroutine,
+		}
+	})
+
+	switch {
+	case w.Cause != nil:
+		cause := &Error{}
+		fromWire(cause, w.Cause)
+		var err error = cause
+		if w.CauseMessage != "" {
+			err = &decodedCause{message: w.CauseMessage, err: cause}
+		}
+		e.update(func(e *Error) { e.Err = err })
+	case w.CauseMessage != "":
+		e.update(func(e *Error) { e.Err = &decodedCause{message: w.CauseMessage} })
+	}
+}
+
+// decodedCause restores a cause that was not an *Error: its message, and the *Error it wrapped
+// if any
+type decodedCause struct {
+	message string
+	err     error
+}
+
+func (c *decodedCause) Error() string {
+	return c.message
+}
+
+func (c *decodedCause) Unwrap() error {
+	return c.err
+}
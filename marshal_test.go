@@ -0,0 +1,93 @@
+package errors
+
+import (
+	"bytes"
+	"encoding/gob"
+	stderrors "errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMarshalBinaryRoundTrip(t *testing.T) {
+	inner := New(503, "Upstream unavailable", "SERVICE_UNAVAILABLE").WithRetryAfter(2 * time.Second)
+	original := WrapWith(fmt.Errorf("calling billing: %w", inner), 502, "BAD_GATEWAY", "Bad gateway").
+		WithInternalMessage("billing returned 503").
+		WithField(FieldDependency, "billing").
+		WithNote("retry later")
+	original.Status = 503
+	original.Op = "checkout.Pay"
+
+	data, err := original.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := &Error{}
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+
+	if decoded.Type != "BAD_GATEWAY" || decoded.Code != 502 || decoded.Status != 503 || decoded.Op != "checkout.Pay" {
+		t.Errorf("Unexpected decoded error %s/%d/%d/%s", decoded.Type, decoded.Code, decoded.Status, decoded.Op)
+	}
+	if decoded.InternalMessage != "billing returned 503" || decoded.Fields[FieldDependency] != "billing" || !reflect.DeepEqual(decoded.Notes, []string{"retry later"}) {
+		t.Errorf("Internal details should survive, got %+v", decoded)
+	}
+	if !reflect.DeepEqual(decoded.StackTraces, original.StackTraces) {
+		t.Error("The stack trace should survive")
+	}
+
+	if decoded.Unwrap().Error() != "calling billing: Upstream unavailable" {
+		t.Errorf("The cause message should survive, got %q", decoded.Unwrap())
+	}
+	var cause *Error
+	if !stderrors.As(decoded.Unwrap(), &cause) || cause.Code != 503 || cause.RetryAfter != 2*time.Second {
+		t.Errorf("The wrapped *Error should survive, got %v", cause)
+	}
+}
+
+func TestMarshalTextPlainCause(t *testing.T) {
+	original := Wrap(stderrors.New("connection refused"))
+
+	text, err := original.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := &Error{}
+	if err := decoded.UnmarshalText(text); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Error() != original.Error() || decoded.Unwrap().Error() != "connection refused" {
+		t.Errorf("Expected %q, got %q", original.Error(), decoded.Error())
+	}
+
+	if err := decoded.UnmarshalText([]byte(`{"v":99}`)); err == nil {
+		t.Error("Newer versions should be rejected")
+	}
+}
+
+func TestGob(t *testing.T) {
+	type reply struct {
+		Err error
+	}
+
+	original := ErrorNotFound().WithInternalMessage("user 42").WithField("user_id", "42")
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(reply{Err: original}); err != nil {
+		t.Fatal(err)
+	}
+	var decoded reply
+	if err := gob.NewDecoder(&buf).Decode(&decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	var e *Error
+	if !stderrors.As(decoded.Err, &e) || e.Type != "NOT_FOUND" || e.InternalMessage != "user 42" || e.Fields["user_id"] != "42" {
+		t.Errorf("*Error should survive gob as an error value, got %#v", decoded.Err)
+	}
+	if !stderrors.Is(decoded.Err, ErrNotFound) {
+		t.Error("The decoded error should match its sentinel")
+	}
+}
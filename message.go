@@ -0,0 +1,43 @@
+package errors
+
+import "fmt"
+
+// WithMessage replaces the message and returns the error for chaining.
+// The previous message is kept in MessageHistory, so higher layers can add context without
+// losing what lower layers reported. MessageTemplate is kept for grouping.
+func (e *Error) WithMessage(message string) *Error {
+	return e.update(func(e *Error) {
+		e.MessageHistory = append(e.MessageHistory, e.Message)
+		e.Message = message
+	})
+}
+
+// WithMessagef is like WithMessage with a message formatted from format and args
+func (e *Error) WithMessagef(format string, args ...any) *Error {
+	return e.WithMessage(fmt.Sprintf(format, args...))
+}
+
+// WithMessagePrefix prepends context to the message, e.g. "loading profile: User not found",
+// and keeps the previous message in MessageHistory
+func (e *Error) WithMessagePrefix(prefix string) *Error {
+	return e.update(func(e *Error) {
+		e.MessageHistory = append(e.MessageHistory, e.Message)
+		e.Message = prefix + ": " + e.Message
+	})
+}
+
+// WithNote records a free-form note for operators, e.g. "retried 3 times" or "served stale cache",
+// and returns the error for chaining. Notes accumulate in order, appear in logs and %+v output,
+// and are removed by Public, so they never reach clients.
+func (e *Error) WithNote(note string) *Error {
+	return e.update(func(e *Error) { e.Notes = append(e.Notes, note) })
+}
+
+// OriginalMessage returns the message the error was created with, before any WithMessage call
+func (e *Error) OriginalMessage() string {
+	s := e.snapshot()
+	if len(s.MessageHistory) > 0 {
+		return s.MessageHistory[0]
+	}
+	return s.Message
+}
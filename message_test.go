@@ -0,0 +1,59 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestWithMessage(t *testing.T) {
+	err := Newf(404, "User %d not found", "USER_NOT_FOUND", 42)
+	fingerprint := err.Fingerprint()
+
+	err.WithMessagePrefix("loading profile").WithMessagef("profile %s unavailable", "me")
+
+	if err.Message != "profile me unavailable" || err.OriginalMessage() != "User 42 not found" {
+		t.Errorf("Unexpected messages %q / %q", err.Message, err.OriginalMessage())
+	}
+	if len(err.MessageHistory) != 2 || err.MessageHistory[1] != "loading profile: User 42 not found" {
+		t.Errorf("Previous messages should be kept in order, got %v", err.MessageHistory)
+	}
+	if err.Fingerprint() != fingerprint {
+		t.Error("Changing the message should not change the fingerprint of a templated error")
+	}
+	if verbose := fmt.Sprintf("%+v", err); !strings.Contains(verbose, "previous messages:\n\tloading profile: User 42 not found\n\tUser 42 not found") {
+		t.Errorf("Verbose format should list previous messages, got %s", verbose)
+	}
+
+	body, _ := json.Marshal(err)
+	if strings.Contains(string(body), "User 42") || len(err.Clone().MessageHistory) != 2 {
+		t.Errorf("History should be cloned but not serialized, got %s", body)
+	}
+	if ErrorNotFound().OriginalMessage() != "Not found" {
+		t.Error("Without history the original message is the current one")
+	}
+}
+
+func TestWithNote(t *testing.T) {
+	err := ErrorServiceUnavailable().WithNote("retried 3 times").WithNote("served stale cache")
+
+	if len(err.Notes) != 2 || err.Notes[1] != "served stale cache" || err.Message != "Service Unavailable" {
+		t.Errorf("Notes should accumulate without changing the message, got %v", err.Notes)
+	}
+	if verbose := fmt.Sprintf("%+v", err); !strings.Contains(verbose, "notes:\n\tretried 3 times\n\tserved stale cache") {
+		t.Errorf("Verbose format should list notes, got %s", verbose)
+	}
+	if logged, _ := json.Marshal(err); !strings.Contains(string(logged), `"notes":["retried 3 times","served stale cache"]`) {
+		t.Errorf("Notes should be logged, got %s", logged)
+	}
+	if public, _ := json.Marshal(err.Public()); strings.Contains(string(public), "notes") {
+		t.Errorf("Notes should never reach clients, got %s", public)
+	}
+
+	clone := err.Clone()
+	clone.Notes[0] = "changed"
+	if err.Notes[0] != "retried 3 times" {
+		t.Error("Clone should copy the notes")
+	}
+}
@@ -0,0 +1,130 @@
+package errors
+
+import (
+	"context"
+	stderrors "errors"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// DefaultNoveltyWindow is the window of NewNoveltyTracker for a non-positive window
+const DefaultNoveltyWindow = 5 * time.Minute
+
+type (
+	// NoveltyTracker adapts verbosity to how new an error is: the first occurrence of a
+	// fingerprint (see Fingerprint) within the window is logged with its stack and cause chain,
+	// later ones compactly with a counter. During sustained failures this cuts log volume by an
+	// order of magnitude while keeping full detail for new problems. It is safe for concurrent use.
+	NoveltyTracker struct {
+		mu        sync.Mutex
+		window    time.Duration
+		seen      map[string]*noveltyEntry
+		lastSweep time.Time
+		now       func() time.Time
+	}
+
+	// noveltyEntry counts the occurrences of a fingerprint since the start of its window
+	noveltyEntry struct {
+		start time.Time
+		count int
+	}
+)
+
+// NewNoveltyTracker returns a tracker treating a fingerprint as new again once window has passed
+// since its first occurrence. A non-positive window uses DefaultNoveltyWindow.
+func NewNoveltyTracker(window time.Duration) *NoveltyTracker {
+	if window <= 0 {
+		window = DefaultNoveltyWindow
+	}
+	return &NoveltyTracker{window: window, seen: make(map[string]*noveltyEntry), now: time.Now}
+}
+
+// Observe records an occurrence of e and reports whether it is the first of its fingerprint in
+// the current window, and how many occurrences the window has counted including this one
+func (t *NoveltyTracker) Observe(e *Error) (novel bool, count int) {
+	fingerprint := e.Fingerprint()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	t.sweep(now)
+
+	entry, ok := t.seen[fingerprint]
+	if !ok || now.Sub(entry.start) >= t.window {
+		entry = &noveltyEntry{start: now}
+		t.seen[fingerprint] = entry
+	}
+	entry.count++
+	return entry.count == 1, entry.count
+}
+
+// Log writes err to logger like the package function Log. The first occurrence of its
+// fingerprint in the window also carries "fingerprint", "stack" and "chain" (the messages of the
+// cause chain); later ones only carry the type, code, message, fingerprint and "occurrences".
+// Errors that are not *Error are logged at error level and share one fingerprint.
+func (t *NoveltyTracker) Log(ctx context.Context, logger *slog.Logger, err error) {
+	if err == nil {
+		return
+	}
+
+	e, level := classifyForLog(err)
+	novel, count := t.Observe(e)
+	s := e.snapshot()
+
+	if !novel {
+		logger.Log(ctx, level, err.Error(), slog.Group("error",
+			slog.String("type", s.Type),
+			slog.Int64("code", s.Code),
+			slog.String("message", s.Message),
+			slog.String("fingerprint", e.Fingerprint()),
+			slog.Int("occurrences", count),
+		))
+		return
+	}
+
+	chain := make([]string, 0)
+	for _, inner := range Chain(err) {
+		chain = append(chain, inner.Error())
+	}
+	logger.Log(ctx, level, err.Error(),
+		slog.Any("error", e),
+		slog.String("fingerprint", e.Fingerprint()),
+		slog.Any("stack", s.StackTraces),
+		slog.Any("chain", chain),
+	)
+}
+
+// Reporter returns a Reporter passing only the first occurrence of each fingerprint in the
+// window to reporter, e.g. to keep a tracker from receiving the same failure thousands of times
+func (t *NoveltyTracker) Reporter(reporter Reporter) Reporter {
+	return ReporterFunc(func(ctx context.Context, err *Error) {
+		if novel, _ := t.Observe(err); novel {
+			reporter.Report(ctx, err)
+		}
+	})
+}
+
+// sweep drops the entries of expired windows, at most once per window
+func (t *NoveltyTracker) sweep(now time.Time) {
+	if now.Sub(t.lastSweep) < t.window {
+		return
+	}
+	t.lastSweep = now
+	for fingerprint, entry := range t.seen {
+		if now.Sub(entry.start) >= t.window {
+			delete(t.seen, fingerprint)
+		}
+	}
+}
+
+// classifyForLog returns the *Error to log for err and its level, as Log does. Errors that are
+// not *Error are wrapped without a stack trace, so they share one fingerprint.
+func classifyForLog(err error) (*Error, slog.Level) {
+	var e *Error
+	if !stderrors.As(err, &e) || e == nil {
+		return &Error{Type: "INTERNAL_SERVER_ERROR", Code: 500, Message: "An internal server error occurred", Err: err}, slog.LevelError
+	}
+	return e, e.LogSeverity().Level()
+}
@@ -0,0 +1,93 @@
+package errors
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+// withNoveltyClock drives the tracker with a fake clock
+func withNoveltyClock(tracker *NoveltyTracker) *time.Time {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	tracker.now = func() time.Time { return now }
+	return &now
+}
+
+func TestNoveltyTrackerObserve(t *testing.T) {
+	tracker := NewNoveltyTracker(time.Minute)
+	now := withNoveltyClock(tracker)
+
+	var errs []*Error
+	for range 3 {
+		errs = append(errs, ErrorBadGateway())
+	}
+	other := ErrorConflict()
+
+	for i, want := range []struct {
+		e     *Error
+		novel bool
+		count int
+	}{{errs[0], true, 1}, {errs[1], false, 2}, {other, true, 1}, {errs[2], false, 3}} {
+		if novel, count := tracker.Observe(want.e); novel != want.novel || count != want.count {
+			t.Errorf("Occurrence %d: expected %v/%d, got %v/%d", i, want.novel, want.count, novel, count)
+		}
+	}
+
+	*now = now.Add(time.Minute)
+	if novel, count := tracker.Observe(errs[0]); !novel || count != 1 {
+		t.Errorf("A new window should make the fingerprint novel again, got %v/%d", novel, count)
+	}
+	if len(tracker.seen) != 1 {
+		t.Errorf("Expired entries should be swept, got %d", len(tracker.seen))
+	}
+}
+
+func TestNoveltyTrackerLog(t *testing.T) {
+	tracker := NewNoveltyTracker(0)
+	withNoveltyClock(tracker)
+	if tracker.window != DefaultNoveltyWindow {
+		t.Errorf("Expected the default window, got %v", tracker.window)
+	}
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	for range 3 {
+		tracker.Log(context.Background(), logger, fmt.Errorf("charging: %w", ErrorBadGateway()))
+	}
+	tracker.Log(context.Background(), logger, nil)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 log lines, got %d", len(lines))
+	}
+
+	var first, last map[string]any
+	_ = json.Unmarshal([]byte(lines[0]), &first)
+	_ = json.Unmarshal([]byte(lines[2]), &last)
+	if first["stack"] == nil || first["chain"] == nil || first["level"] != "ERROR" {
+		t.Errorf("The first occurrence should be logged in full, got %s", lines[0])
+	}
+	compact, _ := last["error"].(map[string]any)
+	if last["stack"] != nil || compact["occurrences"] != 3.0 || compact["type"] != "BAD_GATEWAY" {
+		t.Errorf("Later occurrences should be compact with a counter, got %s", lines[2])
+	}
+}
+
+func TestNoveltyTrackerReporter(t *testing.T) {
+	tracker := NewNoveltyTracker(time.Minute)
+	withNoveltyClock(tracker)
+
+	reported := 0
+	reporter := tracker.Reporter(ReporterFunc(func(context.Context, *Error) { reported++ }))
+	for range 5 {
+		reporter.Report(context.Background(), ErrorBadGateway())
+	}
+	if reported != 1 {
+		t.Errorf("Only the first occurrence should be reported, got %d", reported)
+	}
+}
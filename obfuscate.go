@@ -0,0 +1,109 @@
+package errors
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base32"
+	stderrors "errors"
+	"sync"
+)
+
+// obfuscatedEncoding encodes public codes without padding or ambiguous lower-case letters
+var obfuscatedEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// Obfuscator maps internal error types to opaque public codes, for services that must not expose
+// their error taxonomy. Codes are derived from an HMAC of the type, so they are stable across
+// restarts and instances sharing the secret, and the reverse map stays on the server.
+// It is safe for concurrent use.
+type Obfuscator struct {
+	mu       sync.RWMutex
+	secret   []byte
+	public   map[string]string
+	internal map[string]string
+}
+
+// NewObfuscator creates an obfuscator keyed by secret and pre-registers types,
+// so that Reveal knows them before they are first returned to a client
+func NewObfuscator(secret []byte, types ...string) *Obfuscator {
+	o := &Obfuscator{
+		secret:   secret,
+		public:   make(map[string]string),
+		internal: make(map[string]string),
+	}
+	for _, t := range types {
+		o.PublicCode(t)
+	}
+	return o
+}
+
+// PublicCode returns the opaque public code for errorType, e.g. "E4KZQ7M"
+func (o *Obfuscator) PublicCode(errorType string) string {
+	o.mu.RLock()
+	code, ok := o.public[errorType]
+	o.mu.RUnlock()
+	if ok {
+		return code
+	}
+
+	mac := hmac.New(sha256.New, o.secret)
+	mac.Write([]byte(errorType))
+	encoded := obfuscatedEncoding.EncodeToString(mac.Sum(nil))
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if code, ok := o.public[errorType]; ok {
+		return code
+	}
+	// Lengthen the code on the unlikely collision with another type
+	for n := 6; n <= len(encoded); n++ {
+		code = "E" + encoded[:n]
+		if _, taken := o.internal[code]; !taken {
+			break
+		}
+	}
+	o.public[errorType] = code
+	o.internal[code] = errorType
+
+	return code
+}
+
+// Reveal returns the internal type behind a public code issued by this obfuscator
+func (o *Obfuscator) Reveal(publicCode string) (string, bool) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	errorType, ok := o.internal[publicCode]
+	return errorType, ok
+}
+
+// Mapping returns a copy of the issued public codes keyed by internal type, e.g. for support tooling
+func (o *Obfuscator) Mapping() map[string]string {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	mapping := make(map[string]string, len(o.public))
+	for errorType, code := range o.public {
+		mapping[errorType] = code
+	}
+	return mapping
+}
+
+// Obfuscate returns the Public copy of the first *Error in err's chain (or of DefaultError for other
+// errors) with its type replaced by the public code and its code reduced to the HTTP status.
+// Violation types are generic and kept as is.
+func (o *Obfuscator) Obfuscate(err error) *Error {
+	if err == nil {
+		return nil
+	}
+
+	var e *Error
+	if !stderrors.As(err, &e) {
+		e = DefaultError()
+	}
+
+	public := e.Public()
+	public.Type = o.PublicCode(e.Type)
+	public.Code = int64(HTTPStatus(e))
+	return public
+}
@@ -0,0 +1,49 @@
+package errors
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestObfuscator(t *testing.T) {
+	o := NewObfuscator([]byte("secret"), "USER_SUSPENDED")
+
+	code := o.PublicCode("USER_SUSPENDED")
+	if !strings.HasPrefix(code, "E") || len(code) != 7 || strings.Contains(code, "USER") {
+		t.Errorf("Unexpected public code %q", code)
+	}
+	if other := NewObfuscator([]byte("secret")).PublicCode("USER_SUSPENDED"); other != code {
+		t.Error("Public codes should be stable for the same secret")
+	}
+	if other := NewObfuscator([]byte("other")).PublicCode("USER_SUSPENDED"); other == code {
+		t.Error("Public codes should depend on the secret")
+	}
+
+	if errorType, ok := o.Reveal(code); !ok || errorType != "USER_SUSPENDED" {
+		t.Errorf("Reveal should return the internal type, got %q", errorType)
+	}
+	if _, ok := o.Reveal("EUNKNOWN"); ok {
+		t.Error("Reveal should not know unissued codes")
+	}
+}
+
+func TestObfuscate(t *testing.T) {
+	o := NewObfuscator([]byte("secret"))
+	err := fmt.Errorf("login: %w", New(40301, "Account suspended", "USER_SUSPENDED").WithInternalMessage("fraud score 0.97"))
+
+	public := o.Obfuscate(err)
+	if public.Type != o.PublicCode("USER_SUSPENDED") || public.Code != 500 || public.InternalMessage != "" {
+		t.Errorf("Unexpected obfuscated error %+v", public)
+	}
+	if o.Mapping()["USER_SUSPENDED"] != public.Type {
+		t.Error("Mapping should contain the issued code")
+	}
+
+	registry := NewRegistry()
+	registry.MustRegister(Definition{Type: "NOT_FOUND", Code: 404, Message: "Not found"})
+	externalized := registry.Externalize(registry.New("NOT_FOUND"), WithObfuscator(o))
+	if externalized.Type != o.PublicCode("NOT_FOUND") || externalized.Code != 404 {
+		t.Errorf("Externalize should obfuscate the type, got %s/%d", externalized.Type, externalized.Code)
+	}
+}
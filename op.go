@@ -0,0 +1,83 @@
+package errors
+
+import (
+	stderrors "errors"
+	"strings"
+)
+
+// WithOp records the logical operation that failed, e.g. "userservice.GetUser", and returns
+// the error for chaining
+func (e *Error) WithOp(op string) *Error {
+	return e.update(func(e *Error) { e.Op = op })
+}
+
+// WrapOp wraps err in a new error recording op, so that each layer can add its operation
+// without changing the classification: the type, code, message and transport statuses of the
+// nearest *Error in err's chain are kept, as is its stack trace. Errors that are not *Error are
+// classified like Wrap. WrapOp returns nil for a nil error.
+func WrapOp(op string, err error) *Error {
+	if isNil(err) {
+		return nil
+	}
+
+	var inner *Error
+	if !stderrors.As(err, &inner) {
+		e := wrapError(err, err, 1)
+		e.Op = op
+		return created(e)
+	}
+
+	e := inherit(inner, err)
+	e.Op = op
+	return e
+}
+
+// inherit returns a new error wrapping err with the classification of inner, the nearest *Error
+// in err's chain: its type, codes, message, stack trace, retry hints, timestamp, request and trace IDs and goroutine
+func inherit(inner *Error, err error) *Error {
+	s := inner.snapshot()
+	return &Error{
+		RequestID:    s.RequestID,
+		TraceID:      s.TraceID,
+		Type:         s.Type,
+		Code:         s.Code,
+		BusinessCode: s.BusinessCode,
+		Status:       s.Status,
+		GRPCCode:     s.GRPCCode,
+		Message:      s.Message,
+		Err:          err,
+		StackTraces:  s.StackTraces,
+		Retryable:    s.Retryable,
+		RetryAfter:   s.RetryAfter,
+		Timestamp:    s.Timestamp,
+		Goroutine:    s.Goroutine,
+	}
+}
+
+// OpTrace renders the operations recorded along the error's chain followed by the root cause,
+// e.g. "api.GetUser: store.FindUser: sql: no rows in result set". Repeated operations are
+// listed once. Without any operation it returns the root cause's message.
+func (e *Error) OpTrace() string {
+	if e == nil {
+		return ""
+	}
+
+	path := causePath(e)
+	parts := make([]string, 0, len(path))
+	for _, err := range path {
+		if appErr, ok := err.(*Error); ok {
+			if op := appErr.snapshot().Op; op != "" && (len(parts) == 0 || parts[len(parts)-1] != op) {
+				parts = append(parts, op)
+			}
+		}
+	}
+
+	root := path[len(path)-1]
+	if appErr, ok := root.(*Error); ok && appErr.cause() == nil {
+		parts = append(parts, appErr.snapshot().Message)
+	} else {
+		parts = append(parts, root.Error())
+	}
+
+	return strings.Join(parts, ": ")
+}
@@ -0,0 +1,46 @@
+package errors
+
+import (
+	"database/sql"
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestWrapOp(t *testing.T) {
+	if WrapOp("store.FindUser", nil) != nil {
+		t.Error("WrapOp(nil) should return nil")
+	}
+
+	store := WrapOp("store.FindUser", sql.ErrNoRows)
+	api := WrapOp("api.GetUser", WrapOp("api.GetUser", store))
+
+	if got := api.OpTrace(); got != "api.GetUser: store.FindUser: sql: no rows in result set" {
+		t.Errorf("Unexpected op trace %q", got)
+	}
+	if !stderrors.Is(api, sql.ErrNoRows) || api.Type != "INTERNAL_SERVER_ERROR" {
+		t.Error("The cause and classification should be kept")
+	}
+	if len(store.StackTraces) == 0 || !strings.Contains(store.StackTraces[0], "TestWrapOp") || api.StackTraces[0] != store.StackTraces[0] {
+		t.Error("Wrapping layers should reuse the innermost stack trace")
+	}
+}
+
+func TestWrapOpKeepsClassification(t *testing.T) {
+	notFound := ErrorNotFound().WithOp("store.FindUser")
+	api := WrapOp("api.GetUser", fmt.Errorf("finding: %w", notFound))
+
+	if api.Type != "NOT_FOUND" || api.Code != 404 || HTTPStatus(api) != 404 {
+		t.Errorf("Classification should be kept, got %s/%d", api.Type, api.Code)
+	}
+	if got := api.OpTrace(); got != "api.GetUser: store.FindUser: Not found" {
+		t.Errorf("Unexpected op trace %q", got)
+	}
+	if !strings.Contains(fmt.Sprintf("%+v", api), "op: api.GetUser") {
+		t.Error("Verbose format should show the operation")
+	}
+	if ErrorConflict().OpTrace() != "Conflict" {
+		t.Error("Without operations the trace is the message")
+	}
+}
@@ -0,0 +1,264 @@
+package errors
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limits applied to untrusted error documents
+const (
+	// maxResponseBody limits how much of an error response ParseResponse reads
+	maxResponseBody = 1 << 20
+	// maxEnvelopeSize is the largest document Parse and UnmarshalJSON accept
+	maxEnvelopeSize = maxResponseBody
+	// maxEnvelopeDepth is the deepest object and array nesting Parse and UnmarshalJSON accept
+	maxEnvelopeDepth = 32
+)
+
+// Errors returned by Parse and UnmarshalJSON
+var (
+	ErrEnvelopeTooLarge = stderrors.New("errors: envelope exceeds 1MB")
+	ErrEnvelopeTooDeep  = stderrors.New("errors: envelope nested too deeply")
+	ErrInvalidEnvelope  = stderrors.New("errors: invalid error envelope")
+)
+
+// problemDetails is the RFC 7807 problem details document
+type problemDetails struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int64  `json:"status"`
+	Detail   string `json:"detail"`
+	Instance string `json:"instance"`
+}
+
+// ParseResponse reconstructs an *Error from an error response written by this package
+// (WriteJSON and the framework integrations) or in RFC 7807 problem details format.
+// It returns nil for non-error statuses (below 400). When the body is in an unknown format,
+// e.g. replaced by a proxy, the envelope in EnvelopeHeader is used, and without one the error
+// is derived from the status code. A Retry-After header sets RetryAfter.
+// The body is consumed but not closed.
+func ParseResponse(resp *http.Response) (*Error, error) {
+	if resp == nil || resp.StatusCode < 400 {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	if err != nil {
+		return nil, err
+	}
+
+	e := parseBody(resp, body)
+	if d := parseRetryAfter(resp.Header.Get("Retry-After")); d > 0 {
+		e.WithRetryAfter(d)
+	}
+	return e, nil
+}
+
+// parseBody reconstructs the error from body, falling back to EnvelopeHeader and the status
+func parseBody(resp *http.Response, body []byte) *Error {
+	fallback := &Error{
+		Type:    statusType(resp.StatusCode),
+		Code:    int64(resp.StatusCode),
+		Status:  resp.StatusCode,
+		Message: http.StatusText(resp.StatusCode),
+	}
+
+	e, err := parseEnvelope(body, fallback)
+	if err != nil {
+		return fromEnvelopeHeader(resp, fallback)
+	}
+	e.Status = resp.StatusCode
+	return e
+}
+
+// Parse decodes an error envelope written by WriteJSON or the framework integrations, or an
+// RFC 7807 problem details document. It is meant for bodies from semi-trusted upstreams:
+// documents larger than 1MB or nested deeper than 32 levels are rejected, unknown keys are
+// ignored and invalid UTF-8 is replaced. A missing code defaults to 500 and a missing type is
+// derived from an HTTP status code. Anything else fails with ErrInvalidEnvelope.
+func Parse(data []byte) (*Error, error) {
+	return parseEnvelope(data, &Error{
+		Type:    "INTERNAL_SERVER_ERROR",
+		Code:    500,
+		Message: http.StatusText(http.StatusInternalServerError),
+	})
+}
+
+// parseEnvelope decodes data over a copy of fallback, which supplies missing values
+func parseEnvelope(data []byte, fallback *Error) (*Error, error) {
+	if err := checkEnvelope(data); err != nil {
+		return nil, err
+	}
+
+	var envelope struct {
+		problemDetails
+		ID           string            `json:"id"`
+		RequestID    string            `json:"request_id"`
+		TraceID      string            `json:"trace_id"`
+		Code         int64             `json:"code"`
+		BusinessCode string            `json:"business_code"`
+		Message      string            `json:"message"`
+		Violations   []ValidationError `json:"violations"`
+		Retryable    bool              `json:"retryable"`
+	}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidEnvelope, err)
+	}
+
+	e := fallback.Clone()
+	switch {
+	case envelope.Message != "" || envelope.Code != 0:
+		// This package's envelope
+		if envelope.Code != 0 {
+			e.Code = envelope.Code
+			if http.StatusText(int(envelope.Code)) != "" {
+				e.Type = statusType(int(envelope.Code))
+			}
+		}
+		if envelope.Type != "" {
+			e.Type = envelope.Type
+		}
+		e.ID = envelope.ID
+		e.RequestID = envelope.RequestID
+		e.TraceID = envelope.TraceID
+		e.BusinessCode = envelope.BusinessCode
+		e.Message = envelope.Message
+		e.Retryable = envelope.Retryable
+	case envelope.Title != "" || envelope.Detail != "":
+		// RFC 7807 problem details: the type is a URI whose last segment names the problem
+		if envelope.Status != 0 {
+			e.Code = envelope.Status
+			if http.StatusText(int(envelope.Status)) != "" {
+				e.Type = statusType(int(envelope.Status))
+			}
+		}
+		if envelope.Type != "" && envelope.Type != "about:blank" {
+			e.Type = strings.ToUpper(strings.ReplaceAll(envelope.Type[strings.LastIndex(envelope.Type, "/")+1:], "-", "_"))
+		}
+		e.Message = envelope.Title
+		if envelope.Detail != "" {
+			e.Message = envelope.Detail
+		}
+	default:
+		return nil, ErrInvalidEnvelope
+	}
+	if envelope.Violations != nil {
+		e.Violations = envelope.Violations
+	}
+
+	return e, nil
+}
+
+// checkEnvelope enforces the size and nesting limits on an untrusted JSON document
+func checkEnvelope(data []byte) error {
+	if len(data) > maxEnvelopeSize {
+		return ErrEnvelopeTooLarge
+	}
+
+	depth, inString, escaped := 0, false, false
+	for _, c := range data {
+		switch {
+		case escaped:
+			escaped = false
+		case inString:
+			if c == '\\' {
+				escaped = true
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+		case c == '{' || c == '[':
+			depth++
+			if depth > maxEnvelopeDepth {
+				return ErrEnvelopeTooDeep
+			}
+		case c == '}' || c == ']':
+			depth--
+		}
+	}
+	return nil
+}
+
+// errorJSON has the fields of Error without its methods, so UnmarshalJSON can use the
+// default decoding
+type errorJSON Error
+
+// UnmarshalJSON decodes the JSON form of an error, as produced by json.Marshal, with the size
+// and nesting limits of Parse. Stack traces are read in any StackFormat. Fields absent from the
+// document are left unchanged.
+func (e *Error) UnmarshalJSON(data []byte) error {
+	if err := checkEnvelope(data); err != nil {
+		return err
+	}
+
+	// Decoded over a deep copy and stored at once, so concurrent readers never see a partial
+	// error and maps and slices read earlier are not modified
+	s := e.Clone()
+	doc := struct {
+		*errorJSON
+		StackTraces json.RawMessage `json:"stack_traces"`
+	}{errorJSON: (*errorJSON)(s)}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	if doc.StackTraces != nil {
+		stack, err := parseStackJSON(doc.StackTraces)
+		if err != nil {
+			return err
+		}
+		s.StackTraces = stack
+	}
+	e.update(func(e *Error) { *e = *s })
+	return nil
+}
+
+// parseRetryAfter parses a Retry-After value in seconds or as an HTTP date
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return time.Until(at)
+	}
+	return 0
+}
+
+// fromEnvelopeHeader returns the error carried in resp's EnvelopeHeader, or fallback when
+// the header is missing or invalid
+func fromEnvelopeHeader(resp *http.Response, fallback *Error) *Error {
+	token := resp.Header.Get(EnvelopeHeader)
+	if token == "" {
+		return fallback
+	}
+
+	e, err := DecodeCompact(token)
+	if err != nil {
+		return fallback
+	}
+	e.Status = fallback.Status
+	return e
+}
+
+// statusType returns the type of the predefined error for an HTTP status, e.g. "TOO_MANY_REQUEST"
+// for 429, or derives one from the status text for statuses without one, e.g. "EXPECTATION_FAILED"
+// for 417
+func statusType(status int) string {
+	if class, ok := statusClasses[status]; ok {
+		return class.errorType
+	}
+	text := http.StatusText(status)
+	if text == "" {
+		return "INTERNAL_SERVER_ERROR"
+	}
+	return strings.ToUpper(strings.NewReplacer(" ", "_", "-", "_", "'", "").Replace(text))
+}
@@ -0,0 +1,203 @@
+package errors
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func response(status int, body string) *http.Response {
+	return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader(body))}
+}
+
+func TestParseResponseRoundTrip(t *testing.T) {
+	original := Violations([]ValidationError{
+		{Type: ViolationErrorTypeRequired, Field: "email", Message: "Email is required"},
+	})
+
+	rec := httptest.NewRecorder()
+	WriteJSON(rec, original)
+
+	parsed, err := ParseResponse(rec.Result())
+	if err != nil {
+		t.Fatalf("ParseResponse returned error: %v", err)
+	}
+	if parsed.Type != original.Type || parsed.Code != original.Code || parsed.Message != original.Message {
+		t.Errorf("Unexpected parsed error %+v", parsed)
+	}
+	if len(parsed.Violations) != 1 || parsed.Violations[0].Field != "email" {
+		t.Errorf("Violations should be parsed, got %+v", parsed.Violations)
+	}
+}
+
+func TestParseResponseProblemDetails(t *testing.T) {
+	parsed, _ := ParseResponse(response(http.StatusForbidden,
+		`{"type":"https://example.com/problems/out-of-credit","title":"You do not have enough credit.","status":403,"detail":"Your balance is 30, but that costs 50."}`))
+
+	if parsed.Type != "OUT_OF_CREDIT" || parsed.Code != 403 || parsed.Message != "Your balance is 30, but that costs 50." {
+		t.Errorf("Unexpected parsed error %+v", parsed)
+	}
+}
+
+func TestParseResponseUnknownBody(t *testing.T) {
+	parsed, err := ParseResponse(response(http.StatusBadGateway, "<html>bad gateway</html>"))
+	if err != nil || parsed.Type != "BAD_GATEWAY" || parsed.Code != 502 || parsed.Message != "Bad Gateway" {
+		t.Errorf("Unexpected parsed error %+v (%v)", parsed, err)
+	}
+
+	for _, tt := range []struct {
+		status int
+		target error
+	}{
+		{http.StatusTooManyRequests, ErrTooManyRequests},
+		{http.StatusServiceUnavailable, ErrorServiceUnavailable()},
+	} {
+		parsed, err := ParseResponse(response(tt.status, "<html><body>Slow down</body></html>"))
+		if err != nil || parsed.Type != FromHTTPStatus(tt.status).Type || !stderrors.Is(parsed, tt.target) {
+			t.Errorf("A %d with an HTML body should match the predefined error, got %+v (%v)", tt.status, parsed, err)
+		}
+	}
+	if parsed, _ := ParseResponse(response(http.StatusTooManyRequests, "rate limited")); !IsTooManyRequests(parsed) {
+		t.Errorf("IsTooManyRequests should hold for a parsed 429, got %s", parsed.Type)
+	}
+
+	if parsed, _ := ParseResponse(response(http.StatusOK, "{}")); parsed != nil {
+		t.Error("Successful responses should not produce an error")
+	}
+}
+
+func TestParseResponseEnvelopeHeaderFallback(t *testing.T) {
+	rec := httptest.NewRecorder()
+	WriteJSON(rec, ErrorInternalServerError())
+
+	// A CDN replaced the body but kept the headers
+	resp := rec.Result()
+	resp.Body = io.NopCloser(strings.NewReader("<html>Internal Server Error</html>"))
+
+	parsed, _ := ParseResponse(resp)
+	if parsed.Type != "INTERNAL_SERVER_ERROR" || parsed.Message != ErrorInternalServerError().Message {
+		t.Errorf("Expected the error from the envelope header, got %+v", parsed)
+	}
+}
+
+func TestRetryAfterRoundTrip(t *testing.T) {
+	rec := httptest.NewRecorder()
+	WriteJSON(rec, ErrorServiceUnavailable().WithRetryAfter(1500*time.Millisecond))
+
+	if got := rec.Header().Get("Retry-After"); got != "2" {
+		t.Errorf("Expected Retry-After 2, got %q", got)
+	}
+
+	parsed, _ := ParseResponse(rec.Result())
+	if parsed.RetryAfter != 2*time.Second || !parsed.Retryable {
+		t.Errorf("Retry-After should be parsed, got %v", parsed.RetryAfter)
+	}
+}
+
+func TestParse(t *testing.T) {
+	e, err := Parse([]byte(`{"type":"USER_NOT_FOUND","code":404,"message":"User not found","unknown":{"a":[1]},"violations":[{"type":"REQUIRED","field":"id","message":"bad ` + "\xff" + `"}]}`))
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if e.Type != "USER_NOT_FOUND" || e.Code != 404 || len(e.Violations) != 1 || e.Violations[0].Message != "bad �" {
+		t.Errorf("Unexpected parsed error %+v", e)
+	}
+
+	if e, _ := Parse([]byte(`{"code":409,"message":"Taken"}`)); e.Type != "CONFLICT" {
+		t.Errorf("Type should be derived from the code, got %q", e.Type)
+	}
+	if e, _ := Parse([]byte(`{"message":"Something broke"}`)); e.Type != "INTERNAL_SERVER_ERROR" || e.Code != 500 {
+		t.Errorf("Missing code should default to 500, got %s/%d", e.Type, e.Code)
+	}
+
+	cases := map[string]struct {
+		data string
+		want error
+	}{
+		"too large": {`{"message":"` + strings.Repeat("a", maxEnvelopeSize) + `"}`, ErrEnvelopeTooLarge},
+		"too deep":  {`{"message":"x","fields":` + strings.Repeat("[", 40) + strings.Repeat("]", 40) + `}`, ErrEnvelopeTooDeep},
+		"not json":  {`<html>`, ErrInvalidEnvelope},
+		"no error":  {`{"data":{}}`, ErrInvalidEnvelope},
+	}
+	for name, c := range cases {
+		if _, err := Parse([]byte(c.data)); !stderrors.Is(err, c.want) {
+			t.Errorf("%s: expected %v, got %v", name, c.want, err)
+		}
+	}
+	if _, err := Parse([]byte(`{"message":"[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[[\"{{{"}`)); err != nil {
+		t.Errorf("Brackets inside strings should not count as nesting, got %v", err)
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	original := ErrorNotFound().WithField("user_id", "42")
+	original.ID = "abc"
+	data, _ := json.Marshal(original)
+
+	var decoded Error
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	if decoded.ID != "abc" || decoded.Type != "NOT_FOUND" || decoded.Fields["user_id"] != "42" || len(decoded.StackTraces) != len(original.StackTraces) {
+		t.Errorf("Unexpected decoded error %+v", decoded)
+	}
+
+	deep := `{"fields":{"a":` + strings.Repeat("[", 40) + strings.Repeat("]", 40) + `}}`
+	if err := json.Unmarshal([]byte(deep), &decoded); !stderrors.Is(err, ErrEnvelopeTooDeep) {
+		t.Errorf("Deep documents should be rejected, got %v", err)
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	f.Add([]byte(`{"type":"NOT_FOUND","code":404,"message":"Not found","violations":[{"type":"REQUIRED","field":"id","message":"x"}]}`))
+	f.Add([]byte(`{"type":"https://example.com/problems/out-of-credit","title":"No credit","status":403}`))
+	f.Add([]byte("{\"message\":\"\xff\xfe\",\"code\":-1}"))
+	f.Add([]byte(`[[[[{}]]]]`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		e, err := Parse(data)
+		if err != nil {
+			return
+		}
+		if !utf8.ValidString(e.Type) || !utf8.ValidString(e.Message) {
+			t.Errorf("Parsed strings should be valid UTF-8: %q %q", e.Type, e.Message)
+		}
+
+		// A parsed error written back out must parse to the same classification
+		rec := httptest.NewRecorder()
+		WriteJSON(rec, e)
+		again, err := Parse(rec.Body.Bytes())
+		if err != nil {
+			if e.Message == "" && e.Code == 0 {
+				return
+			}
+			t.Fatalf("Re-parsing %s failed: %v", rec.Body.String(), err)
+		}
+		if again.Type != e.Type || again.Code != e.Code || again.Message != e.Message || len(again.Violations) != len(e.Violations) {
+			t.Errorf("Round trip changed the error: %+v != %+v", again, e)
+		}
+	})
+}
+
+func FuzzUnmarshalJSON(f *testing.F) {
+	data, _ := json.Marshal(ErrorNotFound().WithField("user_id", 42))
+	f.Add(data)
+	f.Add([]byte(`{"fields":{"a":[[[[1]]]]},"sampling":{"sampled":true,"rate":0.5}}`))
+	f.Add([]byte("{\"violations\":null,\"stack_traces\":[\"\xff\"]}"))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var e Error
+		if json.Unmarshal(data, &e) != nil {
+			return
+		}
+		if _, err := json.Marshal(&e); err != nil {
+			t.Errorf("A decoded error should marshal, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,46 @@
+package errors
+
+import "sync"
+
+// errorPool holds released errors for Acquire
+var errorPool = sync.Pool{
+	New: func() any {
+		return &Error{Violations: make([]ValidationError, 0, 4)}
+	},
+}
+
+// Acquire returns an error from a pool for high-frequency, short-lived errors, e.g. ones handled
+// and dropped within a request on a degraded hot path. Unlike New it captures no stack trace,
+// and the Violations slice keeps the capacity of earlier uses, so appending to it directly
+// (e.Violations = append(e.Violations, v)) does not allocate in the steady state. Pass the
+// error to Release once it is no longer referenced; errors that escape, e.g. into logs
+// buffered for later, a report queue or a hook that keeps them, must not be released.
+func Acquire(code int64, message, errorType string) *Error {
+	e := errorPool.Get().(*Error)
+	e.update(func(e *Error) {
+		e.Type = errorType
+		e.Code = code
+		e.Message = message
+	})
+	return created(e)
+}
+
+// Release resets e and returns it to the pool used by Acquire. Only release errors obtained
+// from Acquire, since their slices are reused, and do not use e afterwards. Releasing nil does
+// nothing.
+func Release(e *Error) {
+	if e == nil {
+		return
+	}
+
+	e.update(func(e *Error) {
+		violations, stack := e.Violations, e.StackTraces
+		clear(violations)
+		clear(stack)
+		*e = Error{Violations: violations[:0], StackTraces: stack[:0]}
+		if e.Violations == nil {
+			e.Violations = make([]ValidationError, 0, 4)
+		}
+	})
+	errorPool.Put(e)
+}
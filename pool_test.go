@@ -0,0 +1,32 @@
+package errors
+
+import "testing"
+
+func TestAcquireRelease(t *testing.T) {
+	e := Acquire(429, "Too many requests", "TOO_MANY_REQUEST")
+	if e.Code != 429 || e.Message != "Too many requests" || e.Type != "TOO_MANY_REQUEST" || len(e.StackTraces) != 0 {
+		t.Fatalf("Unexpected acquired error %+v", e)
+	}
+
+	e.Violations = append(e.Violations, ValidationError{Type: ViolationErrorTypeRequired, Field: "email", Message: "Email is required"})
+	e.WithField("user_id", "42").WithInternalMessage("secret")
+	Release(e)
+
+	if len(e.Violations) != 0 || e.Fields != nil || e.InternalMessage != "" || e.Type != "" {
+		t.Errorf("Release should reset the error, got %+v", e)
+	}
+	Release(nil)
+}
+
+func TestAcquireAllocations(t *testing.T) {
+	violation := ValidationError{Type: ViolationErrorTypeRequired, Field: "email", Message: "Email is required"}
+	allocs := testing.AllocsPerRun(100, func() {
+		e := Acquire(422, "Unprocessable entity", "UNPROCESSABLE_ENTITY")
+		e.Violations = append(e.Violations, violation)
+		_ = e.Error()
+		Release(e)
+	})
+	if allocs > 1 {
+		t.Errorf("Expected pooled errors to be almost allocation free, got %v allocations", allocs)
+	}
+}
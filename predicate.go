@@ -0,0 +1,50 @@
+package errors
+
+// IsType reports whether any *Error in err's chain has the given type
+func IsType(err error, errorType string) bool {
+	return matchAny(err, func(e *Error) bool { return e.Type == errorType })
+}
+
+// IsCode reports whether any *Error in err's chain has the given code
+func IsCode(err error, code int64) bool {
+	return matchAny(err, func(e *Error) bool { return e.Code == code })
+}
+
+// IsBadRequest reports whether err's chain contains a BAD_REQUEST error
+func IsBadRequest(err error) bool { return IsType(err, "BAD_REQUEST") }
+
+// IsUnauthorized reports whether err's chain contains an UNAUTHORIZED error
+func IsUnauthorized(err error) bool { return IsType(err, "UNAUTHORIZED") }
+
+// IsForbidden reports whether err's chain contains a FORBIDDEN error
+func IsForbidden(err error) bool { return IsType(err, "FORBIDDEN") }
+
+// IsNotFound reports whether err's chain contains a NOT_FOUND error
+func IsNotFound(err error) bool { return IsType(err, "NOT_FOUND") }
+
+// IsConflict reports whether err's chain contains a CONFLICT error
+func IsConflict(err error) bool { return IsType(err, "CONFLICT") }
+
+// IsUnprocessableEntity reports whether err's chain contains an UNPROCESSABLE_ENTITY error
+func IsUnprocessableEntity(err error) bool { return IsType(err, "UNPROCESSABLE_ENTITY") }
+
+// IsTooManyRequests reports whether err's chain contains a TOO_MANY_REQUEST error
+func IsTooManyRequests(err error) bool { return IsType(err, "TOO_MANY_REQUEST") }
+
+// IsInternalServerError reports whether err's chain contains an INTERNAL_SERVER_ERROR error
+func IsInternalServerError(err error) bool { return IsType(err, "INTERNAL_SERVER_ERROR") }
+
+// IsPanic reports whether err's chain contains a PANIC error
+func IsPanic(err error) bool { return IsType(err, "PANIC") }
+
+// matchAny reports whether match holds for any *Error in err's chain, including every branch
+// of multi-unwrap errors. Aggregates are skipped since their type and code are derived from
+// their members, which are matched instead.
+func matchAny(err error, match func(e *Error) bool) bool {
+	for _, inner := range Chain(err) {
+		if e, ok := inner.(*Error); ok && !e.isAggregate() && match(e) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,26 @@
+package errors
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestPredicates(t *testing.T) {
+	err := fmt.Errorf("loading user: %w", Wrap(ErrorNotFound()))
+
+	if !IsNotFound(err) || !IsType(err, "NOT_FOUND") || !IsCode(err, 404) {
+		t.Error("Predicates should match the wrapped NOT_FOUND error")
+	}
+	// The outer INTERNAL_SERVER_ERROR wrapper is part of the chain as well
+	if !IsInternalServerError(err) || !IsCode(err, 500) {
+		t.Error("Predicates should match every *Error in the chain")
+	}
+	if IsConflict(err) || IsCode(err, 409) || IsType(nil, "NOT_FOUND") {
+		t.Error("Predicates should not match absent errors")
+	}
+
+	combined := Combine(ErrorUnauthorized(), ErrorTooManyRequests())
+	if !IsUnauthorized(combined) || !IsTooManyRequests(combined) {
+		t.Error("Predicates should match any branch of an aggregate")
+	}
+}
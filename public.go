@@ -0,0 +1,34 @@
+package errors
+
+// WithInternalMessage sets a message meant for logs and operators only and returns the error for chaining.
+// It is removed by Public.
+func (e *Error) WithInternalMessage(message string) *Error {
+	return e.update(func(e *Error) { e.InternalMessage = message })
+}
+
+// Public returns a copy of the error that is safe to send to clients.
+// It keeps the ID, request and trace IDs, type, code, business code, transport statuses, message, violations, retry hints and timestamp, and strips the internal message,
+// wrapped error, fields, notes, message template and parameters, stack traces, sampling data and goroutine.
+// Error() on the copy returns Message instead of the wrapped error's text.
+func (e *Error) Public() *Error {
+	if e == nil {
+		return nil
+	}
+
+	s := e.snapshot()
+	return &Error{
+		ID:           s.ID,
+		RequestID:    s.RequestID,
+		TraceID:      s.TraceID,
+		Type:         s.Type,
+		Code:         s.Code,
+		BusinessCode: s.BusinessCode,
+		Status:       s.Status,
+		GRPCCode:     s.GRPCCode,
+		Message:      s.Message,
+		Violations:   append(make([]ValidationError, 0, len(s.Violations)), s.Violations...),
+		Retryable:    s.Retryable,
+		RetryAfter:   s.RetryAfter,
+		Timestamp:    s.Timestamp,
+	}
+}
@@ -0,0 +1,48 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestPublic(t *testing.T) {
+	err := Wrap(fmt.Errorf("pq: duplicate key value violates unique constraint \"users_email_key\"")).
+		WithInternalMessage("insert into users failed").
+		WithField("query", "INSERT INTO users ...")
+	err.Violations = append(err.Violations, ValidationError{Type: ViolationErrorTypeRequired, Field: "email"})
+
+	public := err.Public()
+	if public.Error() != "An internal server error occurred" {
+		t.Errorf("Public error should not leak the wrapped error, got %q", public.Error())
+	}
+
+	data, _ := json.Marshal(public)
+	for _, leaked := range []string{"pq:", "insert into users", "INSERT INTO", "stack_traces\":[\""} {
+		if strings.Contains(string(data), leaked) {
+			t.Errorf("Public JSON leaks %q: %s", leaked, data)
+		}
+	}
+
+	if public.Type != err.Type || public.Code != err.Code || len(public.Violations) != 1 {
+		t.Error("Public should keep type, code and violations")
+	}
+
+	public.Violations[0].Field = "changed"
+	if err.Violations[0].Field != "email" {
+		t.Error("Public should copy violations")
+	}
+
+	var nilErr *Error
+	if nilErr.Public() != nil {
+		t.Error("Public of nil should be nil")
+	}
+}
+
+func TestInternalMessageNotSerialized(t *testing.T) {
+	data, _ := json.Marshal(Wrap(fmt.Errorf("boom")).WithInternalMessage("secret sql"))
+	if strings.Contains(string(data), "secret sql") {
+		t.Errorf("The internal message should never be serialized: %s", data)
+	}
+}
@@ -0,0 +1,44 @@
+package errors
+
+// Recover converts a panic into ErrorPanic() carrying the panic value and the panicking
+// goroutine's stack, and stores it in *errp. It must be deferred directly:
+//
+//	func (w *Worker) process(job Job) (err error) {
+//		defer errors.Recover(&err)
+//		...
+//	}
+//
+// Without a panic *errp is left unchanged. Like FromPanic, http.ErrAbortHandler is panicked again.
+func Recover(errp *error) {
+	recovered := recover()
+	if recovered == nil {
+		return
+	}
+
+	e := FromPanic(recovered)
+	if errp != nil {
+		*errp = e
+	}
+}
+
+// Go runs fn in a new goroutine and returns a channel receiving its error, or the error
+// Recover produces when fn panics. The channel is closed once fn returns, so receiving
+// yields nil when fn succeeds.
+func Go(fn func() error) <-chan error {
+	result := make(chan error, 1)
+
+	go func() {
+		defer close(result)
+
+		var err error
+		func() {
+			defer Recover(&err)
+			err = fn()
+		}()
+		if err != nil {
+			result <- err
+		}
+	}()
+
+	return result
+}
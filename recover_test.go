@@ -0,0 +1,49 @@
+package errors
+
+import (
+	stderrors "errors"
+	"strings"
+	"testing"
+)
+
+func panicking() (err error) {
+	defer Recover(&err)
+	panic("boom")
+}
+
+func TestRecover(t *testing.T) {
+	err := panicking()
+
+	var e *Error
+	if !stderrors.As(err, &e) || e.Type != "PANIC" || e.Unwrap().Error() != "boom" {
+		t.Fatalf("Expected a panic error, got %v", err)
+	}
+	if !strings.Contains(strings.Join(e.StackTraces, "\n"), "panicking") {
+		t.Errorf("The stack should include the panicking function, got %v", e.StackTraces)
+	}
+
+	cause := stderrors.New("failed")
+	err = func() (err error) {
+		defer Recover(&err)
+		return cause
+	}()
+	if err != cause {
+		t.Errorf("Without a panic the error should be kept, got %v", err)
+	}
+}
+
+func TestGo(t *testing.T) {
+	if err := <-Go(func() error { return nil }); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	cause := stderrors.New("failed")
+	if err := <-Go(func() error { return cause }); err != cause {
+		t.Errorf("Expected the returned error, got %v", err)
+	}
+
+	err := <-Go(func() error { panic(stderrors.New("nil map")) })
+	if !IsType(err, "PANIC") || err.Error() != "nil map" {
+		t.Errorf("Expected the recovered panic, got %v", err)
+	}
+}
@@ -0,0 +1,240 @@
+package errors
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type (
+	// Definition describes a registered error type.
+	// Message and Messages are templates where {name} placeholders are replaced by parameters.
+	Definition struct {
+		Type string `json:"type" yaml:"type"`
+		Code int64  `json:"code" yaml:"code"`
+		// BusinessCode is the stable application-level code, e.g. "USR-0042"
+		BusinessCode string `json:"business_code,omitempty" yaml:"business_code,omitempty"`
+		Message      string `json:"message" yaml:"message"`
+		HTTPStatus   int    `json:"http_status" yaml:"http_status"`
+		// GRPCCode holds a google.golang.org/grpc/codes value
+		GRPCCode  uint32            `json:"grpc_code" yaml:"grpc_code"`
+		Retryable bool              `json:"retryable" yaml:"retryable"`
+		Messages  map[string]string `json:"messages,omitempty" yaml:"messages,omitempty"`
+		// FieldDocs maps violation fields to documentation URLs attached to matching violations
+		FieldDocs map[string]string `json:"field_docs,omitempty" yaml:"field_docs,omitempty"`
+		// Beta types are only exposed to opted-in callers; others see the Fallback type, see Externalize
+		Beta     bool   `json:"beta,omitempty" yaml:"beta,omitempty"`
+		Fallback string `json:"fallback,omitempty" yaml:"fallback,omitempty"`
+	}
+
+	// Override customizes an error created from a registry definition
+	Override func(*Error)
+
+	// Constructor creates an error of one registered type from template parameters
+	Constructor func(params map[string]any, overrides ...Override) *Error
+
+	// Registry is a catalog of error definitions keyed by type.
+	// It is safe for concurrent use.
+	Registry struct {
+		mu          sync.RWMutex
+		definitions map[string]Definition
+	}
+)
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{
+		definitions: make(map[string]Definition),
+	}
+}
+
+// Register adds a definition to the registry.
+// It fails when the type is empty or already registered, or when a beta type has no fallback.
+func (r *Registry) Register(def Definition) error {
+	if def.Type == "" {
+		return fmt.Errorf("errors: definition type is required")
+	}
+	if def.Beta && def.Fallback == "" {
+		return fmt.Errorf("errors: beta type %q requires a fallback type", def.Type)
+	}
+
+	if def.HTTPStatus == 0 && def.Code >= 100 && def.Code <= 599 {
+		def.HTTPStatus = int(def.Code)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.definitions[def.Type]; exists {
+		return fmt.Errorf("errors: type %q is already registered", def.Type)
+	}
+	r.definitions[def.Type] = def
+
+	return nil
+}
+
+// MustRegister registers definitions and panics on the first failure.
+// It is intended for package initialization.
+func (r *Registry) MustRegister(defs ...Definition) {
+	for _, def := range defs {
+		if err := r.Register(def); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Lookup returns the definition registered for errorType
+func (r *Registry) Lookup(errorType string) (Definition, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	def, ok := r.definitions[errorType]
+	return def, ok
+}
+
+// New creates an error from the definition registered for errorType and applies the overrides.
+// The definition's HTTP status and gRPC code are carried in Status and GRPCCode, and violations
+// of fields listed in FieldDocs get their docs URL.
+// An unregistered type yields a 500 error with an "unregistered_type" field; overrides still apply.
+func (r *Registry) New(errorType string, overrides ...Override) *Error {
+	return r.build(errorType, "", nil, overrides)
+}
+
+// NewLocalized creates an error using the definition's message for locale, falling back to
+// the default message, and fills its {name} placeholders from params.
+func (r *Registry) NewLocalized(errorType, locale string, params map[string]any, overrides ...Override) *Error {
+	return r.build(errorType, locale, params, overrides)
+}
+
+// Constructor returns a constructor bound to errorType.
+// It fails when the type is not registered, so constructors can be resolved at startup.
+func (r *Registry) Constructor(errorType string) (Constructor, error) {
+	if _, ok := r.Lookup(errorType); !ok {
+		return nil, fmt.Errorf("errors: type %q is not registered", errorType)
+	}
+
+	return func(params map[string]any, overrides ...Override) *Error {
+		return r.build(errorType, "", params, overrides)
+	}, nil
+}
+
+// MustConstructor is like Constructor but panics when the type is not registered
+func (r *Registry) MustConstructor(errorType string) Constructor {
+	constructor, err := r.Constructor(errorType)
+	if err != nil {
+		panic(err)
+	}
+	return constructor
+}
+
+// build creates the error; it must be called directly by the exported constructor
+// so that the stack trace starts at the caller
+func (r *Registry) build(errorType, locale string, params map[string]any, overrides []Override) *Error {
+	def, ok := r.Lookup(errorType)
+	if !ok {
+		def = Definition{Type: "INTERNAL_SERVER_ERROR", Code: 500, Message: "An internal server error occurred"}
+	}
+
+	message := def.Message
+	if localized, exists := def.Messages[locale]; exists && locale != "" {
+		message = localized
+	}
+
+	e := &Error{
+		Type:         def.Type,
+		Code:         def.Code,
+		BusinessCode: def.BusinessCode,
+		Message:      renderTemplate(message, params),
+		StackTraces:  captureStackTrace(2),
+		Retryable:    def.Retryable,
+		Status:       def.HTTPStatus,
+		GRPCCode:     def.GRPCCode,
+	}
+	if len(params) > 0 {
+		e.MessageTemplate = message
+	}
+
+	for _, override := range overrides {
+		override(e)
+	}
+	if len(def.FieldDocs) > 0 {
+		e.Violations = linkFieldDocs(e.Violations, def.FieldDocs)
+	}
+	if !ok {
+		e.WithField("unregistered_type", errorType)
+	}
+
+	return created(e)
+}
+
+// linkFieldDocs returns a copy of violations where those without a docs URL point to
+// the documentation registered for their field
+func linkFieldDocs(violations []ValidationError, docs map[string]string) []ValidationError {
+	linked := make([]ValidationError, len(violations))
+	for i, v := range violations {
+		if url, ok := docs[v.Field]; ok && v.DocsURL == "" {
+			v.DocsURL = url
+		}
+		linked[i] = v
+	}
+	return linked
+}
+
+// renderTemplate replaces {name} placeholders with the matching parameters
+func renderTemplate(template string, params map[string]any) string {
+	if len(params) == 0 {
+		return template
+	}
+
+	pairs := make([]string, 0, len(params)*2)
+	for name, value := range params {
+		pairs = append(pairs, "{"+name+"}", fmt.Sprint(value))
+	}
+
+	return strings.NewReplacer(pairs...).Replace(template)
+}
+
+// Catalog returns all registered definitions sorted by type, e.g. for documentation export
+func (r *Registry) Catalog() []Definition {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	catalog := make([]Definition, 0, len(r.definitions))
+	for _, def := range r.definitions {
+		catalog = append(catalog, def)
+	}
+	sort.Slice(catalog, func(i, j int) bool {
+		return catalog[i].Type < catalog[j].Type
+	})
+
+	return catalog
+}
+
+// OverrideMessage replaces the default message of the definition
+func OverrideMessage(message string) Override {
+	return func(e *Error) {
+		e.Message = message
+	}
+}
+
+// OverrideViolations sets the validation violations of the created error
+func OverrideViolations(violations []ValidationError) Override {
+	return func(e *Error) {
+		e.Violations = violations
+	}
+}
+
+// OverrideField sets a structured field on the created error
+func OverrideField(key string, value any) Override {
+	return func(e *Error) {
+		e.WithField(key, value)
+	}
+}
+
+// OverrideCause sets the wrapped error of the created error
+func OverrideCause(err error) Override {
+	return func(e *Error) {
+		e.Err = err
+	}
+}
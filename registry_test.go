@@ -0,0 +1,111 @@
+package errors
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func newTestRegistry() *Registry {
+	r := NewRegistry()
+	r.MustRegister(
+		Definition{Type: "USER_NOT_FOUND", Code: 404, Message: "User not found", GRPCCode: 5},
+		Definition{Type: "INSUFFICIENT_FUNDS", Code: 10423, Message: "Insufficient funds", HTTPStatus: 422},
+	)
+	return r
+}
+
+func TestRegistryNew(t *testing.T) {
+	r := newTestRegistry()
+
+	err := r.New("USER_NOT_FOUND", OverrideMessage("User 42 not found"), OverrideField("user_id", 42))
+	if err.Type != "USER_NOT_FOUND" || err.Code != 404 {
+		t.Errorf("Unexpected type/code %s/%d", err.Type, err.Code)
+	}
+	if err.Message != "User 42 not found" || err.Fields["user_id"] != 42 {
+		t.Error("Overrides should be applied")
+	}
+	if len(err.StackTraces) == 0 || !strings.Contains(err.StackTraces[0], "TestRegistryNew") {
+		t.Error("Stack trace should start at the caller of Registry.New")
+	}
+
+	unknown := r.New("MISSING", OverrideMessage("Something is missing"))
+	if unknown.Code != 500 || unknown.Fields["unregistered_type"] != "MISSING" {
+		t.Errorf("Unregistered type should produce a default error, got %+v", unknown)
+	}
+	if unknown.Message != "Something is missing" {
+		t.Error("Overrides should be applied to unregistered types")
+	}
+}
+
+func TestRegistryTransportStatus(t *testing.T) {
+	r := newTestRegistry()
+
+	funds := r.New("INSUFFICIENT_FUNDS")
+	if funds.Status != 422 || HTTPStatus(funds) != 422 {
+		t.Errorf("Definition HTTP status should be used, got %d", HTTPStatus(funds))
+	}
+	if notFound := r.New("USER_NOT_FOUND"); notFound.GRPCCode != 5 {
+		t.Errorf("Definition gRPC code should be carried, got %d", notFound.GRPCCode)
+	}
+	if public := funds.Public(); HTTPStatus(public) != 422 {
+		t.Errorf("Public copy should keep the HTTP status, got %d", HTTPStatus(public))
+	}
+}
+
+func TestRegistryFieldDocs(t *testing.T) {
+	r := NewRegistry()
+	r.MustRegister(Definition{
+		Type:      "INVALID_SIGNUP",
+		Code:      422,
+		Message:   "Invalid signup",
+		FieldDocs: map[string]string{"email": "https://docs.example.com/signup#email", "age": "https://docs.example.com/signup#age"},
+	})
+
+	violations := []ValidationError{
+		{Type: ViolationErrorTypeEmail, Field: "email", Message: "Email is invalid"},
+		{Type: ViolationErrorTypeMin, Field: "age", Message: "Age is too low", DocsURL: "https://docs.example.com/age"},
+		{Type: ViolationErrorTypeRequired, Field: "name", Message: "Name is required"},
+	}
+	err := r.New("INVALID_SIGNUP", OverrideViolations(violations))
+
+	if err.Violations[0].DocsURL != "https://docs.example.com/signup#email" {
+		t.Errorf("Registered field docs should be attached, got %q", err.Violations[0].DocsURL)
+	}
+	if err.Violations[1].DocsURL != "https://docs.example.com/age" || err.Violations[2].DocsURL != "" {
+		t.Errorf("Existing and missing docs should be left alone, got %+v", err.Violations)
+	}
+	if violations[0].DocsURL != "" {
+		t.Error("The caller's violations should not be modified")
+	}
+}
+
+func TestRegistryRegister(t *testing.T) {
+	r := newTestRegistry()
+
+	if err := r.Register(Definition{Type: "USER_NOT_FOUND", Code: 404}); err == nil {
+		t.Error("Registering a duplicate type should fail")
+	}
+	if err := r.Register(Definition{Code: 400}); err == nil {
+		t.Error("Registering without a type should fail")
+	}
+
+	def, ok := r.Lookup("USER_NOT_FOUND")
+	if !ok || def.HTTPStatus != 404 {
+		t.Errorf("HTTP status should default to the code, got %d", def.HTTPStatus)
+	}
+	def, _ = r.Lookup("INSUFFICIENT_FUNDS")
+	if def.HTTPStatus != 422 {
+		t.Errorf("Explicit HTTP status should be kept, got %d", def.HTTPStatus)
+	}
+}
+
+func TestRegistryCatalog(t *testing.T) {
+	catalog := newTestRegistry().Catalog()
+	if len(catalog) != 2 {
+		t.Fatalf("Expected 2 definitions, got %d", len(catalog))
+	}
+	if catalog[0].Type != "INSUFFICIENT_FUNDS" || catalog[1].Type != "USER_NOT_FOUND" {
+		t.Errorf("Catalog should be sorted by type: %s", fmt.Sprint(catalog))
+	}
+}
@@ -0,0 +1,166 @@
+package errors
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// maxWebhookInFlight bounds the concurrent deliveries of a WebhookReporter; reports beyond it
+// are dropped
+const maxWebhookInFlight = 16
+
+// defaultWebhookTimeout bounds a webhook delivery when no client is given
+const defaultWebhookTimeout = 5 * time.Second
+
+var (
+	reporterMu      sync.RWMutex
+	defaultReporter = LogReporter(nil)
+)
+
+// SetDefaultReporter replaces the reporter used by ReportAndReturn, initially LogReporter(nil).
+// Passing nil discards reports.
+func SetDefaultReporter(r Reporter) {
+	if r == nil {
+		r = NopReporter
+	}
+
+	reporterMu.Lock()
+	defer reporterMu.Unlock()
+	defaultReporter = r
+}
+
+// DefaultReporter returns the reporter set with SetDefaultReporter
+func DefaultReporter() Reporter {
+	reporterMu.RLock()
+	defer reporterMu.RUnlock()
+	return defaultReporter
+}
+
+// ReportAndReturn reports err to DefaultReporter and returns it unchanged, for fire-and-forget
+// reporting on the way out: return errors.ReportAndReturn(ctx, err). Errors without an *Error
+// in their chain are reported wrapped with Wrap, with the stack trace of the caller. A nil
+// error is returned without reporting.
+func ReportAndReturn(ctx context.Context, err error) error {
+	if isNil(err) {
+		return err
+	}
+
+	var e *Error
+	if !stderrors.As(err, &e) || e == nil {
+		e = createdFrom(err, wrapError(err, err, 1))
+	}
+	DefaultReporter().Report(ctx, e)
+	return err
+}
+
+// TextReporter returns a Reporter writing one line per error to w: the time, severity, type,
+// code, ID and cause chain (see CauseChain). Writes are serialized.
+func TextReporter(w io.Writer) Reporter {
+	var mu sync.Mutex
+	return ReporterFunc(func(_ context.Context, err *Error) {
+		if err == nil {
+			return
+		}
+
+		s := err.snapshot()
+		line := fmt.Sprintf("%s %s %s (%d)", time.Now().Format(time.RFC3339), err.LogSeverity(), s.Type, s.Code)
+		if s.ID != "" {
+			line += " [" + s.ID + "]"
+		}
+		line += ": " + err.CauseChain() + "\n"
+
+		mu.Lock()
+		defer mu.Unlock()
+		_, _ = io.WriteString(w, line)
+	})
+}
+
+// StderrReporter returns a TextReporter writing to standard error
+func StderrReporter() Reporter {
+	return TextReporter(os.Stderr)
+}
+
+// reportRecord is a line written by JSONLinesReporter
+type reportRecord struct {
+	Time  time.Time       `json:"time"`
+	Error json.RawMessage `json:"error"`
+}
+
+// JSONLinesReporter returns a Reporter writing one JSON document per line to w, typically a file
+// opened with os.O_APPEND. Each line holds the report "time" and the "error" in the encoding of
+// MarshalText, internal details and stack trace included, so it can be decoded with UnmarshalText.
+// Writes are serialized.
+func JSONLinesReporter(w io.Writer) Reporter {
+	var mu sync.Mutex
+	return ReporterFunc(func(_ context.Context, err *Error) {
+		if err == nil {
+			return
+		}
+
+		text, marshalErr := err.MarshalText()
+		if marshalErr != nil {
+			return
+		}
+		line, marshalErr := json.Marshal(reportRecord{Time: time.Now().UTC(), Error: text})
+		if marshalErr != nil {
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		_, _ = w.Write(append(line, '\n'))
+	})
+}
+
+// WebhookReporter returns a Reporter posting the Summary of each error (see Summarize) as JSON
+// to url. Delivery is fire-and-forget: Report returns at once, the request runs in the
+// background, detached from the caller's cancellation, and failures are ignored. At most 16
+// deliveries run at a time; further reports are dropped until one completes. A nil client
+// uses one with a 5 second timeout.
+func WebhookReporter(url string, client *http.Client) Reporter {
+	if client == nil {
+		client = &http.Client{Timeout: defaultWebhookTimeout}
+	}
+	slots := make(chan struct{}, maxWebhookInFlight)
+
+	return ReporterFunc(func(ctx context.Context, err *Error) {
+		if err == nil {
+			return
+		}
+		body, marshalErr := json.Marshal(Summarize(err))
+		if marshalErr != nil {
+			return
+		}
+
+		select {
+		case slots <- struct{}{}:
+		default:
+			return
+		}
+
+		ctx = context.WithoutCancel(ctx)
+		go func() {
+			defer func() { <-slots }()
+
+			req, reqErr := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+			if reqErr != nil {
+				return
+			}
+			req.Header.Set("Content-Type", "application/json")
+			resp, doErr := client.Do(req)
+			if doErr != nil {
+				return
+			}
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}()
+	})
+}
@@ -0,0 +1,144 @@
+package errors
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTextReporter(t *testing.T) {
+	var buf bytes.Buffer
+	reporter := TextReporter(&buf)
+
+	err := New(402, "Payment declined", "PAYMENT_DECLINED").WithCause(stderrors.New("card expired"))
+	err.ID = "err_1"
+	reporter.Report(context.Background(), err)
+	reporter.Report(context.Background(), nil)
+
+	line := buf.String()
+	if !strings.HasSuffix(line, " info PAYMENT_DECLINED (402) [err_1]: Payment declined: card expired\n") {
+		t.Errorf("Unexpected line %q", line)
+	}
+	if strings.Count(line, "\n") != 1 {
+		t.Error("Nil errors should not be reported")
+	}
+}
+
+func TestJSONLinesReporter(t *testing.T) {
+	var buf bytes.Buffer
+	reporter := JSONLinesReporter(&buf)
+
+	reporter.Report(context.Background(), ErrorNotFound().WithInternalMessage("row 42 missing"))
+	reporter.Report(context.Background(), ErrorConflict())
+
+	scanner := bufio.NewScanner(&buf)
+	var lines []reportRecord
+	for scanner.Scan() {
+		var record reportRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("Line %q is not JSON: %v", scanner.Text(), err)
+		}
+		lines = append(lines, record)
+	}
+	if len(lines) != 2 || lines[0].Time.IsZero() {
+		t.Fatalf("Expected two timestamped lines, got %+v", lines)
+	}
+
+	var decoded Error
+	if err := decoded.UnmarshalText(lines[0].Error); err != nil {
+		t.Fatalf("UnmarshalText failed: %v", err)
+	}
+	if decoded.Type != "NOT_FOUND" || decoded.InternalMessage != "row 42 missing" || len(decoded.StackTraces) == 0 {
+		t.Errorf("The line should carry the full error, got %+v", decoded)
+	}
+}
+
+func TestWebhookReporter(t *testing.T) {
+	received := make(chan Summary, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var summary Summary
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.Header.Get("Content-Type"))
+		}
+		_ = json.NewDecoder(r.Body).Decode(&summary)
+		received <- summary
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	WebhookReporter(server.URL, nil).Report(ctx, ErrorServiceUnavailable())
+	cancel()
+
+	select {
+	case summary := <-received:
+		if summary.Type != "SERVICE_UNAVAILABLE" || summary.Fingerprint == "" {
+			t.Errorf("Unexpected summary %+v", summary)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("The webhook was not called despite the caller's cancellation")
+	}
+}
+
+func TestWebhookReporterDropsWhenSaturated(t *testing.T) {
+	release := make(chan struct{})
+	calls := make(chan struct{}, 2*maxWebhookInFlight)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls <- struct{}{}
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	reporter := WebhookReporter(server.URL, nil)
+	for range 2 * maxWebhookInFlight {
+		reporter.Report(context.Background(), ErrorInternalServerError())
+	}
+	for range maxWebhookInFlight {
+		<-calls
+	}
+
+	select {
+	case <-calls:
+		t.Error("Reports beyond the in-flight limit should be dropped")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestReportAndReturn(t *testing.T) {
+	var reported []*Error
+	SetDefaultReporter(ReporterFunc(func(_ context.Context, err *Error) { reported = append(reported, err) }))
+	defer SetDefaultReporter(LogReporter(nil))
+
+	plain := stderrors.New("disk full")
+	if got := ReportAndReturn(context.Background(), plain); got != plain {
+		t.Error("ReportAndReturn should return the error unchanged")
+	}
+	appErr := ErrorConflict()
+	_ = ReportAndReturn(context.Background(), appErr)
+	if ReportAndReturn(context.Background(), nil) != nil {
+		t.Error("nil should be returned as is")
+	}
+
+	if len(reported) != 2 {
+		t.Fatalf("Expected two reports, got %d", len(reported))
+	}
+	if reported[0].Type != "INTERNAL_SERVER_ERROR" || !strings.Contains(reported[0].StackTraces[0], "TestReportAndReturn") {
+		t.Errorf("Plain errors should be wrapped at the caller, got %s %v", reported[0].Type, reported[0].StackTraces)
+	}
+	if reported[1] != appErr {
+		t.Error("*Error values should be reported as is")
+	}
+
+	SetDefaultReporter(nil)
+	_ = ReportAndReturn(context.Background(), appErr)
+	if len(reported) != 2 {
+		t.Error("SetDefaultReporter(nil) should discard reports")
+	}
+}
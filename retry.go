@@ -0,0 +1,39 @@
+package errors
+
+import "time"
+
+// WithRetryable marks whether the failed operation may succeed if retried and returns the error for chaining
+func (e *Error) WithRetryable(retryable bool) *Error {
+	return e.update(func(e *Error) { e.Retryable = retryable })
+}
+
+// WithRetryAfter marks the error retryable and records how long callers should wait before retrying.
+// HTTP and gRPC layers can surface it as a Retry-After header or RetryInfo detail.
+func (e *Error) WithRetryAfter(d time.Duration) *Error {
+	return e.update(func(e *Error) {
+		e.Retryable = true
+		e.RetryAfter = d
+	})
+}
+
+// Temporary reports whether the error is retryable, following the net.Error convention
+func (e *Error) Temporary() bool {
+	return e != nil && e.snapshot().Retryable
+}
+
+// Timeout reports whether the error represents a timeout (408 or 504)
+func (e *Error) Timeout() bool {
+	return e != nil && (e.Code == 408 || e.Code == 504)
+}
+
+// IsRetryable reports whether any error in err's chain has a Temporary method returning true.
+// This covers *Error as well as wrapped standard library network errors.
+// Every branch of multi-unwrap errors, such as aggregates, is inspected.
+func IsRetryable(err error) bool {
+	for _, inner := range Chain(err) {
+		if temporary, ok := inner.(interface{ Temporary() bool }); ok && temporary.Temporary() {
+			return true
+		}
+	}
+	return false
+}
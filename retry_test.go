@@ -0,0 +1,56 @@
+package errors
+
+import (
+	"fmt"
+	"net"
+	"testing"
+	"time"
+)
+
+type timeoutNetError struct{}
+
+func (timeoutNetError) Error() string   { return "i/o timeout" }
+func (timeoutNetError) Timeout() bool   { return true }
+func (timeoutNetError) Temporary() bool { return true }
+
+var _ net.Error = timeoutNetError{}
+
+func TestRetryable(t *testing.T) {
+	err := ErrorInternalServerError()
+	if IsRetryable(err) || err.Temporary() {
+		t.Error("Errors should not be retryable by default")
+	}
+
+	err.WithRetryAfter(2 * time.Second)
+	if !IsRetryable(fmt.Errorf("calling payments: %w", err)) {
+		t.Error("IsRetryable should walk the chain")
+	}
+	if err.RetryAfter != 2*time.Second || !err.Public().Retryable {
+		t.Error("Retry hints should be kept on the public copy")
+	}
+
+	if !IsRetryable(Wrap(timeoutNetError{})) {
+		t.Error("IsRetryable should honour wrapped network errors")
+	}
+	if !ErrorTooManyRequests().Temporary() {
+		t.Error("429 should be retryable")
+	}
+	if IsRetryable(nil) {
+		t.Error("nil should not be retryable")
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	if !New(504, "Gateway timeout", "GATEWAY_TIMEOUT").Timeout() {
+		t.Error("504 should be a timeout")
+	}
+	if ErrorNotFound().Timeout() {
+		t.Error("404 should not be a timeout")
+	}
+}
+
+func TestIsRetryableAggregate(t *testing.T) {
+	if !IsRetryable(Combine(ErrorServiceUnavailable(), ErrorServiceUnavailable())) {
+		t.Error("IsRetryable should inspect the members of an aggregate")
+	}
+}
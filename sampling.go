@@ -0,0 +1,23 @@
+package errors
+
+// Sampling records that a sampler kept this error with probability Rate and dropped
+// data (stack traces or reports) for the others. Downstream aggregation multiplies
+// counts by Weight() to extrapolate the true number of occurrences.
+type Sampling struct {
+	Sampled bool    `json:"sampled"`
+	Rate    float64 `json:"rate"`
+}
+
+// WithSampling records a sampling decision on the error and returns the error for chaining.
+// rate is the probability (0, 1] with which the sampler kept the data.
+func (e *Error) WithSampling(sampled bool, rate float64) *Error {
+	return e.update(func(e *Error) { e.Sampling = &Sampling{Sampled: sampled, Rate: rate} })
+}
+
+// Weight returns the number of occurrences this error stands for: 1/Rate when sampled, otherwise 1
+func (s *Sampling) Weight() float64 {
+	if s == nil || !s.Sampled || s.Rate <= 0 || s.Rate >= 1 {
+		return 1
+	}
+	return 1 / s.Rate
+}
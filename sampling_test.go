@@ -0,0 +1,24 @@
+package errors
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestSamplingWeight(t *testing.T) {
+	var none *Sampling
+	if none.Weight() != 1 {
+		t.Error("Unsampled errors should weigh 1")
+	}
+
+	err := ErrorNotFound().WithSampling(true, 0.25)
+	if err.Sampling.Weight() != 4 {
+		t.Errorf("Expected weight 4, got %v", err.Sampling.Weight())
+	}
+
+	data, _ := json.Marshal(err)
+	if !strings.Contains(string(data), `"sampling":{"sampled":true,"rate":0.25}`) {
+		t.Errorf("Sampling decision should be serialized: %s", data)
+	}
+}
@@ -0,0 +1,60 @@
+package errors
+
+// Sentinel is an immutable error value identifying an error type. It is meant for comparisons
+// with errors.Is and as a template for new errors: its fields cannot be modified, so a shared
+// sentinel never picks up a message or violations set by one caller.
+type Sentinel struct {
+	errorType string
+	code      int64
+	message   string
+}
+
+// Predefined sentinels matching the factory functions, e.g. errors.Is(err, ErrNotFound)
+// reports whether err has the type of ErrorNotFound()
+var (
+	ErrBadRequest          = NewSentinel(400, "Bad request", "BAD_REQUEST")
+	ErrUnauthorized        = NewSentinel(401, "Unauthorized", "UNAUTHORIZED")
+	ErrForbidden           = NewSentinel(403, "Forbidden", "FORBIDDEN")
+	ErrNotFound            = NewSentinel(404, "Not found", "NOT_FOUND")
+	ErrConflict            = NewSentinel(409, "Conflict", "CONFLICT")
+	ErrUnprocessableEntity = NewSentinel(422, "Unprocessable Entity", "UNPROCESSABLE_ENTITY")
+	ErrTooManyRequests     = NewSentinel(429, "Too Many Requests", "TOO_MANY_REQUEST")
+	ErrInternalServerError = NewSentinel(500, "Internal Server Error", "INTERNAL_SERVER_ERROR")
+	ErrPanic               = NewSentinel(500, "Panic", "PANIC")
+)
+
+// NewSentinel creates a sentinel with the provided code, message, and error type
+func NewSentinel(code int64, message, errorType string) *Sentinel {
+	return &Sentinel{errorType: errorType, code: code, message: message}
+}
+
+// Type returns the error type of the sentinel
+func (s *Sentinel) Type() string { return s.errorType }
+
+// Code returns the code of errors created from the sentinel
+func (s *Sentinel) Code() int64 { return s.code }
+
+// Error implements the error interface, returning the default message
+func (s *Sentinel) Error() string { return s.message }
+
+// New creates a mutable *Error of the sentinel's type with a stack trace starting at the caller.
+// The result matches the sentinel with errors.Is.
+func (s *Sentinel) New() *Error {
+	return created(&Error{
+		Type:        s.errorType,
+		Code:        s.code,
+		Message:     s.message,
+		StackTraces: captureStackTrace(1),
+	})
+}
+
+// Is reports whether target is a sentinel or an *Error of the same type
+func (s *Sentinel) Is(target error) bool {
+	switch t := target.(type) {
+	case *Sentinel:
+		return t != nil && t.errorType == s.errorType
+	case *Error:
+		return t != nil && t.Type == s.errorType
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestSentinel(t *testing.T) {
+	err := fmt.Errorf("loading user: %w", ErrorNotFound())
+	if !stderrors.Is(err, ErrNotFound) || stderrors.Is(err, ErrConflict) {
+		t.Error("Errors should match the sentinel of their type only")
+	}
+
+	created := ErrNotFound.New()
+	created.Message = "User 42 not found"
+	created.Violations = append(created.Violations, ValidationError{Field: "id"})
+	if ErrNotFound.Error() != "Not found" || ErrNotFound.New().Message != "Not found" || len(ErrNotFound.New().Violations) != 0 {
+		t.Error("Modifying a created error should not affect the sentinel")
+	}
+	if !stderrors.Is(created, ErrNotFound) || !stderrors.Is(ErrNotFound, created) || created.Code != 404 {
+		t.Error("Created errors should match the sentinel both ways")
+	}
+	if len(created.StackTraces) == 0 || !strings.Contains(created.StackTraces[0], "TestSentinel") {
+		t.Error("Stack trace should start at the caller of New")
+	}
+	if stderrors.Is(Combine(ErrorNotFound(), ErrorConflict()), ErrBadRequest) {
+		t.Error("An aggregate should not match the sentinel of its derived type")
+	}
+}
+
+func TestClone(t *testing.T) {
+	original := ErrorNotFound().WithField("user_id", 42)
+	original.Violations = append(original.Violations, ValidationError{Field: "id", Message: "Invalid"})
+
+	clone := original.Clone()
+	clone.Message = "changed"
+	clone.Violations[0].Message = "changed"
+	clone.WithField("user_id", 7)
+	clone.StackTraces[0] = "changed"
+
+	if original.Message != "Not found" || original.Violations[0].Message != "Invalid" || original.Fields["user_id"] != 42 || original.StackTraces[0] == "changed" {
+		t.Errorf("Modifying the clone should not affect the original, got %+v", original)
+	}
+	if !stderrors.Is(clone, original) {
+		t.Error("The clone should keep the type")
+	}
+
+	var nilErr *Error
+	if nilErr.Clone() != nil {
+		t.Error("Clone of nil should be nil")
+	}
+}
@@ -0,0 +1,107 @@
+package errors
+
+import (
+	stderrors "errors"
+	"log"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// ServerLogWriter parses lines written by http.Server.ErrorLog into structured errors.
+// Serve-time panics become PANIC errors carrying the panicking goroutine's stack;
+// any other line becomes an INTERNAL_SERVER_ERROR wrapping the logged text.
+type ServerLogWriter struct {
+	handler func(*Error)
+}
+
+// NewServerLogWriter creates a writer forwarding every parsed error to handler
+func NewServerLogWriter(handler func(*Error)) *ServerLogWriter {
+	return &ServerLogWriter{handler: handler}
+}
+
+// NewServerErrorLog returns a logger suitable for http.Server.ErrorLog that forwards
+// server-internal errors to handler instead of printing them
+func NewServerErrorLog(handler func(*Error)) *log.Logger {
+	return log.New(NewServerLogWriter(handler), "", 0)
+}
+
+// Write implements io.Writer. Each call is expected to hold one log entry, as written by log.Logger.
+func (w *ServerLogWriter) Write(p []byte) (int, error) {
+	if w.handler != nil {
+		w.handler(ParseServerLog(string(p)))
+	}
+	return len(p), nil
+}
+
+// ParseServerLog converts a single http.Server error log entry into an *Error
+func ParseServerLog(entry string) *Error {
+	entry = strings.TrimRight(entry, "\n")
+	first, rest, _ := strings.Cut(entry, "\n")
+
+	// Format: "http: panic serving <remote addr>: <panic value>"
+	if after, ok := strings.CutPrefix(first, "http: panic serving "); ok {
+		remoteAddr, value, _ := strings.Cut(after, ": ")
+
+		e := &Error{
+			Type:        "PANIC",
+			Code:        500,
+			Message:     "Panic",
+			StackTraces: parseGoroutineStack(rest),
+			Err:         stderrors.New(value),
+		}
+		return created(e.WithField("remote_addr", remoteAddr))
+	}
+
+	message := strings.TrimPrefix(first, "http: ")
+	if rest != "" {
+		message += "\n" + rest
+	}
+
+	return created(&Error{
+		Type:    "INTERNAL_SERVER_ERROR",
+		Code:    500,
+		Message: "An internal server error occurred",
+		Err:     stderrors.New(message),
+	})
+}
+
+// parseGoroutineStack converts a goroutine dump, as printed by runtime/debug.Stack,
+// into "file:line function" entries, dropping irrelevant frames like captureStackTrace
+func parseGoroutineStack(dump string) []string {
+	result := make([]string, 0)
+	settings := currentStackSettings()
+	lines := strings.Split(dump, "\n")
+
+	for i := 0; i < len(lines)-1; i++ {
+		function := lines[i]
+		location := lines[i+1]
+		if function == "" || strings.HasPrefix(function, "goroutine ") || strings.HasPrefix(function, "created by ") ||
+			!strings.HasPrefix(location, "\t") {
+			continue
+		}
+		i++
+
+		if idx := strings.LastIndex(function, "("); idx > 0 && strings.HasSuffix(function, ")") {
+			function = function[:idx]
+		}
+		// The runtime prints its panic frame without the package name
+		if function == "panic" {
+			function = "runtime.gopanic"
+		}
+
+		file := strings.TrimSpace(location)
+		if idx := strings.LastIndex(file, " +0x"); idx > 0 {
+			file = file[:idx]
+		}
+		path, lineText, _ := strings.Cut(file, ":")
+		line, _ := strconv.Atoi(lineText)
+
+		frame := runtime.Frame{Function: function, File: path, Line: line}
+		if settings.relevant(frame) {
+			result = append(result, settings.format(frame))
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,77 @@
+package errors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestParseServerLogPanic(t *testing.T) {
+	entry := "http: panic serving 127.0.0.1:5555: boom\n" +
+		"goroutine 7 [running]:\n" +
+		"net/http.(*conn).serve.func1()\n" +
+		"\t/usr/local/go/src/net/http/server.go:1898 +0xbe\n" +
+		"panic({0x6a3e40?, 0x7c6b50?})\n" +
+		"\t/usr/local/go/src/runtime/panic.go:770 +0x132\n" +
+		"main.handler({0x7cbab8?, 0xc0000a0000?}, 0x0?)\n" +
+		"\t/app/main.go:12 +0x25\n" +
+		"created by net/http.(*Server).Serve in goroutine 1\n" +
+		"\t/usr/local/go/src/net/http/server.go:3285 +0x4b4\n"
+
+	err := ParseServerLog(entry)
+	if err.Type != "PANIC" || err.Unwrap().Error() != "boom" {
+		t.Errorf("Unexpected panic error %s: %v", err.Type, err.Unwrap())
+	}
+	if err.Fields["remote_addr"] != "127.0.0.1:5555" {
+		t.Errorf("Unexpected remote address %v", err.Fields["remote_addr"])
+	}
+
+	expected := []string{
+		"/usr/local/go/src/net/http/server.go:1898 net/http.(*conn).serve.func1",
+		"/app/main.go:12 main.handler",
+	}
+	if strings.Join(err.StackTraces, "|") != strings.Join(expected, "|") {
+		t.Errorf("Unexpected stack %v", err.StackTraces)
+	}
+}
+
+func TestParseServerLogOther(t *testing.T) {
+	err := ParseServerLog("http: TLS handshake error from 10.0.0.1:443: EOF\n")
+	if err.Code != 500 || err.Error() != "TLS handshake error from 10.0.0.1:443: EOF" {
+		t.Errorf("Unexpected error %d %q", err.Code, err.Error())
+	}
+}
+
+func TestServerErrorLogCapturesHandlerPanic(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		captured []*Error
+	)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("handler exploded")
+	}))
+	server.Config.ErrorLog = NewServerErrorLog(func(e *Error) {
+		mu.Lock()
+		defer mu.Unlock()
+		captured = append(captured, e)
+	})
+	server.Start()
+	defer server.Close()
+
+	if resp, err := http.Get(server.URL); err == nil {
+		resp.Body.Close()
+	}
+	server.Close()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(captured) != 1 || captured[0].Type != "PANIC" || captured[0].Unwrap().Error() != "handler exploded" {
+		t.Fatalf("Expected one captured panic, got %v", captured)
+	}
+	if len(captured[0].StackTraces) == 0 {
+		t.Error("Captured panic should carry the goroutine stack")
+	}
+}
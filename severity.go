@@ -0,0 +1,99 @@
+package errors
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// Severity ranks how serious an error is, e.g. to choose the log level it is written at.
+// The zero value SeverityDefault derives the severity from the error, see DefaultSeverity.
+type Severity int
+
+const (
+	SeverityDefault Severity = iota
+	SeverityDebug
+	SeverityInfo
+	SeverityWarning
+	SeverityError
+	SeverityCritical
+)
+
+var severityNames = map[Severity]string{
+	SeverityDefault:  "default",
+	SeverityDebug:    "debug",
+	SeverityInfo:     "info",
+	SeverityWarning:  "warning",
+	SeverityError:    "error",
+	SeverityCritical: "critical",
+}
+
+// WithSeverity overrides the severity derived from the error and returns the error for chaining
+func (e *Error) WithSeverity(severity Severity) *Error {
+	return e.update(func(e *Error) { e.Severity = severity })
+}
+
+// LogSeverity returns the severity set with WithSeverity, or DefaultSeverity(e)
+func (e *Error) LogSeverity() Severity {
+	if severity := e.snapshot().Severity; severity != SeverityDefault {
+		return severity
+	}
+	return DefaultSeverity(e)
+}
+
+// DefaultSeverity derives a severity from the error: panics are critical, server errors are
+// errors, rate limiting is a warning and other client errors are informational
+func DefaultSeverity(e *Error) Severity {
+	if e.Type == "PANIC" {
+		return SeverityCritical
+	}
+
+	switch status := HTTPStatus(e); {
+	case status >= 500:
+		return SeverityError
+	case status == 429:
+		return SeverityWarning
+	case status >= 400:
+		return SeverityInfo
+	}
+	return SeverityError
+}
+
+// String returns the lower-case name of the severity
+func (s Severity) String() string {
+	if name, ok := severityNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("severity(%d)", int(s))
+}
+
+// Level returns the slog level for the severity; critical is one step above slog.LevelError
+func (s Severity) Level() slog.Level {
+	switch s {
+	case SeverityDebug:
+		return slog.LevelDebug
+	case SeverityInfo:
+		return slog.LevelInfo
+	case SeverityWarning:
+		return slog.LevelWarn
+	case SeverityCritical:
+		return slog.LevelError + 4
+	}
+	return slog.LevelError
+}
+
+// MarshalText encodes the severity as its name
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText decodes a severity name
+func (s *Severity) UnmarshalText(text []byte) error {
+	for severity, name := range severityNames {
+		if strings.EqualFold(name, string(text)) {
+			*s = severity
+			return nil
+		}
+	}
+	return fmt.Errorf("errors: unknown severity %q", text)
+}
@@ -0,0 +1,81 @@
+package errors
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestDefaultSeverity(t *testing.T) {
+	cases := map[*Error]Severity{
+		ErrorPanic():               SeverityCritical,
+		ErrorInternalServerError(): SeverityError,
+		ErrorTooManyRequests():     SeverityWarning,
+		ErrorNotFound():            SeverityInfo,
+		New(10423, "Insufficient funds", "INSUFFICIENT_FUNDS"): SeverityError,
+	}
+	for e, want := range cases {
+		if got := e.LogSeverity(); got != want {
+			t.Errorf("%s: expected %s, got %s", e.Type, want, got)
+		}
+	}
+
+	if got := ErrorNotFound().WithSeverity(SeverityDebug).LogSeverity(); got != SeverityDebug {
+		t.Errorf("WithSeverity should override the default, got %s", got)
+	}
+	if SeverityCritical.Level() <= slog.LevelError || SeverityWarning.Level() != slog.LevelWarn {
+		t.Error("Unexpected slog levels")
+	}
+}
+
+func TestSeverityJSON(t *testing.T) {
+	data, _ := json.Marshal(ErrorConflict().WithSeverity(SeverityWarning))
+	if !strings.Contains(string(data), `"severity":"warning"`) {
+		t.Errorf("Severity should be encoded by name, got %s", data)
+	}
+	var decoded Error
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded.Severity != SeverityWarning {
+		t.Errorf("Unexpected decoded severity %s (%v)", decoded.Severity, err)
+	}
+
+	data, _ = json.Marshal(ErrorConflict())
+	if strings.Contains(string(data), `"severity":`) {
+		t.Errorf("Default severity should be omitted, got %s", data)
+	}
+	decoded = Error{}
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded.Severity != SeverityDefault {
+		t.Errorf("Unexpected decoded severity %s (%v)", decoded.Severity, err)
+	}
+	var s Severity
+	if err := s.UnmarshalText([]byte("Critical")); err != nil || s != SeverityCritical {
+		t.Errorf("Names should decode case-insensitively, got %s (%v)", s, err)
+	}
+	if err := s.UnmarshalText([]byte("fatal")); err == nil {
+		t.Error("Unknown names should fail")
+	}
+}
+
+func TestLog(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	Log(context.Background(), logger, ErrorNotFound().WithField("user_id", 42))
+	Log(context.Background(), logger, ErrorPanic())
+	Log(context.Background(), logger, fmt.Errorf("plain"))
+	Log(context.Background(), logger, nil)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 log lines, got %d: %s", len(lines), buf.String())
+	}
+	if !strings.Contains(lines[0], `"level":"INFO"`) || !strings.Contains(lines[0], `"error":{"type":"NOT_FOUND"`) || !strings.Contains(lines[0], `"user_id":42`) {
+		t.Errorf("Unexpected log line %s", lines[0])
+	}
+	if !strings.Contains(lines[1], `"level":"ERROR+4"`) || !strings.Contains(lines[2], `"level":"ERROR"`) {
+		t.Errorf("Unexpected levels %s / %s", lines[1], lines[2])
+	}
+}
@@ -0,0 +1,141 @@
+package errors
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	// ShutdownResult is the outcome of closing one subsystem
+	ShutdownResult struct {
+		Name     string
+		Duration time.Duration
+		Err      error
+	}
+
+	// ShutdownCollector gathers the results of closing each subsystem during service shutdown
+	// and produces one aggregated report. It is safe for concurrent use.
+	ShutdownCollector struct {
+		timeout time.Duration
+		mu      sync.Mutex
+		results []ShutdownResult
+	}
+)
+
+// NewShutdownCollector creates a collector that gives each subsystem at most timeout to close.
+// A zero timeout waits indefinitely.
+func NewShutdownCollector(timeout time.Duration) *ShutdownCollector {
+	return &ShutdownCollector{timeout: timeout}
+}
+
+// Close runs closeFn for the named subsystem and records its result.
+// If closeFn does not return within the timeout, a timeout error is recorded and Close returns;
+// closeFn keeps running in the background.
+func (c *ShutdownCollector) Close(name string, closeFn func() error) {
+	c.CloseContext(context.Background(), name, func(context.Context) error {
+		return closeFn()
+	})
+}
+
+// CloseContext is like Close but passes closeFn a context cancelled when the timeout expires
+func (c *ShutdownCollector) CloseContext(ctx context.Context, name string, closeFn func(ctx context.Context) error) {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- closeFn(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = fmt.Errorf("timed out after %s: %w", c.timeout, ctx.Err())
+	}
+
+	c.Add(name, time.Since(start), err)
+}
+
+// Add records the result of a subsystem closed outside the collector
+func (c *ShutdownCollector) Add(name string, duration time.Duration, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.results = append(c.results, ShutdownResult{Name: name, Duration: duration, Err: err})
+}
+
+// Results returns the recorded results in the order they completed
+func (c *ShutdownCollector) Results() []ShutdownResult {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]ShutdownResult(nil), c.results...)
+}
+
+// Err returns the failures combined into one error, each tagged with a "subsystem" field,
+// or nil when every subsystem closed cleanly
+func (c *ShutdownCollector) Err() *Error {
+	failures := make([]error, 0)
+	for _, result := range c.Results() {
+		if result.Err == nil {
+			continue
+		}
+
+		e := &Error{
+			Type:    "SHUTDOWN_FAILED",
+			Code:    500,
+			Message: fmt.Sprintf("Failed to close %s", result.Name),
+			Err:     result.Err,
+		}
+		failures = append(failures, createdFrom(result.Err, e.WithField("subsystem", result.Name)))
+	}
+
+	return Combine(failures...)
+}
+
+// ExitCode returns 0 when every subsystem closed cleanly and 1 otherwise
+func (c *ShutdownCollector) ExitCode() int {
+	for _, result := range c.Results() {
+		if result.Err != nil {
+			return 1
+		}
+	}
+	return 0
+}
+
+// Report renders every result as one multi-line block suitable for a final log entry
+func (c *ShutdownCollector) Report() string {
+	results := c.Results()
+
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+		}
+	}
+
+	var b strings.Builder
+	if failed == 0 {
+		fmt.Fprintf(&b, "shutdown completed: %d subsystem(s) closed cleanly\n", len(results))
+	} else {
+		fmt.Fprintf(&b, "shutdown completed with %d error(s) in %d subsystem(s):\n", failed, len(results))
+	}
+
+	for _, result := range results {
+		status := "ok"
+		if result.Err != nil {
+			status = "FAILED: " + result.Err.Error()
+		}
+		fmt.Fprintf(&b, "  - %s (%s): %s\n", result.Name, result.Duration.Round(time.Millisecond), status)
+	}
+
+	return b.String()
+}
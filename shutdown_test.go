@@ -0,0 +1,53 @@
+package errors
+
+import (
+	"context"
+	stderrors "errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestShutdownCollector(t *testing.T) {
+	dbErr := stderrors.New("connection reset")
+
+	c := NewShutdownCollector(20 * time.Millisecond)
+	c.Close("http", func() error { return nil })
+	c.Close("database", func() error { return dbErr })
+	c.CloseContext(context.Background(), "queue", func(ctx context.Context) error {
+		<-ctx.Done()
+		time.Sleep(10 * time.Millisecond)
+		return nil
+	})
+
+	if c.ExitCode() != 1 {
+		t.Error("Expected exit code 1")
+	}
+
+	err := c.Err()
+	if err == nil || len(err.Errors()) != 2 {
+		t.Fatalf("Expected 2 failures, got %v", err)
+	}
+	if !stderrors.Is(err, dbErr) || !stderrors.Is(err, context.DeadlineExceeded) {
+		t.Error("Aggregate should match the subsystem errors")
+	}
+
+	report := c.Report()
+	for _, want := range []string{"2 error(s) in 3 subsystem(s)", "http", "database", "connection reset", "queue", "timed out"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("Report should contain %q:\n%s", want, report)
+		}
+	}
+}
+
+func TestShutdownCollectorClean(t *testing.T) {
+	c := NewShutdownCollector(0)
+	c.Close("cache", func() error { return nil })
+
+	if c.ExitCode() != 0 || c.Err() != nil {
+		t.Error("Clean shutdown should have no error")
+	}
+	if !strings.Contains(c.Report(), "1 subsystem(s) closed cleanly") {
+		t.Errorf("Unexpected report %q", c.Report())
+	}
+}
@@ -0,0 +1,85 @@
+package errors
+
+import (
+	"context"
+	stderrors "errors"
+	"log/slog"
+)
+
+// LogValue implements slog.LogValuer, logging the error as a group with its type, code,
+// message, severity, request and trace IDs, fields, cause, source, timestamp, elapsed time and goroutine
+func (e *Error) LogValue() slog.Value {
+	if e == nil {
+		return slog.Value{}
+	}
+
+	s := e.snapshot()
+	attrs := []slog.Attr{
+		slog.String("type", s.Type),
+		slog.Int64("code", s.Code),
+		slog.String("message", s.Message),
+		slog.String("severity", s.LogSeverity().String()),
+	}
+	if s.ID != "" {
+		attrs = append(attrs, slog.String("id", s.ID))
+	}
+	if s.RequestID != "" {
+		attrs = append(attrs, slog.String("request_id", s.RequestID))
+	}
+	if s.TraceID != "" {
+		attrs = append(attrs, slog.String("trace_id", s.TraceID))
+	}
+	if s.Op != "" {
+		attrs = append(attrs, slog.String("op", s.Op))
+	}
+	if s.InternalMessage != "" {
+		attrs = append(attrs, slog.String("internal_message", s.InternalMessage))
+	}
+	if len(s.Notes) > 0 {
+		attrs = append(attrs, slog.Any("notes", s.Notes))
+	}
+	if len(s.Fields) > 0 {
+		fields := make([]any, 0, len(s.Fields))
+		for key, value := range s.Fields {
+			fields = append(fields, slog.Any(key, value))
+		}
+		attrs = append(attrs, slog.Group("fields", fields...))
+	}
+	if s.Err != nil {
+		attrs = append(attrs, slog.String("cause", s.Err.Error()))
+	}
+	if len(s.StackTraces) > 0 {
+		attrs = append(attrs, slog.String("source", s.StackTraces[0]))
+	}
+	if !s.Timestamp.IsZero() {
+		attrs = append(attrs, slog.Time("timestamp", s.Timestamp))
+	}
+	if s.Elapsed > 0 {
+		attrs = append(attrs, slog.Duration("elapsed", s.Elapsed))
+	}
+	if s.Goroutine != nil {
+		goroutine := []any{slog.Uint64("id", s.Goroutine.ID)}
+		for key, value := range s.Goroutine.Labels {
+			goroutine = append(goroutine, slog.String(key, value))
+		}
+		attrs = append(attrs, slog.Group("goroutine", goroutine...))
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// Log writes err to logger at the level of its severity, under the "error" key.
+// Errors that are not *Error are logged at error level. A nil error is not logged.
+func Log(ctx context.Context, logger *slog.Logger, err error) {
+	if err == nil {
+		return
+	}
+
+	var e *Error
+	if !stderrors.As(err, &e) || e == nil {
+		logger.Log(ctx, slog.LevelError, err.Error(), slog.String("error", err.Error()))
+		return
+	}
+
+	logger.Log(ctx, e.LogSeverity().Level(), e.Error(), slog.Any("error", e))
+}
@@ -0,0 +1,78 @@
+package errors
+
+import (
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+// sourceContextFrames is how many of the innermost frames %+v shows source for
+const sourceContextFrames = 3
+
+type (
+	// SourceLine is a numbered line of source code
+	SourceLine struct {
+		Number int    `json:"number"`
+		Text   string `json:"text"`
+	}
+
+	// SourceFrame is a stack frame with the source lines around it
+	SourceFrame struct {
+		Frame
+		Lines []SourceLine `json:"lines,omitempty"`
+	}
+)
+
+// sourceContext is the number of lines around each frame %+v shows, see SetSourceContext
+var sourceContext atomic.Int64
+
+// SetSourceContext makes %+v show n lines of source before and after the innermost frames of
+// stack traces, for rich local debugging output, e.g. in development builds. The files are read
+// when the error is formatted, so it only helps where the source is available. 0, the default,
+// turns it off.
+func SetSourceContext(n int) {
+	sourceContext.Store(int64(max(n, 0)))
+}
+
+// SourceFrames returns the top innermost frames of the stack trace with up to context lines of
+// source before and after each. Frames whose file cannot be read, e.g. in a binary deployed
+// without its source or with paths trimmed by TrimModulePrefix relative to another directory,
+// have no lines. Entries not in the "file:line function" format are skipped.
+func (e *Error) SourceFrames(context, top int) []SourceFrame {
+	if e == nil {
+		return nil
+	}
+	return sourceFrames(e.snapshot().StackTraces, context, top)
+}
+
+// sourceFrames returns the top frames of stack with their source, reading each file once
+func sourceFrames(stack []string, context, top int) []SourceFrame {
+	files := make(map[string][]string)
+	frames := make([]SourceFrame, 0, min(len(stack), top))
+	for _, entry := range stack {
+		if len(frames) == top {
+			break
+		}
+		frame := parseFrame(entry)
+		if frame == nil {
+			continue
+		}
+
+		lines, ok := files[frame.File]
+		if !ok {
+			if content, err := os.ReadFile(frame.File); err == nil {
+				lines = strings.Split(string(content), "\n")
+			}
+			files[frame.File] = lines
+		}
+
+		sf := SourceFrame{Frame: *frame}
+		if frame.Line >= 1 && frame.Line <= len(lines) {
+			for n := max(frame.Line-context, 1); n <= min(frame.Line+context, len(lines)); n++ {
+				sf.Lines = append(sf.Lines, SourceLine{Number: n, Text: strings.TrimRight(lines[n-1], "\r")})
+			}
+		}
+		frames = append(frames, sf)
+	}
+	return frames
+}
@@ -0,0 +1,50 @@
+package errors
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestSourceFrames(t *testing.T) {
+	e := ErrorNotFound() // the creation site
+	frames := e.SourceFrames(1, 1)
+	if len(frames) != 1 {
+		t.Fatalf("Expected the innermost frame, got %d", len(frames))
+	}
+
+	top := frames[0]
+	if top.Function != "github.com/andryhardiyanto/go-errors.TestSourceFrames" || len(top.Lines) != 3 {
+		t.Fatalf("Expected three lines around the creation site, got %+v", top)
+	}
+	if top.Lines[1].Number != top.Line || !strings.Contains(top.Lines[1].Text, "// the creation site") {
+		t.Errorf("The middle line should be the frame's, got %+v", top.Lines[1])
+	}
+	if top.Lines[0].Number != top.Line-1 || top.Lines[2].Number != top.Line+1 {
+		t.Errorf("Unexpected line numbers %+v", top.Lines)
+	}
+
+	missing := &Error{StackTraces: []string{"<foreign>", "missing/file.go:3 pkg.Fn"}}
+	if frames := missing.SourceFrames(2, 5); len(frames) != 1 || frames[0].Lines != nil {
+		t.Errorf("Unreadable files should have no lines and foreign entries be skipped, got %+v", frames)
+	}
+	if (*Error)(nil).SourceFrames(2, 5) != nil {
+		t.Error("A nil error should have no frames")
+	}
+}
+
+func TestFormatSourceContext(t *testing.T) {
+	t.Cleanup(func() { SetSourceContext(0) })
+
+	e := ErrorNotFound() // the creation site
+	if strings.Contains(fmt.Sprintf("%+v", e), " | ") {
+		t.Error("Source should not be shown by default")
+	}
+
+	SetSourceContext(1)
+	out := fmt.Sprintf("%+v", e)
+	line := fmt.Sprintf("> %5d | \te := ErrorNotFound() // the creation site", e.SourceFrames(0, 1)[0].Line)
+	if !strings.Contains(out, line) {
+		t.Errorf("The creation line should be marked in\n%s", out)
+	}
+}
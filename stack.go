@@ -0,0 +1,172 @@
+package errors
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// FrameFilter reports whether a stack frame should be kept in captured stack traces
+type FrameFilter func(frame runtime.Frame) bool
+
+// DefaultMaxStackFrames is the number of frames a stack trace holds unless changed with SetMaxStackFrames
+const DefaultMaxStackFrames = 32
+
+// CaptureMode selects how much of the stack constructors capture
+type CaptureMode int
+
+const (
+	// CaptureStack captures the full stack trace, up to the frame limit
+	CaptureStack CaptureMode = iota
+	// CaptureCaller captures only the creation site, see Error.Source
+	CaptureCaller
+)
+
+// stackSettings holds the registered frame filters, path prefixes, frame limit, capture mode and sampler
+type stackSettings struct {
+	filters      []FrameFilter
+	trimPrefixes []string
+	maxFrames    int
+	mode         CaptureMode
+	sampler      *StackSampler
+}
+
+var (
+	stackMu  sync.RWMutex
+	stackCfg = stackSettings{maxFrames: DefaultMaxStackFrames}
+)
+
+// SkipVendor drops frames from files under a vendor directory
+func SkipVendor(frame runtime.Frame) bool {
+	return !strings.Contains(frame.File, "/vendor/")
+}
+
+// SkipPackages returns a filter dropping frames whose function belongs to a package starting with
+// one of prefixes, e.g. SkipPackages("github.com/acme/middleware", "net/http.")
+func SkipPackages(prefixes ...string) FrameFilter {
+	return func(frame runtime.Frame) bool {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(frame.Function, prefix) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// RegisterFrameFilter adds filters applied to every stack trace captured afterwards.
+// A frame is kept only when every filter keeps it. Runtime and testing frames are always dropped.
+func RegisterFrameFilter(filters ...FrameFilter) {
+	stackMu.Lock()
+	defer stackMu.Unlock()
+	for _, filter := range filters {
+		if filter != nil {
+			stackCfg.filters = append(stackCfg.filters, filter)
+		}
+	}
+}
+
+// ResetFrameFilters removes every filter added with RegisterFrameFilter
+func ResetFrameFilters() {
+	stackMu.Lock()
+	defer stackMu.Unlock()
+	stackCfg.filters = nil
+}
+
+// TrimModulePrefix sets path prefixes removed from the file names of captured frames, e.g. the
+// repository root or GOPATH/pkg/mod, so traces show repository-relative paths. The first matching
+// prefix is removed. Calling it without prefixes restores absolute paths.
+func TrimModulePrefix(prefixes ...string) {
+	trimmed := make([]string, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		if prefix != "" {
+			trimmed = append(trimmed, strings.TrimSuffix(prefix, "/")+"/")
+		}
+	}
+
+	stackMu.Lock()
+	defer stackMu.Unlock()
+	stackCfg.trimPrefixes = trimmed
+}
+
+// SetMaxStackFrames sets how many frames captured stack traces keep at most.
+// A value below 1 restores DefaultMaxStackFrames.
+func SetMaxStackFrames(n int) {
+	if n < 1 {
+		n = DefaultMaxStackFrames
+	}
+
+	stackMu.Lock()
+	defer stackMu.Unlock()
+	stackCfg.maxFrames = n
+}
+
+// SetCaptureMode sets how much of the stack errors created afterwards capture. CaptureCaller
+// records just the creation site, for services that find full stack traces too heavy; the
+// fingerprint, which hashes that frame, is unchanged.
+func SetCaptureMode(mode CaptureMode) {
+	stackMu.Lock()
+	defer stackMu.Unlock()
+	stackCfg.mode = mode
+}
+
+// Source returns the site the error was created at, as "file:line function", or "" when it
+// has no stack trace
+func (e *Error) Source() string {
+	if e == nil {
+		return ""
+	}
+	if stack := e.snapshot().StackTraces; len(stack) > 0 {
+		return stack[0]
+	}
+	return ""
+}
+
+// existingStackTrace returns the stack trace of the first *Error in err's chain that has one, or nil.
+// The result has no spare capacity, so appending to it never writes to the original.
+func existingStackTrace(err error) []string {
+	for _, inner := range causePath(err) {
+		if e, ok := inner.(*Error); ok && e != nil {
+			if stack := e.snapshot().StackTraces; len(stack) > 0 {
+				return stack[:len(stack):len(stack)]
+			}
+		}
+	}
+	return nil
+}
+
+// currentStackSettings returns the settings in effect. The slices are never modified in place,
+// so the copy can be used without holding stackMu.
+func currentStackSettings() stackSettings {
+	stackMu.RLock()
+	defer stackMu.RUnlock()
+	return stackCfg
+}
+
+// relevant reports whether frame is kept: runtime and testing frames are skipped, then every
+// registered filter must keep the frame
+func (s stackSettings) relevant(frame runtime.Frame) bool {
+	if strings.HasPrefix(frame.Function, "runtime.") || strings.HasPrefix(frame.Function, "testing.") {
+		return false
+	}
+
+	for _, filter := range s.filters {
+		if !filter(frame) {
+			return false
+		}
+	}
+	return true
+}
+
+// format renders frame as "file:line function", trimming the configured path prefix
+func (s stackSettings) format(frame runtime.Frame) string {
+	file := frame.File
+	for _, prefix := range s.trimPrefixes {
+		if trimmed, ok := strings.CutPrefix(file, prefix); ok {
+			file = trimmed
+			break
+		}
+	}
+	return file + ":" + strconv.Itoa(frame.Line) + " " + frame.Function
+}
@@ -0,0 +1,116 @@
+package errors
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestFrameFilters(t *testing.T) {
+	t.Cleanup(ResetFrameFilters)
+
+	RegisterFrameFilter(SkipPackages("github.com/andryhardiyanto/go-errors.TestFrameFilters"))
+	err := ErrorNotFound()
+	for _, frame := range err.StackTraces {
+		if strings.Contains(frame, "TestFrameFilters") {
+			t.Errorf("Filtered frames should be dropped, got %v", err.StackTraces)
+		}
+	}
+
+	ResetFrameFilters()
+	if err := ErrorNotFound(); !strings.Contains(err.StackTraces[0], "TestFrameFilters") {
+		t.Errorf("Reset should restore the default filter, got %v", err.StackTraces)
+	}
+}
+
+func TestSkipVendor(t *testing.T) {
+	if SkipVendor(runtime.Frame{File: "/src/app/vendor/github.com/lib/pq/conn.go"}) {
+		t.Error("Vendored frames should be dropped")
+	}
+	if !SkipVendor(runtime.Frame{File: "/src/app/handler.go"}) {
+		t.Error("Other frames should be kept")
+	}
+}
+
+func TestTrimModulePrefix(t *testing.T) {
+	t.Cleanup(func() { TrimModulePrefix() })
+
+	dir, _ := os.Getwd()
+	TrimModulePrefix("/does/not/match", dir)
+
+	err := ErrorNotFound()
+	if !strings.HasPrefix(err.StackTraces[0], "stack_test.go:") {
+		t.Errorf("Expected a repository-relative path, got %q", err.StackTraces[0])
+	}
+
+	TrimModulePrefix()
+	if err := ErrorNotFound(); !filepath.IsAbs(strings.SplitN(err.StackTraces[0], ":", 2)[0]) {
+		t.Errorf("Expected an absolute path after reset, got %q", err.StackTraces[0])
+	}
+}
+
+func TestWrapReusesStackTrace(t *testing.T) {
+	inner := ErrorNotFound()
+	wrapped := Wrap(fmt.Errorf("loading user: %w", Wrap(inner)))
+
+	if len(wrapped.StackTraces) != len(inner.StackTraces) || &wrapped.StackTraces[0] != &inner.StackTraces[0] {
+		t.Errorf("Wrap should reuse the inner stack trace, got %v", wrapped.StackTraces)
+	}
+
+	wrapped.StackTraces = append(wrapped.StackTraces, "extra")
+	if len(inner.StackTraces) > 0 && inner.StackTraces[len(inner.StackTraces)-1] == "extra" {
+		t.Error("Appending to a reused trace should not change the original")
+	}
+
+	if plain := Wrap(fmt.Errorf("boom")); len(plain.StackTraces) == 0 || !strings.Contains(plain.StackTraces[0], "TestWrapReusesStackTrace") {
+		t.Errorf("Errors without a trace should capture one, got %v", plain.StackTraces)
+	}
+}
+
+func TestSetMaxStackFrames(t *testing.T) {
+	t.Cleanup(func() { SetMaxStackFrames(0) })
+
+	SetMaxStackFrames(1)
+	if err := ErrorNotFound(); len(err.StackTraces) != 1 {
+		t.Errorf("Expected one frame, got %v", err.StackTraces)
+	}
+
+	SetMaxStackFrames(0)
+	if got := currentStackSettings().maxFrames; got != DefaultMaxStackFrames {
+		t.Errorf("Expected the default limit, got %d", got)
+	}
+}
+
+func TestCaptureCaller(t *testing.T) {
+	t.Cleanup(func() { SetCaptureMode(CaptureStack) })
+
+	create := func() *Error { return ErrorNotFound() }
+	full := create()
+	SetCaptureMode(CaptureCaller)
+	e := create()
+	if len(e.StackTraces) != 1 || !strings.Contains(e.StackTraces[0], "TestCaptureCaller") {
+		t.Fatalf("Only the creation site should be captured, got %v", e.StackTraces)
+	}
+	if e.Fingerprint() != full.Fingerprint() {
+		t.Error("The fingerprint should not depend on the capture mode")
+	}
+
+	if e.Source() != e.StackTraces[0] || full.Source() != full.StackTraces[0] {
+		t.Errorf("Source should be the creation site, got %q", e.Source())
+	}
+	var source string
+	for _, attr := range e.LogValue().Group() {
+		if attr.Key == "source" {
+			source = attr.Value.String()
+		}
+	}
+	if source != e.Source() {
+		t.Errorf("Logs should include the source, got %q", source)
+	}
+	if (&Error{}).Source() != "" || (*Error)(nil).Source() != "" {
+		t.Error("Errors without a stack should have no source")
+	}
+}
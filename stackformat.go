@@ -0,0 +1,132 @@
+package errors
+
+import (
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// StackFormat selects how stack traces are rendered by StackTrace and MarshalJSON
+type StackFormat int
+
+const (
+	// StackLines is a list of "file:line function" entries, innermost first, the default
+	StackLines StackFormat = iota
+	// StackText is multi-line text like a Go panic trace, the function on one line and its
+	// location indented on the next
+	StackText
+	// StackFrames is a list of structured frames, see Frame
+	StackFrames
+	// StackCompact is a single line of "function:line" frames, outermost first and separated by
+	// ';', in the folded format read by pprof and flame graph tools
+	StackCompact
+)
+
+// jsonStackFormat is the StackFormat MarshalJSON renders stack_traces in
+var jsonStackFormat atomic.Int64
+
+// SetStackFormat sets the format MarshalJSON renders stack_traces in, so the JSON form fits the
+// log backend reading it: a list of strings for StackLines, a string for StackText and
+// StackCompact, and a list of objects for StackFrames. UnmarshalJSON reads every format back.
+func SetStackFormat(format StackFormat) {
+	jsonStackFormat.Store(int64(format))
+}
+
+// StackTrace renders the stack trace in format: the entries on separate lines for StackLines,
+// and a JSON array for StackFrames. Entries not in the "file:line function" format, e.g. from
+// a decoded foreign error, are rendered as they are, and skipped by StackFrames.
+func (e *Error) StackTrace(format StackFormat) string {
+	if e == nil {
+		return ""
+	}
+	stack := e.snapshot().StackTraces
+	if format == StackFrames {
+		b, _ := json.Marshal(stackValue(stack, format))
+		return string(b)
+	}
+	if format == StackLines {
+		return strings.Join(stack, "\n")
+	}
+	return stackValue(stack, format).(string)
+}
+
+// stackValue returns the JSON value of stack rendered in format
+func stackValue(stack []string, format StackFormat) any {
+	switch format {
+	case StackText:
+		lines := make([]string, 0, len(stack))
+		for _, entry := range stack {
+			if frame := parseFrame(entry); frame != nil {
+				entry = frame.Function + "\n\t" + frame.File + ":" + strconv.Itoa(frame.Line)
+			}
+			lines = append(lines, entry)
+		}
+		return strings.Join(lines, "\n")
+	case StackFrames:
+		frames := make([]Frame, 0, len(stack))
+		for _, entry := range stack {
+			if frame := parseFrame(entry); frame != nil {
+				frames = append(frames, *frame)
+			}
+		}
+		return frames
+	case StackCompact:
+		folded := make([]string, 0, len(stack))
+		for _, entry := range slices.Backward(stack) {
+			if frame := parseFrame(entry); frame != nil {
+				entry = frame.Function + ":" + strconv.Itoa(frame.Line)
+			}
+			folded = append(folded, entry)
+		}
+		return strings.Join(folded, ";")
+	default:
+		if stack == nil {
+			return []string{}
+		}
+		return stack
+	}
+}
+
+// parseStackJSON decodes stack_traces in any StackFormat back into entries. Frames and text
+// come back as "file:line function" entries; compact stacks, which lack file names, as their
+// "function:line" frames, innermost first.
+func parseStackJSON(raw json.RawMessage) ([]string, error) {
+	var stack []string
+	if err := json.Unmarshal(raw, &stack); err == nil {
+		return stack, nil
+	}
+
+	var frames []Frame
+	if err := json.Unmarshal(raw, &frames); err == nil {
+		stack = make([]string, 0, len(frames))
+		for _, frame := range frames {
+			stack = append(stack, frame.File+":"+strconv.Itoa(frame.Line)+" "+frame.Function)
+		}
+		return stack, nil
+	}
+
+	var text string
+	if err := json.Unmarshal(raw, &text); err != nil {
+		return nil, err
+	}
+	if text == "" {
+		return nil, nil
+	}
+	if !strings.Contains(text, "\n") {
+		stack = strings.Split(text, ";")
+		slices.Reverse(stack)
+		return stack, nil
+	}
+	lines := strings.Split(text, "\n")
+	for i := 0; i < len(lines); i++ {
+		if i+1 < len(lines) && strings.HasPrefix(lines[i+1], "\t") {
+			stack = append(stack, strings.TrimPrefix(lines[i+1], "\t")+" "+lines[i])
+			i++
+			continue
+		}
+		stack = append(stack, lines[i])
+	}
+	return stack, nil
+}
@@ -0,0 +1,76 @@
+package errors
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestStackTraceFormats(t *testing.T) {
+	e := &Error{Type: "X", StackTraces: []string{
+		"store/user.go:42 example.com/store.FindUser",
+		"api/user.go:10 example.com/api.GetUser",
+		"<foreign frame>",
+	}}
+
+	tests := []struct {
+		format StackFormat
+		want   string
+	}{
+		{StackLines, "store/user.go:42 example.com/store.FindUser\napi/user.go:10 example.com/api.GetUser\n<foreign frame>"},
+		{StackText, "example.com/store.FindUser\n\tstore/user.go:42\nexample.com/api.GetUser\n\tapi/user.go:10\n<foreign frame>"},
+		{StackFrames, `[{"function":"example.com/store.FindUser","file":"store/user.go","line":42},{"function":"example.com/api.GetUser","file":"api/user.go","line":10}]`},
+		{StackCompact, "<foreign frame>;example.com/api.GetUser:10;example.com/store.FindUser:42"},
+	}
+	for _, tt := range tests {
+		if got := e.StackTrace(tt.format); got != tt.want {
+			t.Errorf("Format %d: expected\n%s\ngot\n%s", tt.format, tt.want, got)
+		}
+	}
+	if (*Error)(nil).StackTrace(StackText) != "" {
+		t.Error("A nil error should render no stack")
+	}
+}
+
+func TestSetStackFormat(t *testing.T) {
+	t.Cleanup(func() { SetStackFormat(StackLines) })
+
+	stack := []string{"store/user.go:42 example.com/store.FindUser", "api/user.go:10 example.com/api.GetUser"}
+	tests := []struct {
+		format StackFormat
+		want   string
+		back   []string
+	}{
+		{StackLines, `["store/user.go:42 example.com/store.FindUser","api/user.go:10 example.com/api.GetUser"]`, stack},
+		{StackText, `"example.com/store.FindUser\n\tstore/user.go:42\nexample.com/api.GetUser\n\tapi/user.go:10"`, stack},
+		{StackFrames, `[{"function":"example.com/store.FindUser","file":"store/user.go","line":42},{"function":"example.com/api.GetUser","file":"api/user.go","line":10}]`, stack},
+		{StackCompact, `"example.com/api.GetUser:10;example.com/store.FindUser:42"`, []string{"example.com/store.FindUser:42", "example.com/api.GetUser:10"}},
+	}
+	for _, tt := range tests {
+		SetStackFormat(tt.format)
+		b, err := json.Marshal(&Error{Type: "X", StackTraces: stack})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var doc map[string]json.RawMessage
+		if err := json.Unmarshal(b, &doc); err != nil {
+			t.Fatal(err)
+		}
+		if string(doc["stack_traces"]) != tt.want {
+			t.Errorf("Format %d: expected %s, got %s", tt.format, tt.want, doc["stack_traces"])
+		}
+
+		var decoded Error
+		if err := json.Unmarshal(b, &decoded); err != nil {
+			t.Fatalf("Format %d: %v", tt.format, err)
+		}
+		if decoded.Type != "X" || !reflect.DeepEqual(decoded.StackTraces, tt.back) {
+			t.Errorf("Format %d: expected %v back, got %v", tt.format, tt.back, decoded.StackTraces)
+		}
+	}
+
+	SetStackFormat(StackText)
+	if b, _ := json.Marshal(&Error{Type: "X"}); string(b) != `{"type":"X","code":0,"message":"","violations":[],"stack_traces":""}` {
+		t.Errorf("Absent stacks should render empty, got %s", b)
+	}
+}
@@ -0,0 +1,86 @@
+package errors
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultStackSampleWindow is the window of NewStackSampler for a non-positive window
+const DefaultStackSampleWindow = time.Minute
+
+type (
+	// StackSampler limits stack trace capture on hot error paths. Errors created at the same call
+	// site are counted per window; the first of each window and then one in every N get a full
+	// stack trace, the others only their creation frame. Fingerprints, which hash that frame,
+	// stay the same, so sampled errors still group with their full-trace siblings. It is safe
+	// for concurrent use.
+	StackSampler struct {
+		mu        sync.Mutex
+		every     int
+		window    time.Duration
+		sites     map[uintptr]*sampledSite
+		lastSweep time.Time
+		now       func() time.Time
+	}
+
+	// sampledSite counts the errors created at a call site since the start of its window
+	sampledSite struct {
+		start time.Time
+		count int
+	}
+)
+
+// NewStackSampler returns a sampler capturing the full stack for 1 in every errors created at
+// the same call site per window. every below 2 captures every stack, and a non-positive window
+// uses DefaultStackSampleWindow.
+func NewStackSampler(every int, window time.Duration) *StackSampler {
+	if every < 1 {
+		every = 1
+	}
+	if window <= 0 {
+		window = DefaultStackSampleWindow
+	}
+	return &StackSampler{every: every, window: window, sites: make(map[uintptr]*sampledSite), now: time.Now}
+}
+
+// SetStackSampler installs s for every stack trace captured afterwards. Passing nil captures
+// every stack trace in full again.
+func SetStackSampler(s *StackSampler) {
+	stackMu.Lock()
+	defer stackMu.Unlock()
+	stackCfg.sampler = s
+}
+
+// sample records an error created at the call site pc and reports whether it gets a full stack trace
+func (s *StackSampler) sample(pc uintptr) bool {
+	if s.every == 1 {
+		return true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	site, ok := s.sites[pc]
+	if !ok || now.Sub(site.start) >= s.window {
+		site = &sampledSite{start: now}
+		s.sites[pc] = site
+	}
+	site.count++
+	return (site.count-1)%s.every == 0
+}
+
+// sweep drops the sites of expired windows, at most once per window
+func (s *StackSampler) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < s.window {
+		return
+	}
+	s.lastSweep = now
+	for pc, site := range s.sites {
+		if now.Sub(site.start) >= s.window {
+			delete(s.sites, pc)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package errors
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStackSamplerWindow(t *testing.T) {
+	sampler := NewStackSampler(3, time.Minute)
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	sampler.now = func() time.Time { return now }
+
+	var got []bool
+	for range 7 {
+		got = append(got, sampler.sample(1))
+	}
+	want := []bool{true, false, false, true, false, false, true}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Expected the first and then one in three to be sampled, got %v", got)
+		}
+	}
+	if !sampler.sample(2) {
+		t.Error("Other call sites should be counted separately")
+	}
+
+	now = now.Add(time.Minute)
+	if !sampler.sample(1) || sampler.sample(1) {
+		t.Error("A new window should sample the first error again")
+	}
+}
+
+func TestSetStackSampler(t *testing.T) {
+	SetStackSampler(NewStackSampler(10, time.Hour))
+	defer SetStackSampler(nil)
+
+	newError := func() *Error { return New(500, "Hot path", "INTERNAL_SERVER_ERROR") }
+	errs := make([]*Error, 20)
+	for i := range errs {
+		errs[i] = newError()
+	}
+
+	full := 0
+	for _, e := range errs {
+		switch len(e.StackTraces) {
+		case 1:
+		case 0:
+			t.Fatal("Unsampled errors should keep their creation frame")
+		default:
+			full++
+		}
+		if e.StackTraces[0] != errs[0].StackTraces[0] || e.Fingerprint() != errs[0].Fingerprint() {
+			t.Fatal("Sampling should not change the creation frame or the fingerprint")
+		}
+	}
+	if full != 2 {
+		t.Errorf("Expected 2 of 20 full stack traces, got %d", full)
+	}
+
+	SetStackSampler(nil)
+	if len(newError().StackTraces) < 2 {
+		t.Error("Removing the sampler should capture full stack traces again")
+	}
+}
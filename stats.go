@@ -0,0 +1,141 @@
+package errors
+
+import (
+	stderrors "errors"
+	"sync"
+	"time"
+)
+
+// DefaultStatsWindow is the period covered by Stats until SetStatsWindow is called
+const DefaultStatsWindow = 5 * time.Minute
+
+// statsBucket is the granularity of the sliding window used by Stats
+const statsBucket = time.Minute
+
+// Statistics summarizes the errors passed to Record within the stats window
+type Statistics struct {
+	// Window is the period covered by the statistics
+	Window time.Duration
+	// Occurrences is the number of recorded errors, extrapolated with Sampling.Weight.
+	// Cancellations (see IsCanceled) are not failures and are counted in Canceled instead.
+	Occurrences float64
+	// Canceled is the number of recorded cancellations, extrapolated with Sampling.Weight
+	Canceled float64
+	// DistinctFingerprints estimates how many different fingerprints were recorded, see Fingerprint.
+	// A sudden rise usually means a deploy introduced new failure modes.
+	DistinctFingerprints uint64
+	// ByDependency is the number of occurrences per dependency (see WithDependency). Errors
+	// without a dependency are not included.
+	ByDependency map[string]float64
+}
+
+// bucket holds the errors recorded during one statsBucket
+type bucket struct {
+	start        time.Time
+	occurrences  float64
+	canceled     float64
+	dependencies map[string]float64
+	sketch       sketch
+}
+
+var (
+	statsMu      sync.Mutex
+	statsWindow  = DefaultStatsWindow
+	statsBuckets = make([]bucket, bucketCount(DefaultStatsWindow))
+	statsNow     = time.Now
+)
+
+// Record counts err in the statistics returned by Stats. Call it wherever errors are logged or
+// reported; it keeps a fixed-size sketch per minute instead of the events themselves.
+// Errors that are not *Error all share the fingerprint of DefaultError.
+func Record(err error) {
+	if err == nil {
+		return
+	}
+
+	var e *Error
+	if !stderrors.As(err, &e) || e == nil {
+		e = DefaultError()
+	}
+	canceled := IsCanceled(err)
+	fingerprint := e.Fingerprint()
+	dependency := e.Dependency()
+	weight := e.Sampling.Weight()
+
+	statsMu.Lock()
+	defer statsMu.Unlock()
+
+	b := currentBucket(statsNow())
+	if canceled {
+		b.canceled += weight
+		return
+	}
+	b.occurrences += weight
+	b.sketch.add(fingerprint)
+	if dependency != "" {
+		if b.dependencies == nil {
+			b.dependencies = make(map[string]float64)
+		}
+		b.dependencies[dependency] += weight
+	}
+}
+
+// Stats returns the statistics of the errors recorded within the stats window
+func Stats() Statistics {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+
+	stats := Statistics{Window: statsWindow, ByDependency: make(map[string]float64)}
+	oldest := statsNow().Truncate(statsBucket).Add(-statsWindow + statsBucket)
+
+	var union sketch
+	for i := range statsBuckets {
+		b := &statsBuckets[i]
+		if b.start.IsZero() || b.start.Before(oldest) {
+			continue
+		}
+		stats.Occurrences += b.occurrences
+		stats.Canceled += b.canceled
+		for dependency, occurrences := range b.dependencies {
+			stats.ByDependency[dependency] += occurrences
+		}
+		union.merge(&b.sketch)
+	}
+	stats.DistinctFingerprints = union.estimate()
+
+	return stats
+}
+
+// SetStatsWindow sets the period covered by Stats, rounded up to whole minutes, and clears
+// the recorded statistics. A non-positive window restores DefaultStatsWindow.
+func SetStatsWindow(window time.Duration) {
+	if window <= 0 {
+		window = DefaultStatsWindow
+	}
+	count := bucketCount(window)
+
+	statsMu.Lock()
+	defer statsMu.Unlock()
+
+	statsWindow = time.Duration(count) * statsBucket
+	statsBuckets = make([]bucket, count)
+}
+
+// currentBucket returns the bucket for now, recycling the slot of an expired minute
+func currentBucket(now time.Time) *bucket {
+	start := now.Truncate(statsBucket)
+	b := &statsBuckets[int(start.Unix()/int64(statsBucket/time.Second))%len(statsBuckets)]
+	if !b.start.Equal(start) {
+		b.start = start
+		b.occurrences = 0
+		b.canceled = 0
+		b.dependencies = nil
+		b.sketch.reset()
+	}
+	return b
+}
+
+// bucketCount returns the number of buckets covering window
+func bucketCount(window time.Duration) int {
+	return int((window + statsBucket - 1) / statsBucket)
+}
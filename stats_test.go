@@ -0,0 +1,87 @@
+package errors
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// withStatsClock resets the statistics and drives them with a fake clock for the test
+func withStatsClock(t *testing.T, window time.Duration) *time.Time {
+	t.Helper()
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	statsNow = func() time.Time { return now }
+	SetStatsWindow(window)
+	t.Cleanup(func() {
+		statsNow = time.Now
+		SetStatsWindow(0)
+	})
+	return &now
+}
+
+func TestStatsDistinctFingerprints(t *testing.T) {
+	withStatsClock(t, 5*time.Minute)
+
+	for i := range 5000 {
+		Record(Newf(500, "failure %d", fmt.Sprintf("TYPE_%d", i), i))
+	}
+	same := ErrorNotFound()
+	for range 100 {
+		Record(same)
+	}
+
+	stats := Stats()
+	if stats.Occurrences != 5100 || stats.Window != 5*time.Minute {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+	if got := float64(stats.DistinctFingerprints); got < 5001*0.95 || got > 5001*1.05 {
+		t.Errorf("Expected about 5001 distinct fingerprints, got %d", stats.DistinctFingerprints)
+	}
+}
+
+func TestStatsWindow(t *testing.T) {
+	now := withStatsClock(t, 2*time.Minute+time.Second)
+
+	Record(ErrorNotFound())
+	Record(ErrorNotFound().WithSampling(true, 0.25))
+	if stats := Stats(); stats.Window != 3*time.Minute || stats.Occurrences != 5 || stats.DistinctFingerprints != 2 {
+		t.Errorf("Window should round up to minutes and occurrences use sampling weights, got %+v", stats)
+	}
+
+	*now = now.Add(2 * time.Minute)
+	Record(ErrorConflict())
+	if stats := Stats(); stats.Occurrences != 6 || stats.DistinctFingerprints != 3 {
+		t.Errorf("Recent minutes should be combined, got %+v", stats)
+	}
+
+	*now = now.Add(time.Minute)
+	if stats := Stats(); stats.Occurrences != 1 || stats.DistinctFingerprints != 1 {
+		t.Errorf("Expired minutes should be dropped, got %+v", stats)
+	}
+
+	Record(nil)
+	Record(fmt.Errorf("plain"))
+	if stats := Stats(); stats.Occurrences != 2 {
+		t.Errorf("Plain errors should be recorded and nil ignored, got %+v", stats)
+	}
+}
+
+func TestStatsByDependency(t *testing.T) {
+	now := withStatsClock(t, 2*time.Minute)
+
+	Record(ErrorBadGateway().WithDependency("payments-api"))
+	Record(ErrorGatewayTimeout().WithDependency("payments-api").WithSampling(true, 0.5))
+	Record(ErrorServiceUnavailable().WithUpstream("inventory", "/v1/stock"))
+	Record(ErrorNotFound())
+
+	stats := Stats()
+	if len(stats.ByDependency) != 2 || stats.ByDependency["payments-api"] != 3 || stats.ByDependency["inventory"] != 1 {
+		t.Errorf("Unexpected occurrences by dependency %v", stats.ByDependency)
+	}
+
+	*now = now.Add(2 * time.Minute)
+	if stats := Stats(); len(stats.ByDependency) != 0 {
+		t.Errorf("Expired minutes should be dropped, got %v", stats.ByDependency)
+	}
+}
@@ -0,0 +1,143 @@
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// summaryFields are the conventional fields copied into Summary.Metadata
+var summaryFields = []string{
+	FieldRequestID, FieldTraceID, FieldDependency, FieldUpstream, FieldUpstreamEndpoint,
+	FieldAttempt, FieldMaxAttempts, FieldTimeoutMs, FieldFaultPoint,
+}
+
+type (
+	// Summary is a compact digest of an error for automated triage tooling. Its JSON field names
+	// are stable. It carries no stack text and no free-form fields: only the top frame and the
+	// conventional metadata fields.
+	Summary struct {
+		Type         string            `json:"type"`
+		Code         int64             `json:"code"`
+		BusinessCode string            `json:"business_code,omitempty"`
+		Status       int               `json:"status"`
+		Message      string            `json:"message"`
+		Op           string            `json:"op,omitempty"`
+		RootCause    string            `json:"root_cause"`
+		Frame        *Frame            `json:"frame,omitempty"`
+		Fingerprint  string            `json:"fingerprint"`
+		ID           string            `json:"id,omitempty"`
+		Severity     string            `json:"severity"`
+		Retryable    bool              `json:"retryable"`
+		Canceled     bool              `json:"canceled"`
+		Violations   []string          `json:"violations,omitempty"`
+		Metadata     map[string]string `json:"metadata,omitempty"`
+	}
+
+	// Frame is a stack frame where an error was created
+	Frame struct {
+		Function string `json:"function"`
+		File     string `json:"file"`
+		Line     int    `json:"line"`
+	}
+)
+
+// Summarize returns the digest of err: the type, code, HTTP status, message and operation of the
+// first *Error in its chain, the Go type of the root cause (e.g. "*net.OpError"), the frame the
+// error was created at, its fingerprint, ID, severity, retryability, the fields of its violations
+// and the conventional metadata fields (request_id, trace_id, dependency, ...) of every *Error in
+// the chain, the outermost winning. Errors without
+// an *Error are classified like Wrap, without a frame. Summarize(nil) returns the zero Summary.
+func Summarize(err error) Summary {
+	if isNil(err) {
+		return Summary{}
+	}
+
+	var e *Error
+	if !stderrors.As(err, &e) || e == nil {
+		e = wrapError(err, err, 0)
+		e.StackTraces = nil
+	}
+	s := e.snapshot()
+
+	summary := Summary{
+		Type:         s.Type,
+		Code:         s.Code,
+		BusinessCode: s.BusinessCode,
+		Status:       HTTPStatus(&s),
+		Message:      s.Message,
+		Op:           s.Op,
+		RootCause:    fmt.Sprintf("%T", RootCause(err)),
+		Fingerprint:  e.Fingerprint(),
+		ID:           s.ID,
+		Severity:     e.LogSeverity().String(),
+		Retryable:    s.Retryable,
+		Canceled:     IsCanceled(err),
+	}
+	if len(s.StackTraces) > 0 {
+		summary.Frame = parseFrame(s.StackTraces[0])
+	}
+	for _, v := range s.Violations {
+		summary.Violations = append(summary.Violations, v.Field)
+	}
+	for key, value := range map[string]string{FieldRequestID: RequestID(err), FieldTraceID: TraceID(err)} {
+		if value != "" {
+			if summary.Metadata == nil {
+				summary.Metadata = make(map[string]string)
+			}
+			summary.Metadata[key] = value
+		}
+	}
+	for _, inner := range Chain(err) {
+		inner, ok := inner.(*Error)
+		if !ok {
+			continue
+		}
+		fields := inner.snapshot().Fields
+		for _, key := range summaryFields {
+			value, ok := fields[key]
+			if _, seen := summary.Metadata[key]; !ok || seen {
+				continue
+			}
+			if summary.Metadata == nil {
+				summary.Metadata = make(map[string]string)
+			}
+			summary.Metadata[key] = fmt.Sprint(value)
+		}
+	}
+
+	return summary
+}
+
+// Frames returns the stack trace parsed into frames, innermost first, e.g. for crash reporters
+// that want structured frames. Entries not in the "file:line function" format, e.g. from a
+// decoded foreign error, are skipped.
+func (e *Error) Frames() []Frame {
+	stack := e.snapshot().StackTraces
+	frames := make([]Frame, 0, len(stack))
+	for _, entry := range stack {
+		if frame := parseFrame(entry); frame != nil {
+			frames = append(frames, *frame)
+		}
+	}
+	return frames
+}
+
+// parseFrame parses a stack trace entry in the "file:line function" format, or returns nil
+func parseFrame(entry string) *Frame {
+	space := strings.LastIndex(entry, " ")
+	if space < 0 {
+		return nil
+	}
+	location, function := entry[:space], entry[space+1:]
+	colon := strings.LastIndex(location, ":")
+	if colon < 0 {
+		return nil
+	}
+	line, err := strconv.Atoi(location[colon+1:])
+	if err != nil {
+		return nil
+	}
+	return &Frame{Function: function, File: location[:colon], Line: line}
+}
@@ -0,0 +1,76 @@
+package errors
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestSummarize(t *testing.T) {
+	cause := &json.SyntaxError{Offset: 3}
+	inner := ErrorServiceUnavailable().WithDependency("vault").WithField(FieldRequestID, "req-1").WithField("secret", "s3cr3t")
+	inner.Err = fmt.Errorf("loading: %w", cause)
+	e := WrapOp("config.Load", inner)
+
+	s := Summarize(fmt.Errorf("startup: %w", e))
+	if s.Type != "SERVICE_UNAVAILABLE" || s.Code != 503 || s.Status != 503 || s.Op != "config.Load" || !s.Retryable || s.Canceled {
+		t.Errorf("Unexpected summary %+v", s)
+	}
+	if s.RootCause != "*json.SyntaxError" {
+		t.Errorf("Expected the root cause class, got %q", s.RootCause)
+	}
+	if s.Frame == nil || !strings.HasSuffix(s.Frame.Function, "TestSummarize") || !strings.HasSuffix(s.Frame.File, "summary_test.go") || s.Frame.Line == 0 {
+		t.Errorf("Expected the creating frame, got %+v", s.Frame)
+	}
+	if s.Fingerprint != e.Fingerprint() || s.Severity != "error" {
+		t.Errorf("Unexpected identity %q/%q", s.Fingerprint, s.Severity)
+	}
+	if len(s.Metadata) != 2 || s.Metadata[FieldDependency] != "vault" || s.Metadata[FieldRequestID] != "req-1" {
+		t.Errorf("Only conventional fields should be kept, got %v", s.Metadata)
+	}
+
+	data, _ := json.Marshal(s)
+	if strings.Contains(string(data), "s3cr3t") || strings.Contains(string(data), "stack") {
+		t.Errorf("Summaries should not carry free-form fields or stacks, got %s", data)
+	}
+}
+
+func TestSummarizePlainErrors(t *testing.T) {
+	if s := Summarize(nil); s.Type != "" || s.Frame != nil {
+		t.Errorf("nil should give the zero summary, got %+v", s)
+	}
+
+	s := Summarize(fmt.Errorf("query: %w", context.Canceled))
+	if s.Type != "CLIENT_CLOSED_REQUEST" || !s.Canceled || s.Frame != nil || s.RootCause != "*errors.errorString" {
+		t.Errorf("Plain errors should be classified like Wrap, got %+v", s)
+	}
+
+	v := Summarize(Violations([]ValidationError{{Field: "email"}, {Field: "items[0].sku"}}))
+	if len(v.Violations) != 2 || v.Violations[1] != "items[0].sku" {
+		t.Errorf("Expected violation fields, got %v", v.Violations)
+	}
+}
+
+func TestParseFrame(t *testing.T) {
+	f := parseFrame("/src/my app/main.go:42 main.run")
+	if f == nil || f.File != "/src/my app/main.go" || f.Line != 42 || f.Function != "main.run" {
+		t.Errorf("Unexpected frame %+v", f)
+	}
+	if parseFrame("garbage") != nil || parseFrame("file:x fn") != nil {
+		t.Error("Malformed entries should give no frame")
+	}
+}
+
+func TestFrames(t *testing.T) {
+	e := &Error{StackTraces: []string{"/app/store.go:12 app/store.Find", "garbage", "/app/main.go:5 main.main"}}
+
+	frames := e.Frames()
+	if len(frames) != 2 || frames[0] != (Frame{Function: "app/store.Find", File: "/app/store.go", Line: 12}) || frames[1].Function != "main.main" {
+		t.Errorf("Unexpected frames %+v", frames)
+	}
+	if frames := New(500, "Boom", "INTERNAL").Frames(); len(frames) == 0 || !strings.Contains(frames[0].Function, "TestFrames") {
+		t.Errorf("Frames should start at the creation site, got %+v", frames)
+	}
+}
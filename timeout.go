@@ -0,0 +1,41 @@
+package errors
+
+import (
+	"context"
+	stderrors "errors"
+	"time"
+)
+
+// FieldTimeoutMs is the field key under which WithTimeout records the exceeded budget, in milliseconds
+const FieldTimeoutMs = "timeout_ms"
+
+// WithTimeout runs fn with a context limited to d and classifies the outcome:
+//
+//   - when the budget d expires, the result is a retryable GATEWAY_TIMEOUT (504) *Error recording op
+//     and FieldTimeoutMs, wrapping fn's error (or context.DeadlineExceeded when fn returned nil late)
+//   - when ctx itself is canceled or reaches its own deadline, the caller gave up rather than the
+//     operation running out of budget, and fn's error is returned unchanged
+//   - otherwise fn's error is returned unchanged
+func WithTimeout(ctx context.Context, op string, d time.Duration, fn func(ctx context.Context) error) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, d)
+	defer cancel()
+
+	err := fn(timeoutCtx)
+	if ctx.Err() != nil || !stderrors.Is(timeoutCtx.Err(), context.DeadlineExceeded) {
+		return err
+	}
+	if err == nil {
+		err = context.DeadlineExceeded
+	}
+
+	return created(&Error{
+		Type:        "GATEWAY_TIMEOUT",
+		Code:        504,
+		Op:          op,
+		Message:     "Gateway Timeout",
+		StackTraces: captureStackTrace(1),
+		Err:         err,
+		Retryable:   true,
+		Fields:      map[string]any{FieldTimeoutMs: d.Milliseconds()},
+	})
+}
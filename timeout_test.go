@@ -0,0 +1,55 @@
+package errors
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+)
+
+func TestWithTimeoutExpires(t *testing.T) {
+	err := WithTimeout(context.Background(), "payments.Charge", 10*time.Millisecond, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	var e *Error
+	if !stderrors.As(err, &e) || e.Code != 504 || e.Op != "payments.Charge" || !e.Retryable {
+		t.Fatalf("Expected a 504 timeout, got %+v", err)
+	}
+	if e.Fields[FieldTimeoutMs] != int64(10) || !stderrors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the budget and the deadline cause, got %v", e.Fields)
+	}
+}
+
+func TestWithTimeoutLateSuccess(t *testing.T) {
+	err := WithTimeout(context.Background(), "report.Build", time.Millisecond, func(ctx context.Context) error {
+		time.Sleep(5 * time.Millisecond)
+		return nil
+	})
+	if !IsType(err, "GATEWAY_TIMEOUT") {
+		t.Errorf("Finishing after the budget should be a timeout, got %v", err)
+	}
+}
+
+func TestWithTimeoutCallerCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := WithTimeout(ctx, "payments.Charge", time.Second, func(ctx context.Context) error {
+		return ctx.Err()
+	})
+	if err != context.Canceled {
+		t.Errorf("Caller cancellation should be returned unchanged, got %v", err)
+	}
+}
+
+func TestWithTimeoutPassesThrough(t *testing.T) {
+	conflict := ErrorConflict()
+	if err := WithTimeout(context.Background(), "op", time.Second, func(context.Context) error { return conflict }); err != conflict {
+		t.Errorf("Errors within the budget should be returned unchanged, got %v", err)
+	}
+	if err := WithTimeout(context.Background(), "op", time.Second, func(context.Context) error { return nil }); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
@@ -0,0 +1,15 @@
+package errors
+
+import "time"
+
+// RecordElapsed records in Elapsed the time since the error was created and returns the error
+// for chaining. Call it where errors cross a boundary, e.g. in the middleware writing error
+// responses or the worker loop of a long-running job, to see how long a failure took to
+// surface. Errors without a Timestamp, e.g. decoded from a foreign payload, are unchanged.
+func (e *Error) RecordElapsed() *Error {
+	return e.update(func(e *Error) {
+		if !e.Timestamp.IsZero() {
+			e.Elapsed = time.Since(e.Timestamp)
+		}
+	})
+}
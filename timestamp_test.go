@@ -0,0 +1,78 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimestamp(t *testing.T) {
+	before := time.Now()
+	e := ErrorNotFound()
+	if e.Timestamp.Before(before) || e.Timestamp.After(time.Now()) {
+		t.Errorf("New errors should be stamped at creation, got %v", e.Timestamp)
+	}
+
+	wrapped := Wrap(fmt.Errorf("loading: %w", e))
+	if !wrapped.Timestamp.Equal(e.Timestamp) {
+		t.Errorf("Wrap should keep the inner timestamp, got %v want %v", wrapped.Timestamp, e.Timestamp)
+	}
+	if plain := Wrap(fmt.Errorf("disk full")); plain.Timestamp.IsZero() {
+		t.Error("Wrapping a plain error should stamp it")
+	}
+
+	if e.Elapsed != 0 {
+		t.Error("Elapsed should be unset until recorded")
+	}
+	e.Timestamp = time.Now().Add(-time.Second)
+	if e.RecordElapsed() != e || e.Elapsed < time.Second {
+		t.Errorf("RecordElapsed should record the time since creation, got %v", e.Elapsed)
+	}
+	if foreign := (&Error{Type: "X"}).RecordElapsed(); foreign.Elapsed != 0 {
+		t.Error("Errors without a timestamp should be unchanged")
+	}
+}
+
+func TestTimestampEncoding(t *testing.T) {
+	e := ErrorBadRequest()
+	e.Timestamp = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	e.Elapsed = 1500 * time.Millisecond
+
+	b, err := json.Marshal(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var m map[string]any
+	if err := json.Unmarshal(b, &m); err != nil {
+		t.Fatal(err)
+	}
+	if m["timestamp"] != "2024-05-01T12:00:00Z" || m["elapsed"] != float64(1500*time.Millisecond) {
+		t.Errorf("JSON should include timestamp and elapsed, got %s", b)
+	}
+	if b, _ := json.Marshal(&Error{Type: "X"}); strings.Contains(string(b), "timestamp") {
+		t.Errorf("A zero timestamp should be omitted, got %s", b)
+	}
+
+	text, err := e.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Error
+	if err := decoded.UnmarshalText(text); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Timestamp.Equal(e.Timestamp) || decoded.Elapsed != e.Elapsed {
+		t.Errorf("The text encoding should round-trip, got %v %v", decoded.Timestamp, decoded.Elapsed)
+	}
+
+	attrs := map[string]slog.Value{}
+	for _, attr := range e.LogValue().Group() {
+		attrs[attr.Key] = attr.Value
+	}
+	if !attrs["timestamp"].Time().Equal(e.Timestamp) || attrs["elapsed"].Duration() != e.Elapsed {
+		t.Errorf("Logs should include timestamp and elapsed, got %v", attrs)
+	}
+}
@@ -1,34 +1,134 @@
 package errors
 
+import (
+	stderrors "errors"
+	"maps"
+	"time"
+)
+
 type (
 	ViolationErrorType string
 	ValidationError    struct {
-		Type    ViolationErrorType `json:"type"`
-		Field   string             `json:"field"`
-		Message string             `json:"message"`
+		Type       ViolationErrorType `json:"type"`
+		Field      string             `json:"field"`
+		Pointer    string             `json:"pointer,omitempty"`
+		Message    string             `json:"message"`
+		MessageKey string             `json:"-"`
+		Params     map[string]any     `json:"params,omitempty"`
+		DocsURL    string             `json:"docs_url,omitempty"`
 	}
 
 	Error struct {
-		Type        string            `json:"type"`
-		Code        int64             `json:"code"`
-		Message     string            `json:"message"`
-		Violations  []ValidationError `json:"violations"`
-		Err         error             `json:"-"`
-		StackTraces []string          `json:"stack_traces"`
+		ID              string            `json:"id,omitempty"`
+		RequestID       string            `json:"request_id,omitempty"`
+		TraceID         string            `json:"trace_id,omitempty"`
+		Type            string            `json:"type"`
+		Code            int64             `json:"code"`
+		BusinessCode    string            `json:"business_code,omitempty"`
+		Status          int               `json:"-"`
+		GRPCCode        uint32            `json:"-"`
+		Op              string            `json:"-"`
+		Message         string            `json:"message"`
+		InternalMessage string            `json:"-"`
+		MessageKey      string            `json:"-"`
+		MessageTemplate string            `json:"-"`
+		MessageParams   []any             `json:"-"`
+		MessageHistory  []string          `json:"-"`
+		Notes           []string          `json:"notes,omitempty"`
+		Violations      []ValidationError `json:"violations"`
+		Fields          map[string]any    `json:"fields,omitempty"`
+		Err             error             `json:"-"`
+		StackTraces     []string          `json:"stack_traces"`
+		Retryable       bool              `json:"retryable,omitempty"`
+		RetryAfter      time.Duration     `json:"-"`
+		Sampling        *Sampling         `json:"sampling,omitempty"`
+		Severity        Severity          `json:"severity,omitempty"`
+		Timestamp       time.Time         `json:"timestamp,omitzero"`
+		Elapsed         time.Duration     `json:"elapsed,omitempty"`
+		Goroutine       *Goroutine        `json:"goroutine,omitempty"`
 	}
 )
 
+// WithDocsURL returns a copy of the violation pointing to the field's documentation
+func (v ValidationError) WithDocsURL(url string) ValidationError {
+	v.DocsURL = url
+	return v
+}
+
+// WithParam returns a copy of the violation with a message parameter, e.g. ("min", 3), that
+// clients can use to render their own localized messages. The params map is copied, so the
+// original violation is not affected.
+func (v ValidationError) WithParam(key string, value any) ValidationError {
+	params := make(map[string]any, len(v.Params)+1)
+	for k, existing := range v.Params {
+		params[k] = existing
+	}
+	params[key] = value
+	v.Params = params
+	return v
+}
+
+// WithField sets a structured field on the error and returns the error for chaining
+// The fields map is replaced rather than modified, so maps returned earlier are not affected.
+func (e *Error) WithField(key string, value any) *Error {
+	return e.update(func(e *Error) {
+		fields := make(map[string]any, len(e.Fields)+1)
+		for k, v := range e.Fields {
+			fields[k] = v
+		}
+		fields[key] = value
+		e.Fields = fields
+	})
+}
+
+// Clone returns a copy of the error that shares no maps or slices with it, so the copy can be
+// modified without affecting the original. The wrapped error and field values themselves are
+// not copied. Clone of a nil error returns nil.
+func (e *Error) Clone() *Error {
+	if e == nil {
+		return nil
+	}
+
+	c := e.snapshot()
+	c.MessageParams = append([]any(nil), c.MessageParams...)
+	c.MessageHistory = append([]string(nil), c.MessageHistory...)
+	c.Notes = append([]string(nil), c.Notes...)
+	c.Violations = append(make([]ValidationError, 0, len(c.Violations)), c.Violations...)
+	for i, v := range c.Violations {
+		if v.Params != nil {
+			c.Violations[i].Params = maps.Clone(v.Params)
+		}
+	}
+	c.StackTraces = append(make([]string, 0, len(c.StackTraces)), c.StackTraces...)
+	if c.Fields != nil {
+		fields := make(map[string]any, len(c.Fields))
+		for key, value := range c.Fields {
+			fields[key] = value
+		}
+		c.Fields = fields
+	}
+	if c.Sampling != nil {
+		sampling := *c.Sampling
+		c.Sampling = &sampling
+	}
+	if c.Goroutine != nil {
+		c.Goroutine = &Goroutine{ID: c.Goroutine.ID, Labels: maps.Clone(c.Goroutine.Labels)}
+	}
+	return &c
+}
+
 // Error implements the error interface
 func (e *Error) Error() string {
 	if e == nil {
 		return ""
 	}
 
-	if e.Err != nil {
-		return e.Err.Error()
+	s := e.snapshot()
+	if s.Err != nil {
+		return s.Err.Error()
 	}
 
-	return e.Message
+	return s.Message
 }
 
 // Unwrap returns the wrapped error, implementing the errors.Unwrap interface
@@ -36,7 +136,7 @@ func (e *Error) Unwrap() error {
 	if e == nil {
 		return nil
 	}
-	return e.Err
+	return e.cause()
 }
 
 // Is reports whether any error in err's chain matches target
@@ -45,13 +145,19 @@ func (e *Error) Is(target error) bool {
 		return target == nil
 	}
 
-	if targetErr, ok := target.(*Error); ok {
-		return e.Type == targetErr.Type
+	s := e.snapshot()
+
+	// An aggregate's type is derived from its members, so only the members are matched
+	if targetErr, ok := target.(*Error); ok && !isJoined(s.Err) && targetErr != nil && s.Type == targetErr.snapshot().Type {
+		return true
+	}
+	if sentinel, ok := target.(*Sentinel); ok && !isJoined(s.Err) && sentinel != nil && s.Type == sentinel.errorType {
+		return true
 	}
 
-	// Check if the underlying error matches
-	if e.Err != nil {
-		return e.Err == target
+	// Check if any error in the underlying chain matches
+	if s.Err != nil {
+		return stderrors.Is(s.Err, target)
 	}
 
 	return false
@@ -0,0 +1,189 @@
+package errors
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestIsTraversesWrappedChain(t *testing.T) {
+	sentinel := stderrors.New("sentinel")
+	err := Wrap(fmt.Errorf("layer two: %w", fmt.Errorf("layer one: %w", sentinel)))
+
+	if !err.Is(sentinel) {
+		t.Error("Is() should match a sentinel deep in the wrapped chain")
+	}
+
+	if !stderrors.Is(fmt.Errorf("outer: %w", err), sentinel) {
+		t.Error("errors.Is should match a sentinel through an outer fmt.Errorf wrapper")
+	}
+
+	if err.Is(stderrors.New("other")) {
+		t.Error("Is() should not match an unrelated error")
+	}
+}
+
+func TestAsFindsErrorInChain(t *testing.T) {
+	notFound := ErrorNotFound()
+	wrapped := fmt.Errorf("handler: %w", fmt.Errorf("service: %w", notFound))
+
+	var target *Error
+	if !stderrors.As(wrapped, &target) {
+		t.Fatal("errors.As should find *Error in the chain")
+	}
+	if target != notFound {
+		t.Error("errors.As should return the original *Error")
+	}
+
+	if !stderrors.Is(wrapped, ErrorNotFound()) {
+		t.Error("errors.Is should match *Error by type through the chain")
+	}
+
+	if stderrors.As(fmt.Errorf("plain"), &target) {
+		t.Error("errors.As should not match a plain error")
+	}
+}
+
+func TestNewf(t *testing.T) {
+	err := Newf(404, "User %s not found", "NOT_FOUND", "42")
+	if err.Message != "User 42 not found" {
+		t.Errorf("Unexpected message %q", err.Message)
+	}
+	if err.MessageTemplate != "User %s not found" || len(err.MessageParams) != 1 || err.MessageParams[0] != "42" {
+		t.Errorf("Template and params should be kept, got %q %v", err.MessageTemplate, err.MessageParams)
+	}
+	if data, _ := json.Marshal(err); strings.Contains(string(data), "42\"]") || strings.Contains(string(data), "%s") {
+		t.Errorf("Template and params should not be serialized: %s", data)
+	}
+
+	other := Newf(404, "User %s not found", "NOT_FOUND", "43")
+	err.StackTraces, other.StackTraces = nil, nil
+	if err.Fingerprint() != other.Fingerprint() {
+		t.Error("Errors sharing a template should share a fingerprint")
+	}
+}
+
+func TestFromHTTPStatus(t *testing.T) {
+	tests := []struct {
+		status    int
+		errorType string
+		retryable bool
+	}{
+		{404, "NOT_FOUND", false},
+		{429, "TOO_MANY_REQUEST", true},
+		{503, "SERVICE_UNAVAILABLE", true},
+		{417, "EXPECTATION_FAILED", false},
+		{499, "BAD_REQUEST", false},
+		{599, "INTERNAL_SERVER_ERROR", false},
+	}
+
+	for _, tt := range tests {
+		e := FromHTTPStatus(tt.status)
+		if e.Type != tt.errorType || e.Code != int64(tt.status) || e.Retryable != tt.retryable {
+			t.Errorf("FromHTTPStatus(%d) = %s/%d/%v", tt.status, e.Type, e.Code, e.Retryable)
+		}
+		if len(e.StackTraces) == 0 || !strings.Contains(e.StackTraces[0], "TestFromHTTPStatus") {
+			t.Errorf("FromHTTPStatus(%d) should capture the caller's stack", tt.status)
+		}
+	}
+
+	if FromHTTPStatus(204) != nil {
+		t.Error("Non-error statuses should return nil")
+	}
+}
+
+func TestStatusClassesMatchFactories(t *testing.T) {
+	factories := []func() *Error{
+		ErrorBadRequest, ErrorUnauthorized, ErrorPaymentRequired, ErrorForbidden, ErrorNotFound,
+		ErrorMethodNotAllowed, ErrorNotAcceptable, ErrorRequestTimeout, ErrorConflict, ErrorGone,
+		ErrorPreconditionFailed, ErrorRequestEntityTooLarge, ErrorUnsupportedMediaType,
+		ErrorUnprocessableEntity, ErrorLocked, ErrorTooEarly, ErrorPreconditionRequired,
+		ErrorTooManyRequests, ErrorRequestHeaderFieldsTooLarge, ErrorUnavailableForLegalReasons,
+		ErrorInternalServerError, ErrorNotImplemented, ErrorBadGateway, ErrorServiceUnavailable,
+		ErrorGatewayTimeout,
+	}
+	if len(factories) != len(statusClasses) {
+		t.Errorf("Expected a class for each of the %d factories, got %d", len(factories), len(statusClasses))
+	}
+	for _, factory := range factories {
+		want := factory()
+		got := FromHTTPStatus(int(want.Code))
+		if got.Type != want.Type || got.Message != want.Message || got.Retryable != want.Retryable {
+			t.Errorf("FromHTTPStatus(%d) = %s/%q/%v, the factory gives %s/%q/%v",
+				want.Code, got.Type, got.Message, got.Retryable, want.Type, want.Message, want.Retryable)
+		}
+	}
+}
+
+func TestWrapf(t *testing.T) {
+	cause := stderrors.New("sql: no rows in result set")
+	err := Wrapf(cause, "loading user %d", 42)
+
+	if err.Error() != "loading user 42: sql: no rows in result set" || err.Code != 500 {
+		t.Errorf("Unexpected error %q (%d)", err.Error(), err.Code)
+	}
+	if !stderrors.Is(err, cause) || len(err.StackTraces) == 0 || !strings.Contains(err.StackTraces[0], "TestWrapf") {
+		t.Errorf("The cause should stay reachable and the stack start at the caller, got %v", err.StackTraces)
+	}
+	if Wrapf(nil, "loading user %d", 42) != nil {
+		t.Error("Wrapf(nil) should return nil")
+	}
+}
+
+func TestWrapWith(t *testing.T) {
+	cause := stderrors.New("duplicate key value violates unique constraint")
+	err := WrapWith(cause, 409, "EMAIL_TAKEN", "Email is already registered")
+
+	if err.Type != "EMAIL_TAKEN" || err.Code != 409 || err.Message != "Email is already registered" || HTTPStatus(err) != 409 {
+		t.Errorf("Unexpected classification %+v", err)
+	}
+	if err.Error() != cause.Error() || !stderrors.Is(err, cause) {
+		t.Errorf("The cause should be kept, got %q", err.Error())
+	}
+	if WrapWith(nil, 409, "EMAIL_TAKEN", "taken") != nil {
+		t.Error("WrapWith(nil) should return nil")
+	}
+}
+
+func TestWrapContextErrors(t *testing.T) {
+	timeout := Wrap(fmt.Errorf("querying orders: %w", context.DeadlineExceeded))
+	if timeout.Type != "GATEWAY_TIMEOUT" || timeout.Code != 504 || !timeout.Retryable || !stderrors.Is(timeout, context.DeadlineExceeded) {
+		t.Errorf("Deadlines should map to 504, got %+v", timeout)
+	}
+
+	canceled := Wrapf(context.Canceled, "querying orders")
+	if canceled.Type != "CLIENT_CLOSED_REQUEST" || canceled.Code != 499 || canceled.Retryable {
+		t.Errorf("Cancellation should map to 499, got %+v", canceled)
+	}
+	if !strings.Contains(canceled.StackTraces[0], "TestWrapContextErrors") {
+		t.Errorf("The stack should start at the caller, got %v", canceled.StackTraces)
+	}
+
+	if op := WrapOp("orders.List", context.Canceled); op.Code != 499 || op.Op != "orders.List" {
+		t.Errorf("WrapOp should classify like Wrap, got %+v", op)
+	}
+	if DefaultClassifier.Classify(context.DeadlineExceeded).Code != 504 {
+		t.Error("The default classifier should classify like Wrap")
+	}
+	if e := ErrorClientClosedRequest(); e.Type != "CLIENT_CLOSED_REQUEST" || HTTPStatus(e) != 499 {
+		t.Errorf("Unexpected error %+v", e)
+	}
+}
+
+func TestValidationErrorWithParam(t *testing.T) {
+	original := ValidationError{Type: ViolationErrorTypeMin, Field: "age"}.WithParam("min", 18)
+	changed := original.WithParam("unit", "years")
+	if len(original.Params) != 1 || len(changed.Params) != 2 || changed.Params["min"] != 18 {
+		t.Errorf("WithParam should not modify the original, got %v and %v", original.Params, changed.Params)
+	}
+
+	e := Violations([]ValidationError{original})
+	clone := e.Clone()
+	clone.Violations[0].Params["min"] = 21
+	if e.Violations[0].Params["min"] != 18 {
+		t.Error("Clone should copy violation params")
+	}
+}
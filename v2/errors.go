@@ -0,0 +1,245 @@
+// Package errors is the core of the v2 module: the Error type, its construction options and
+// chain helpers. Predefined kinds live in kinds, transport encodings in transport/..., and
+// observability seams in observe; this package imports none of them.
+package errors
+
+import (
+	stderrors "errors"
+	"maps"
+	"slices"
+	"sync"
+)
+
+type (
+	// ViolationType classifies why a field is invalid
+	ViolationType string
+
+	// Violation describes one invalid field
+	Violation struct {
+		Type    ViolationType
+		Field   string
+		Message string
+	}
+
+	// Error is an application error. Its state is only reachable through accessors and options,
+	// so an *Error can be shared as a sentinel without callers mutating it.
+	Error struct {
+		kind       string
+		code       int64
+		message    string
+		violations []Violation
+		fields     map[string]any
+		cause      error
+		stack      []Frame
+	}
+
+	// Option configures an error created by New, Wrap or Derive
+	Option func(*Error)
+)
+
+// Common violation types, matching the v1 constants
+const (
+	ViolationRequired   ViolationType = "REQUIRED"
+	ViolationRequiredIf ViolationType = "REQUIRED_IF"
+	ViolationOneOf      ViolationType = "ONEOF"
+	ViolationUUID       ViolationType = "UUID"
+	ViolationMin        ViolationType = "MIN"
+	ViolationMax        ViolationType = "MAX"
+	ViolationEmail      ViolationType = "EMAIL"
+	ViolationDate       ViolationType = "DATE"
+)
+
+var (
+	resolversMu sync.RWMutex
+	resolvers   []func(target error) (string, bool)
+)
+
+// New creates an error of the given kind with a stack trace starting at the caller
+func New(kind string, code int64, message string, opts ...Option) *Error {
+	e := &Error{kind: kind, code: code, message: message, stack: callers(1)}
+	return e.apply(opts)
+}
+
+// Wrap attaches cause to a new error. When cause already carries an *Error its kind, code,
+// message, violations and stack are kept; otherwise the error is an internal server error.
+// Wrap returns nil for a nil cause.
+func Wrap(cause error, opts ...Option) *Error {
+	if cause == nil {
+		return nil
+	}
+
+	var inner *Error
+	if stderrors.As(cause, &inner) && inner != nil {
+		e := &Error{
+			kind:       inner.kind,
+			code:       inner.code,
+			message:    inner.message,
+			violations: slices.Clone(inner.violations),
+			cause:      cause,
+			stack:      inner.stack,
+		}
+		if len(e.stack) == 0 {
+			e.stack = callers(1)
+		}
+		return e.apply(opts)
+	}
+
+	e := &Error{
+		kind:    "INTERNAL_SERVER_ERROR",
+		code:    500,
+		message: "An internal server error occurred",
+		cause:   cause,
+		stack:   callers(1),
+	}
+	return e.apply(opts)
+}
+
+// Derive creates a new error with the kind, code and message of template, e.g. a sentinel from
+// the kinds package, and a stack trace starting at the caller. The result matches template
+// with errors.Is.
+func Derive(template *Error, opts ...Option) *Error {
+	e := &Error{kind: template.kind, code: template.code, message: template.message, stack: callers(1)}
+	return e.apply(opts)
+}
+
+// apply runs the options and reports the error to the installed hook
+func (e *Error) apply(opts []Option) *Error {
+	for _, opt := range opts {
+		opt(e)
+	}
+	notify(e)
+	return e
+}
+
+// Kind returns the error kind, e.g. "NOT_FOUND"
+func (e *Error) Kind() string { return e.kind }
+
+// Code returns the error code
+func (e *Error) Code() int64 { return e.code }
+
+// Message returns the client-facing message
+func (e *Error) Message() string { return e.message }
+
+// Violations returns a copy of the validation violations
+func (e *Error) Violations() []Violation { return slices.Clone(e.violations) }
+
+// Fields returns a copy of the structured fields
+func (e *Error) Fields() map[string]any { return maps.Clone(e.fields) }
+
+// Field returns the structured field stored under key
+func (e *Error) Field(key string) (any, bool) {
+	value, ok := e.fields[key]
+	return value, ok
+}
+
+// Stack returns a copy of the captured stack frames
+func (e *Error) Stack() []Frame { return slices.Clone(e.stack) }
+
+// Error implements the error interface. It returns the cause's message when there is one,
+// and the client-facing message otherwise.
+func (e *Error) Error() string {
+	if e == nil {
+		return ""
+	}
+	if e.cause != nil {
+		return e.cause.Error()
+	}
+	return e.message
+}
+
+// Unwrap returns the cause
+func (e *Error) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	return e.cause
+}
+
+// Is reports whether target is an error of the same kind: a v2 *Error, or any error a
+// registered kind resolver recognizes, such as a v1 error through v1compat
+func (e *Error) Is(target error) bool {
+	if e == nil || e.kind == "" {
+		return false
+	}
+	if t, ok := target.(*Error); ok {
+		return t != nil && t.kind == e.kind
+	}
+
+	resolversMu.RLock()
+	defer resolversMu.RUnlock()
+	for _, resolve := range resolvers {
+		if kind, ok := resolve(target); ok {
+			return kind == e.kind
+		}
+	}
+	return false
+}
+
+// RegisterKindResolver lets Is match foreign error values by kind.
+// resolve reports the kind of target and whether it recognizes it.
+func RegisterKindResolver(resolve func(target error) (kind string, ok bool)) {
+	resolversMu.Lock()
+	defer resolversMu.Unlock()
+	resolvers = append(resolvers, resolve)
+}
+
+// Is reports whether any error in err's tree matches target, see errors.Is
+func Is(err, target error) bool { return stderrors.Is(err, target) }
+
+// As finds the first error in err's tree that matches target, see errors.As
+func As(err error, target any) bool { return stderrors.As(err, target) }
+
+// Unwrap returns the result of calling err's Unwrap method, see errors.Unwrap
+func Unwrap(err error) error { return stderrors.Unwrap(err) }
+
+// WithMessage sets the client-facing message
+func WithMessage(message string) Option {
+	return func(e *Error) {
+		e.message = message
+	}
+}
+
+// WithCode sets the error code
+func WithCode(code int64) Option {
+	return func(e *Error) {
+		e.code = code
+	}
+}
+
+// WithKind sets the error kind
+func WithKind(kind string) Option {
+	return func(e *Error) {
+		e.kind = kind
+	}
+}
+
+// WithViolations appends validation violations
+func WithViolations(violations ...Violation) Option {
+	return func(e *Error) {
+		e.violations = append(slices.Clip(e.violations), violations...)
+	}
+}
+
+// WithField sets a structured field
+func WithField(key string, value any) Option {
+	return func(e *Error) {
+		fields := make(map[string]any, len(e.fields)+1)
+		maps.Copy(fields, e.fields)
+		fields[key] = value
+		e.fields = fields
+	}
+}
+
+// WithCause sets the cause without changing the error's classification
+func WithCause(cause error) Option {
+	return func(e *Error) {
+		e.cause = cause
+	}
+}
+
+// WithStack replaces the captured stack; nil removes it
+func WithStack(frames []Frame) Option {
+	return func(e *Error) {
+		e.stack = slices.Clone(frames)
+	}
+}
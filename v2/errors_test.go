@@ -0,0 +1,122 @@
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	err := New("NOT_FOUND", 404, "Not found",
+		WithField("user_id", 42),
+		WithViolations(Violation{Type: ViolationRequired, Field: "email", Message: "Email is required"}),
+	)
+
+	if err.Kind() != "NOT_FOUND" || err.Code() != 404 || err.Message() != "Not found" || err.Error() != "Not found" {
+		t.Errorf("Unexpected error %s/%d/%s", err.Kind(), err.Code(), err.Message())
+	}
+	if value, ok := err.Field("user_id"); !ok || value != 42 {
+		t.Errorf("Field should be set, got %v", value)
+	}
+	if len(err.Violations()) != 1 || err.Violations()[0].Field != "email" {
+		t.Errorf("Violations should be set, got %v", err.Violations())
+	}
+	if stack := err.Stack(); len(stack) == 0 || !strings.HasSuffix(stack[0].Function, "TestNew") {
+		t.Errorf("Stack should start at the caller, got %v", stack)
+	}
+}
+
+func TestAccessorsReturnCopies(t *testing.T) {
+	err := New("CONFLICT", 409, "Conflict", WithField("a", 1), WithViolations(Violation{Field: "x"}))
+
+	err.Fields()["a"] = 2
+	err.Violations()[0].Field = "y"
+	if value, _ := err.Field("a"); value != 1 || err.Violations()[0].Field != "x" {
+		t.Error("Accessors should not expose the error's state")
+	}
+}
+
+func TestWrap(t *testing.T) {
+	if Wrap(nil) != nil {
+		t.Error("Wrap(nil) should return nil")
+	}
+
+	cause := fmt.Errorf("pq: connection refused")
+	internal := Wrap(cause)
+	if internal.Kind() != "INTERNAL_SERVER_ERROR" || internal.Code() != 500 || !stderrors.Is(internal, cause) {
+		t.Errorf("Plain errors should wrap as internal errors, got %s/%d", internal.Kind(), internal.Code())
+	}
+	if internal.Error() != "pq: connection refused" {
+		t.Errorf("Error should report the cause, got %q", internal.Error())
+	}
+
+	notFound := New("NOT_FOUND", 404, "Not found")
+	wrapped := Wrap(fmt.Errorf("loading user: %w", notFound), WithField("user_id", 42))
+	if wrapped.Kind() != "NOT_FOUND" || wrapped.Code() != 404 {
+		t.Errorf("Wrap should keep the classification, got %s/%d", wrapped.Kind(), wrapped.Code())
+	}
+	if len(wrapped.Stack()) != len(notFound.Stack()) || wrapped.Stack()[0] != notFound.Stack()[0] {
+		t.Error("Wrap should reuse the existing stack")
+	}
+}
+
+func TestIs(t *testing.T) {
+	sentinel := New("NOT_FOUND", 404, "Not found", WithStack(nil))
+	err := Derive(sentinel, WithMessage("User 42 not found"))
+
+	if !Is(fmt.Errorf("handler: %w", err), sentinel) || err.Message() != "User 42 not found" {
+		t.Error("Derived errors should match their template")
+	}
+	if Is(err, New("CONFLICT", 409, "Conflict")) {
+		t.Error("Different kinds should not match")
+	}
+
+	var target *Error
+	if !As(fmt.Errorf("wrapped: %w", err), &target) || target != err || Unwrap(fmt.Errorf("x: %w", err)) != err {
+		t.Error("As and Unwrap should follow the chain")
+	}
+}
+
+type fakeKind string
+
+func (f fakeKind) Error() string { return string(f) }
+
+func TestRegisterKindResolver(t *testing.T) {
+	RegisterKindResolver(func(target error) (string, bool) {
+		kind, ok := target.(fakeKind)
+		return string(kind), ok
+	})
+
+	err := New("GONE", 410, "Gone")
+	if !stderrors.Is(err, fakeKind("GONE")) || stderrors.Is(err, fakeKind("LOCKED")) {
+		t.Error("Resolved targets should match by kind")
+	}
+}
+
+type countingHook struct{ count int }
+
+func (h *countingHook) OnError(*Error) { h.count++ }
+
+type fixedCapturer struct{}
+
+func (fixedCapturer) Capture(skip int) []Frame {
+	return []Frame{{Function: "fixed", File: "fixed.go", Line: skip}}
+}
+
+func TestSeams(t *testing.T) {
+	hook := &countingHook{}
+	SetHook(hook)
+	SetStackCapturer(fixedCapturer{})
+	defer SetHook(nil)
+	defer SetStackCapturer(nil)
+
+	err := New("BAD_REQUEST", 400, "Bad request")
+	_ = Wrap(fmt.Errorf("boom"))
+	if hook.count != 2 {
+		t.Errorf("Hook should see every created error, got %d", hook.count)
+	}
+	if stack := err.Stack(); len(stack) != 1 || stack[0].Function != "fixed" || stack[0].Line != 2 {
+		t.Errorf("The installed capturer should be used with the caller's skip, got %v", stack)
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/andryhardiyanto/go-errors/v2
+
+go 1.26.2
@@ -0,0 +1,31 @@
+// Package kinds holds the predefined error kinds as immutable sentinels.
+// Match them with errors.Is and create errors of a kind with errors.Derive:
+//
+//	err := errors.Derive(kinds.NotFound, errors.WithMessage("User 42 not found"))
+//	errors.Is(err, kinds.NotFound) // true
+package kinds
+
+import (
+	errors "github.com/andryhardiyanto/go-errors/v2"
+)
+
+// Sentinels for the common HTTP error classes. Kind names match the v1 error types so that
+// v1compat can match errors across versions.
+var (
+	BadRequest          = sentinel("BAD_REQUEST", 400, "Bad request")
+	Unauthorized        = sentinel("UNAUTHORIZED", 401, "Unauthorized")
+	Forbidden           = sentinel("FORBIDDEN", 403, "Forbidden")
+	NotFound            = sentinel("NOT_FOUND", 404, "Not found")
+	Conflict            = sentinel("CONFLICT", 409, "Conflict")
+	UnprocessableEntity = sentinel("UNPROCESSABLE_ENTITY", 422, "Unprocessable entity")
+	TooManyRequests     = sentinel("TOO_MANY_REQUEST", 429, "Too Many Requests")
+	Internal            = sentinel("INTERNAL_SERVER_ERROR", 500, "An internal server error occurred")
+	NotImplemented      = sentinel("NOT_IMPLEMENTED", 501, "Not Implemented")
+	Unavailable         = sentinel("SERVICE_UNAVAILABLE", 503, "Service Unavailable")
+	Timeout             = sentinel("GATEWAY_TIMEOUT", 504, "Gateway Timeout")
+)
+
+// sentinel creates a stackless error used only for matching and as a template
+func sentinel(kind string, code int64, message string) *errors.Error {
+	return errors.New(kind, code, message, errors.WithStack(nil))
+}
@@ -0,0 +1,22 @@
+package kinds
+
+import (
+	"fmt"
+	"testing"
+
+	errors "github.com/andryhardiyanto/go-errors/v2"
+)
+
+func TestSentinels(t *testing.T) {
+	if len(NotFound.Stack()) != 0 {
+		t.Error("Sentinels should not carry a stack")
+	}
+
+	err := errors.Derive(NotFound, errors.WithMessage("User 42 not found"))
+	if !errors.Is(fmt.Errorf("handler: %w", err), NotFound) || errors.Is(err, Conflict) {
+		t.Error("Derived errors should match only their kind")
+	}
+	if NotFound.Message() != "Not found" || err.Code() != 404 {
+		t.Error("Deriving should not change the sentinel")
+	}
+}
@@ -0,0 +1,70 @@
+// Package observe defines the observability seams of the v2 module: hooks notified of every
+// created error and the stack capturer used by the core package.
+package observe
+
+import (
+	"sync"
+
+	errors "github.com/andryhardiyanto/go-errors/v2"
+)
+
+type (
+	// Hook is notified of every error created by errors.New, errors.Wrap and errors.Derive.
+	// Hooks run synchronously on the creating goroutine and must not block.
+	Hook interface {
+		OnError(err *errors.Error)
+	}
+
+	// HookFunc adapts a function to Hook
+	HookFunc func(err *errors.Error)
+
+	// StackCapturer captures the stack of a newly created error.
+	// Capture skips skip frames, with 0 identifying the caller of Capture.
+	StackCapturer interface {
+		Capture(skip int) []errors.Frame
+	}
+
+	// hooks fans an error out to several hooks
+	hooks []Hook
+
+	// noStack is a StackCapturer that records nothing
+	noStack struct{}
+)
+
+// NoStack disables stack capture, e.g. for hot paths where traces are not needed
+var NoStack StackCapturer = noStack{}
+
+var mu sync.Mutex
+
+// OnError calls f(err)
+func (f HookFunc) OnError(err *errors.Error) { f(err) }
+
+func (h hooks) OnError(err *errors.Error) {
+	for _, hook := range h {
+		hook.OnError(err)
+	}
+}
+
+func (noStack) Capture(int) []errors.Frame { return nil }
+
+// Install replaces the installed hooks; they run in the given order.
+// Calling Install without hooks removes them.
+func Install(hs ...Hook) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(hs) == 0 {
+		errors.SetHook(nil)
+		return
+	}
+	errors.SetHook(append(hooks(nil), hs...))
+}
+
+// UseStackCapturer replaces the stack capturer; nil restores errors.CaptureStack
+func UseStackCapturer(c StackCapturer) {
+	if c == nil {
+		errors.SetStackCapturer(nil)
+		return
+	}
+	errors.SetStackCapturer(c)
+}
@@ -0,0 +1,34 @@
+package observe
+
+import (
+	"testing"
+
+	errors "github.com/andryhardiyanto/go-errors/v2"
+)
+
+func TestInstall(t *testing.T) {
+	var order []string
+	Install(
+		HookFunc(func(err *errors.Error) { order = append(order, "first:"+err.Kind()) }),
+		HookFunc(func(err *errors.Error) { order = append(order, "second:"+err.Kind()) }),
+	)
+	_ = errors.New("NOT_FOUND", 404, "Not found")
+	Install()
+	_ = errors.New("CONFLICT", 409, "Conflict")
+
+	if len(order) != 2 || order[0] != "first:NOT_FOUND" || order[1] != "second:NOT_FOUND" {
+		t.Errorf("Hooks should run in order until removed, got %v", order)
+	}
+}
+
+func TestUseStackCapturer(t *testing.T) {
+	UseStackCapturer(NoStack)
+	if stack := errors.New("NOT_FOUND", 404, "Not found").Stack(); len(stack) != 0 {
+		t.Errorf("NoStack should disable capture, got %v", stack)
+	}
+
+	UseStackCapturer(nil)
+	if stack := errors.New("NOT_FOUND", 404, "Not found").Stack(); len(stack) == 0 {
+		t.Error("nil should restore the default capturer")
+	}
+}
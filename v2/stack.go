@@ -0,0 +1,91 @@
+package errors
+
+import (
+	"runtime"
+	"strings"
+	"sync/atomic"
+)
+
+// maxFrames caps the number of frames captured per error
+const maxFrames = 32
+
+type (
+	// Frame is one captured stack frame
+	Frame struct {
+		Function string
+		File     string
+		Line     int
+	}
+
+	// capturerBox and hookBox hold the installed seams so they can be swapped atomically
+	capturerBox struct {
+		capturer interface{ Capture(skip int) []Frame }
+	}
+	hookBox struct {
+		hook interface{ OnError(err *Error) }
+	}
+)
+
+var (
+	capturer atomic.Pointer[capturerBox]
+	hook     atomic.Pointer[hookBox]
+)
+
+// SetStackCapturer replaces how New, Wrap and Derive capture stacks; nil restores CaptureStack.
+// Capture(skip) must skip skip frames, with 0 identifying its own caller. See observe.StackCapturer.
+func SetStackCapturer(c interface{ Capture(skip int) []Frame }) {
+	if c == nil {
+		capturer.Store(nil)
+		return
+	}
+	capturer.Store(&capturerBox{capturer: c})
+}
+
+// SetHook installs the hook notified of every error created by New, Wrap and Derive;
+// nil removes it. See observe.Install.
+func SetHook(h interface{ OnError(err *Error) }) {
+	if h == nil {
+		hook.Store(nil)
+		return
+	}
+	hook.Store(&hookBox{hook: h})
+}
+
+// CaptureStack returns the calling goroutine's stack, skipping skip frames with 0 identifying
+// the caller of CaptureStack. Runtime and testing frames are left out.
+func CaptureStack(skip int) []Frame {
+	pcs := make([]uintptr, maxFrames)
+	n := runtime.Callers(skip+2, pcs)
+	if n == 0 {
+		return nil
+	}
+
+	frames := runtime.CallersFrames(pcs[:n])
+	result := make([]Frame, 0, n)
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, "runtime.") && !strings.HasPrefix(frame.Function, "testing.") {
+			result = append(result, Frame{Function: frame.Function, File: frame.File, Line: frame.Line})
+		}
+		if !more {
+			break
+		}
+	}
+	return result
+}
+
+// callers captures the stack through the installed capturer, with skip 0 identifying
+// the caller of callers
+func callers(skip int) []Frame {
+	if box := capturer.Load(); box != nil {
+		return box.capturer.Capture(skip + 1)
+	}
+	return CaptureStack(skip + 1)
+}
+
+// notify reports e to the installed hook
+func notify(e *Error) {
+	if box := hook.Load(); box != nil {
+		box.hook.OnError(e)
+	}
+}
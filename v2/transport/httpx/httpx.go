@@ -0,0 +1,136 @@
+// Package httpx maps v2 errors to HTTP: status mapping, a JSON envelope encoder and decoder,
+// and a response writer built from them.
+package httpx
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	errors "github.com/andryhardiyanto/go-errors/v2"
+)
+
+type (
+	// Encoder writes an error response body
+	Encoder interface {
+		Encode(w io.Writer, err *errors.Error) error
+	}
+
+	// Decoder reads an error response body
+	Decoder interface {
+		Decode(r io.Reader) (*errors.Error, error)
+	}
+
+	// StatusMapper chooses the HTTP status of an error
+	StatusMapper interface {
+		HTTPStatus(err *errors.Error) int
+	}
+
+	// StatusMapperFunc adapts a function to StatusMapper
+	StatusMapperFunc func(err *errors.Error) int
+
+	// JSON encodes and decodes the envelope {"type", "code", "message", "violations"}.
+	// Fields, causes and stacks are never encoded.
+	JSON struct{}
+
+	// Writer writes errors as HTTP responses
+	Writer struct {
+		encoder Encoder
+		mapper  StatusMapper
+	}
+
+	// Option configures a Writer
+	Option func(*Writer)
+
+	envelope struct {
+		Type       string      `json:"type"`
+		Code       int64       `json:"code"`
+		Message    string      `json:"message"`
+		Violations []violation `json:"violations"`
+	}
+
+	violation struct {
+		Type    errors.ViolationType `json:"type"`
+		Field   string               `json:"field"`
+		Message string               `json:"message"`
+	}
+)
+
+// DefaultStatusMapper uses the error code when it is a valid HTTP status and 500 otherwise
+var DefaultStatusMapper StatusMapper = StatusMapperFunc(func(err *errors.Error) int {
+	if code := err.Code(); code >= 100 && code <= 599 {
+		return int(code)
+	}
+	return http.StatusInternalServerError
+})
+
+// HTTPStatus calls f(err)
+func (f StatusMapperFunc) HTTPStatus(err *errors.Error) int { return f(err) }
+
+// Encode writes err as a JSON envelope
+func (JSON) Encode(w io.Writer, err *errors.Error) error {
+	body := envelope{
+		Type:       err.Kind(),
+		Code:       err.Code(),
+		Message:    err.Message(),
+		Violations: make([]violation, 0),
+	}
+	for _, v := range err.Violations() {
+		body.Violations = append(body.Violations, violation(v))
+	}
+	return json.NewEncoder(w).Encode(body)
+}
+
+// Decode reads a JSON envelope. The decoded error carries no stack.
+func (JSON) Decode(r io.Reader) (*errors.Error, error) {
+	var body envelope
+	if err := json.NewDecoder(r).Decode(&body); err != nil {
+		return nil, err
+	}
+
+	opts := []errors.Option{errors.WithStack(nil)}
+	for _, v := range body.Violations {
+		opts = append(opts, errors.WithViolations(errors.Violation(v)))
+	}
+	return errors.New(body.Type, body.Code, body.Message, opts...), nil
+}
+
+// WithEncoder sets the response body encoder; the default is JSON
+func WithEncoder(encoder Encoder) Option {
+	return func(w *Writer) {
+		w.encoder = encoder
+	}
+}
+
+// WithStatusMapper sets the status mapping; the default is DefaultStatusMapper
+func WithStatusMapper(mapper StatusMapper) Option {
+	return func(w *Writer) {
+		w.mapper = mapper
+	}
+}
+
+// NewWriter creates a Writer
+func NewWriter(opts ...Option) *Writer {
+	w := &Writer{encoder: JSON{}, mapper: DefaultStatusMapper}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+// Write responds with err. Errors that carry no *errors.Error are written as internal errors,
+// and a nil error writes nothing.
+func (w *Writer) Write(rw http.ResponseWriter, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var e *errors.Error
+	if !errors.As(err, &e) {
+		e = errors.Wrap(err)
+	}
+
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(w.mapper.HTTPStatus(e))
+	return w.encoder.Encode(rw, e)
+}
@@ -0,0 +1,57 @@
+package httpx
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	errors "github.com/andryhardiyanto/go-errors/v2"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	original := errors.New("UNPROCESSABLE_ENTITY", 422, "Unprocessable entity",
+		errors.WithViolations(errors.Violation{Type: errors.ViolationRequired, Field: "email", Message: "Email is required"}),
+		errors.WithField("secret", "hidden"),
+	)
+
+	var buf bytes.Buffer
+	if err := (JSON{}).Encode(&buf, original); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(buf.String(), "hidden") {
+		t.Errorf("Fields should not be encoded, got %s", buf.String())
+	}
+
+	decoded, err := JSON{}.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !errors.Is(decoded, original) || decoded.Code() != 422 || len(decoded.Violations()) != 1 || len(decoded.Stack()) != 0 {
+		t.Errorf("Unexpected decoded error %s/%d %v", decoded.Kind(), decoded.Code(), decoded.Violations())
+	}
+}
+
+func TestWriter(t *testing.T) {
+	rec := httptest.NewRecorder()
+	if err := NewWriter().Write(rec, fmt.Errorf("pq: connection refused")); err != nil {
+		t.Fatal(err)
+	}
+	if rec.Code != http.StatusInternalServerError || strings.Contains(rec.Body.String(), "pq:") {
+		t.Errorf("Unexpected response %d %s", rec.Code, rec.Body.String())
+	}
+
+	mapper := StatusMapperFunc(func(err *errors.Error) int {
+		if err.Code() == 10423 {
+			return http.StatusUnprocessableEntity
+		}
+		return DefaultStatusMapper.HTTPStatus(err)
+	})
+	rec = httptest.NewRecorder()
+	_ = NewWriter(WithStatusMapper(mapper)).Write(rec, errors.New("INSUFFICIENT_FUNDS", 10423, "Insufficient funds"))
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Custom mapper should be used, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,13 @@
+module github.com/andryhardiyanto/go-errors/v2/v1compat
+
+go 1.26.2
+
+require (
+	github.com/andryhardiyanto/go-errors v0.0.0
+	github.com/andryhardiyanto/go-errors/v2 v2.0.0
+)
+
+replace (
+	github.com/andryhardiyanto/go-errors => ../../
+	github.com/andryhardiyanto/go-errors/v2 => ../
+)
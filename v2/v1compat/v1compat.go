@@ -0,0 +1,103 @@
+// Package v1compat converts errors between the v1 and v2 modules so services can migrate one
+// package at a time. Importing it also lets errors.Is match a v2 error against a v1 sentinel
+// of the same type.
+package v1compat
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	v1 "github.com/andryhardiyanto/go-errors"
+	errors "github.com/andryhardiyanto/go-errors/v2"
+)
+
+func init() {
+	errors.RegisterKindResolver(func(target error) (string, bool) {
+		e, ok := target.(*v1.Error)
+		if !ok || e == nil {
+			return "", false
+		}
+		return e.Type, true
+	})
+}
+
+// FromV1 converts a v1 error, preserving its type, code, message, violations, fields, cause
+// and stack. Other v1 fields such as the ID or retry hints have no v2 equivalent yet and are
+// dropped. A nil error converts to nil.
+func FromV1(e *v1.Error) *errors.Error {
+	if e == nil {
+		return nil
+	}
+
+	opts := []errors.Option{errors.WithStack(parseFrames(e.StackTraces)), errors.WithCause(e.Err)}
+	for _, v := range e.Violations {
+		opts = append(opts, errors.WithViolations(errors.Violation{
+			Type:    errors.ViolationType(v.Type),
+			Field:   v.Field,
+			Message: v.Message,
+		}))
+	}
+	for key, value := range e.Fields {
+		opts = append(opts, errors.WithField(key, value))
+	}
+
+	return errors.New(e.Type, e.Code, e.Message, opts...)
+}
+
+// ToV1 converts a v2 error, preserving its kind, code, message, violations, fields, cause and
+// stack. A nil error converts to nil.
+func ToV1(e *errors.Error) *v1.Error {
+	if e == nil {
+		return nil
+	}
+
+	out := &v1.Error{
+		Type:        e.Kind(),
+		Code:        e.Code(),
+		Message:     e.Message(),
+		Violations:  make([]v1.ValidationError, 0),
+		Fields:      e.Fields(),
+		Err:         e.Unwrap(),
+		StackTraces: formatFrames(e.Stack()),
+	}
+	for _, v := range e.Violations() {
+		out.Violations = append(out.Violations, v1.ValidationError{
+			Type:    v1.ViolationErrorType(v.Type),
+			Field:   v.Field,
+			Message: v.Message,
+		})
+	}
+	return out
+}
+
+// formatFrames renders frames in the v1 "file:line function" format
+func formatFrames(frames []errors.Frame) []string {
+	out := make([]string, 0, len(frames))
+	for _, frame := range frames {
+		out = append(out, fmt.Sprintf("%s:%d %s", frame.File, frame.Line, frame.Function))
+	}
+	return out
+}
+
+// parseFrames parses v1 "file:line function" stack entries, skipping malformed ones
+func parseFrames(traces []string) []errors.Frame {
+	frames := make([]errors.Frame, 0, len(traces))
+	for _, trace := range traces {
+		space := strings.LastIndex(trace, " ")
+		if space < 0 {
+			continue
+		}
+		location, function := trace[:space], trace[space+1:]
+		i := strings.LastIndex(location, ":")
+		if i < 0 {
+			continue
+		}
+		line, err := strconv.Atoi(location[i+1:])
+		if err != nil {
+			continue
+		}
+		frames = append(frames, errors.Frame{Function: function, File: location[:i], Line: line})
+	}
+	return frames
+}
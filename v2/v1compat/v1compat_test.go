@@ -0,0 +1,51 @@
+package v1compat
+
+import (
+	stderrors "errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	v1 "github.com/andryhardiyanto/go-errors"
+	errors "github.com/andryhardiyanto/go-errors/v2"
+	"github.com/andryhardiyanto/go-errors/v2/kinds"
+)
+
+func TestRoundTrip(t *testing.T) {
+	cause := fmt.Errorf("pq: no rows")
+	original := v1.ErrorNotFound().WithField("user_id", 42)
+	original.Err = cause
+	original.Violations = append(original.Violations, v1.ValidationError{Type: v1.ViolationErrorTypeRequired, Field: "id", Message: "ID is required"})
+
+	converted := FromV1(original)
+	if converted.Kind() != "NOT_FOUND" || converted.Code() != 404 || converted.Message() != "Not found" {
+		t.Errorf("Unexpected converted error %s/%d/%s", converted.Kind(), converted.Code(), converted.Message())
+	}
+	if value, _ := converted.Field("user_id"); value != 42 || !stderrors.Is(converted, cause) || len(converted.Violations()) != 1 {
+		t.Error("Fields, cause and violations should be preserved")
+	}
+	if len(converted.Stack()) != len(original.StackTraces) || converted.Stack()[0].Line == 0 {
+		t.Errorf("Stack should be preserved, got %v", converted.Stack())
+	}
+
+	back := ToV1(converted)
+	if back.Type != original.Type || back.Code != original.Code || back.Err != cause || back.Fields["user_id"] != 42 {
+		t.Errorf("Unexpected v1 error %+v", back)
+	}
+	if back.StackTraces[0] != original.StackTraces[0] || !reflect.DeepEqual(back.Violations[0], original.Violations[0]) {
+		t.Error("Stack and violations should survive the round trip")
+	}
+	if FromV1(nil) != nil || ToV1(nil) != nil {
+		t.Error("nil should convert to nil")
+	}
+}
+
+func TestIsAcrossVersions(t *testing.T) {
+	err := errors.Derive(kinds.NotFound)
+	if !stderrors.Is(fmt.Errorf("handler: %w", err), v1.ErrorNotFound()) {
+		t.Error("A v2 error should match a v1 sentinel of the same type")
+	}
+	if stderrors.Is(err, v1.ErrorConflict()) {
+		t.Error("Different types should not match")
+	}
+}
@@ -0,0 +1,172 @@
+package errors
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ViolationBuilder collects violations for nested request bodies. Builders returned by Nested and
+// Index add to the same list under a path prefix, so each violation's Field is the dotted path
+// of the field, e.g. "address.street" or "items[0].sku", and its Pointer the JSON Pointer
+// ("/items/0/sku"). A ViolationBuilder is not safe for concurrent use.
+type ViolationBuilder struct {
+	prefix     string
+	violations *[]ValidationError
+}
+
+// NewViolationBuilder returns an empty builder
+func NewViolationBuilder() *ViolationBuilder {
+	return &ViolationBuilder{violations: &[]ValidationError{}}
+}
+
+// Add records a violation of the field under the builder's prefix and returns the builder for chaining
+func (b *ViolationBuilder) Add(field string, violationType ViolationErrorType, message string) *ViolationBuilder {
+	path := joinFieldPath(b.prefix, field)
+	*b.violations = append(*b.violations, ValidationError{
+		Type:    violationType,
+		Field:   path,
+		Pointer: JSONPointer(path),
+		Message: message,
+	})
+	return b
+}
+
+// Nested returns a builder adding violations of the object in field, e.g. Nested("address")
+// followed by Add("street", ...) records "address.street"
+func (b *ViolationBuilder) Nested(field string) *ViolationBuilder {
+	return &ViolationBuilder{prefix: joinFieldPath(b.prefix, field), violations: b.violations}
+}
+
+// Index returns a builder adding violations of element i of the array in field, e.g.
+// Index("items", 0) followed by Add("sku", ...) records "items[0].sku"
+func (b *ViolationBuilder) Index(field string, i int) *ViolationBuilder {
+	return &ViolationBuilder{prefix: joinFieldPath(b.prefix, field) + "[" + strconv.Itoa(i) + "]", violations: b.violations}
+}
+
+// Violations returns a copy of the violations recorded so far through the builder or any
+// builder derived from it
+func (b *ViolationBuilder) Violations() []ValidationError {
+	return append(make([]ValidationError, 0, len(*b.violations)), *b.violations...)
+}
+
+// Err returns the violations as an UNPROCESSABLE_ENTITY error (see Violations), or nil when
+// none were recorded
+func (b *ViolationBuilder) Err() *Error {
+	if len(*b.violations) == 0 {
+		return nil
+	}
+	return created(&Error{
+		Type:        "UNPROCESSABLE_ENTITY",
+		Code:        422,
+		Message:     "Unprocessable entity",
+		Violations:  b.Violations(),
+		StackTraces: captureStackTrace(1),
+	})
+}
+
+// AddViolation appends violations to the error and returns the error for chaining, e.g. to
+// accumulate the results of several validation phases into one response
+func (e *Error) AddViolation(violations ...ValidationError) *Error {
+	return e.update(func(e *Error) {
+		e.Violations = append(e.Violations[:len(e.Violations):len(e.Violations)], violations...)
+	})
+}
+
+// MergeViolations appends the violations of other to the error and returns the error for
+// chaining. A nil other adds nothing.
+func (e *Error) MergeViolations(other *Error) *Error {
+	if other == nil {
+		return e
+	}
+	return e.AddViolation(other.snapshot().Violations...)
+}
+
+// ViolationsForField returns a copy of the violations of field, matched exactly against their
+// Field, e.g. "address.street"
+func (e *Error) ViolationsForField(field string) []ValidationError {
+	violations := make([]ValidationError, 0)
+	if e == nil {
+		return violations
+	}
+	for _, v := range e.snapshot().Violations {
+		if v.Field == field {
+			violations = append(violations, v)
+		}
+	}
+	return violations
+}
+
+// HasViolations reports whether the error has any violation
+func (e *Error) HasViolations() bool {
+	return e != nil && len(e.snapshot().Violations) > 0
+}
+
+// JSONPointer converts a dotted field path such as "items[0].sku" into an RFC 6901 JSON
+// Pointer ("/items/0/sku"), escaping "~" and "/" in names. An empty path is the empty pointer.
+func JSONPointer(path string) string {
+	var b strings.Builder
+	for _, segment := range splitFieldPath(path) {
+		b.WriteByte('/')
+		b.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(segment))
+	}
+	return b.String()
+}
+
+// FieldPathFromPointer converts an RFC 6901 JSON Pointer such as "/items/0/sku" into a dotted
+// field path ("items[0].sku"). Numeric segments become indexes.
+func FieldPathFromPointer(pointer string) string {
+	if pointer == "" {
+		return ""
+	}
+
+	var path string
+	for _, segment := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		segment = strings.NewReplacer("~1", "/", "~0", "~").Replace(segment)
+		if _, err := strconv.Atoi(segment); err == nil && path != "" {
+			path += "[" + segment + "]"
+			continue
+		}
+		path = joinFieldPath(path, segment)
+	}
+	return path
+}
+
+// JSONPointer returns the violation's Pointer, or one derived from its Field
+func (v ValidationError) JSONPointer() string {
+	if v.Pointer != "" {
+		return v.Pointer
+	}
+	return JSONPointer(v.Field)
+}
+
+// joinFieldPath appends field to the dotted path prefix
+func joinFieldPath(prefix, field string) string {
+	if prefix == "" {
+		return field
+	}
+	if field == "" {
+		return prefix
+	}
+	return prefix + "." + field
+}
+
+// splitFieldPath splits a dotted path into its names and indexes, e.g. "items[0].sku" into
+// "items", "0" and "sku"
+func splitFieldPath(path string) []string {
+	if path == "" {
+		return nil
+	}
+
+	segments := make([]string, 0, strings.Count(path, ".")+1)
+	for _, part := range strings.Split(path, ".") {
+		name, indexes, _ := strings.Cut(part, "[")
+		segments = append(segments, name)
+		if indexes == "" {
+			continue
+		}
+		for _, index := range strings.Split(strings.TrimSuffix(indexes, "]"), "][") {
+			segments = append(segments, index)
+		}
+	}
+	return segments
+}
@@ -0,0 +1,99 @@
+package errors
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestViolationBuilder(t *testing.T) {
+	b := NewViolationBuilder()
+	if b.Err() != nil {
+		t.Error("An empty builder should not produce an error")
+	}
+
+	b.Add("email", ViolationErrorTypeEmail, "Invalid email")
+	b.Nested("address").Add("street", ViolationErrorTypeRequired, "Street is required")
+	items := b.Index("items", 1)
+	items.Add("sku", ViolationErrorTypeRequired, "SKU is required")
+	items.Nested("price").Add("amount", ViolationErrorTypeMin, "Amount must be positive")
+
+	want := []ValidationError{
+		{Type: ViolationErrorTypeEmail, Field: "email", Pointer: "/email", Message: "Invalid email"},
+		{Type: ViolationErrorTypeRequired, Field: "address.street", Pointer: "/address/street", Message: "Street is required"},
+		{Type: ViolationErrorTypeRequired, Field: "items[1].sku", Pointer: "/items/1/sku", Message: "SKU is required"},
+		{Type: ViolationErrorTypeMin, Field: "items[1].price.amount", Pointer: "/items/1/price/amount", Message: "Amount must be positive"},
+	}
+	got := b.Violations()
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d violations, got %v", len(want), got)
+	}
+	for i := range want {
+		if !reflect.DeepEqual(got[i], want[i]) {
+			t.Errorf("Violation %d: expected %+v, got %+v", i, want[i], got[i])
+		}
+	}
+
+	e := b.Err()
+	if e.Type != "UNPROCESSABLE_ENTITY" || len(e.Violations) != 4 || !strings.Contains(e.StackTraces[0], "TestViolationBuilder") {
+		t.Errorf("Unexpected error %+v", e)
+	}
+	data, _ := json.Marshal(e.Violations[2])
+	if !strings.Contains(string(data), `"pointer":"/items/1/sku"`) {
+		t.Errorf("Pointer should be serialized, got %s", data)
+	}
+}
+
+func TestJSONPointer(t *testing.T) {
+	cases := []struct{ path, pointer string }{
+		{"", ""},
+		{"email", "/email"},
+		{"address.street", "/address/street"},
+		{"items[0].sku", "/items/0/sku"},
+		{"matrix[1][2]", "/matrix/1/2"},
+		{"headers.a/b~c", "/headers/a~1b~0c"},
+	}
+	for _, c := range cases {
+		if got := JSONPointer(c.path); got != c.pointer {
+			t.Errorf("JSONPointer(%q) = %q, want %q", c.path, got, c.pointer)
+		}
+		if got := FieldPathFromPointer(c.pointer); got != c.path {
+			t.Errorf("FieldPathFromPointer(%q) = %q, want %q", c.pointer, got, c.path)
+		}
+	}
+
+	v := ValidationError{Field: "items[0].sku"}
+	if v.JSONPointer() != "/items/0/sku" || v.WithDocsURL("x").JSONPointer() != "/items/0/sku" {
+		t.Error("The pointer should be derived from the field")
+	}
+}
+
+func TestAddAndMergeViolations(t *testing.T) {
+	schema := []ValidationError{{Type: ViolationErrorTypeRequired, Field: "email"}}
+	e := Violations(schema[:1:1])
+	if !e.HasViolations() || ErrorNotFound().HasViolations() || (*Error)(nil).HasViolations() {
+		t.Error("Unexpected HasViolations result")
+	}
+
+	rules := NewViolationBuilder().Add("email", ViolationErrorTypeUnique, "Email is taken").Add("age", ViolationErrorTypeMin, "Too young").Err()
+	e.MergeViolations(rules).MergeViolations(nil).AddViolation(NewViolation(ViolationErrorTypeGT, "quantity", "0"))
+
+	if len(e.Violations) != 4 || len(schema) != 1 {
+		t.Fatalf("Expected 4 violations without changing the input slice, got %v", e.Violations)
+	}
+	if got := e.ViolationsForField("email"); len(got) != 2 || got[0].Type != ViolationErrorTypeRequired || got[1].Type != ViolationErrorTypeUnique {
+		t.Errorf("Unexpected email violations %v", got)
+	}
+	if got := e.ViolationsForField("missing"); got == nil || len(got) != 0 {
+		t.Errorf("Expected an empty slice, got %v", got)
+	}
+	if len(rules.Violations) != 2 {
+		t.Error("The merged error should not be modified")
+	}
+
+	e.MergeViolations(e)
+	if len(e.Violations) != 8 {
+		t.Errorf("Merging an error into itself should duplicate its violations, got %d", len(e.Violations))
+	}
+}
@@ -0,0 +1,105 @@
+package errors
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	violationTypesMu sync.RWMutex
+	// violationTypes maps the registered violation types to their default message templates
+	violationTypes = map[ViolationErrorType]string{
+		ViolationErrorTypeRequired:   "{field} is required",
+		ViolationErrorTypeOneOf:      "{field} must be one of [{param}]",
+		ViolationErrorTypeUUID:       "{field} must be a valid UUID",
+		ViolationErrorTypeMin:        "{field} must be at least {param}",
+		ViolationErrorTypeMax:        "{field} must be at most {param}",
+		ViolationErrorTypeEmail:      "{field} must be a valid email address",
+		ViolationErrorTypeDate:       "{field} must be a valid date in format {param}",
+		ViolationErrorTypeRequiredIf: "{field} is required when {param}",
+		ViolationErrorTypeSort:       "{field} is not a valid sort order",
+		ViolationErrorTypeLen:        "{field} must have a length of {param}",
+		ViolationErrorTypeGT:         "{field} must be greater than {param}",
+		ViolationErrorTypeGTE:        "{field} must be greater than or equal to {param}",
+		ViolationErrorTypeLT:         "{field} must be less than {param}",
+		ViolationErrorTypeLTE:        "{field} must be less than or equal to {param}",
+		ViolationErrorTypeURL:        "{field} must be a valid URL",
+		ViolationErrorTypeNumeric:    "{field} must be numeric",
+		ViolationErrorTypeAlphanum:   "{field} must contain only letters and digits",
+		ViolationErrorTypeDateTime:   "{field} must be a valid date and time in format {param}",
+		ViolationErrorTypePhone:      "{field} must be a valid phone number",
+		ViolationErrorTypeEnum:       "{field} must be one of [{param}]",
+		ViolationErrorTypeUnique:     "{field} must contain unique values",
+		ViolationErrorTypeRegexp:     "{field} must match {param}",
+		ViolationErrorTypeConfig:     "{field} is invalid",
+	}
+)
+
+// RegisterViolationType registers a custom violation type with the default message template of
+// NewViolation, whose {field} and {param} placeholders are filled in. An empty template keeps the
+// type without a default message. Registering a type again, including a predefined one, replaces
+// its template.
+func RegisterViolationType(violationType ViolationErrorType, template string) {
+	violationTypesMu.Lock()
+	defer violationTypesMu.Unlock()
+	violationTypes[violationType] = template
+}
+
+// LookupViolationType returns the default message template of a violation type and whether the
+// type is predefined or registered
+func LookupViolationType(violationType ViolationErrorType) (template string, ok bool) {
+	violationTypesMu.RLock()
+	defer violationTypesMu.RUnlock()
+	template, ok = violationTypes[violationType]
+	return template, ok
+}
+
+// ViolationTypes returns the predefined and registered violation types sorted by name, e.g. for
+// documentation export
+func ViolationTypes() []ViolationErrorType {
+	violationTypesMu.RLock()
+	defer violationTypesMu.RUnlock()
+
+	types := make([]ViolationErrorType, 0, len(violationTypes))
+	for violationType := range violationTypes {
+		types = append(types, violationType)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		return types[i] < types[j]
+	})
+	return types
+}
+
+// NewViolation returns a violation of field whose message is the type's default template with
+// {field} and {param} filled in, e.g. "quantity must be greater than 0" for
+// NewViolation(ViolationErrorTypeGT, "quantity", "0"). Types without a template get an empty message.
+// A non-empty param is also recorded in Params under the lower-cased type, as a number when it
+// is one, e.g. {"gt": 0}; lists of the ONEOF and ENUM types become string slices.
+func NewViolation(violationType ViolationErrorType, field, param string) ValidationError {
+	template, _ := LookupViolationType(violationType)
+	v := ValidationError{
+		Type:    violationType,
+		Field:   field,
+		Message: renderTemplate(template, map[string]any{"field": field, "param": param}),
+	}
+	if param != "" {
+		v = v.WithParam(strings.ToLower(string(violationType)), violationParam(violationType, param))
+	}
+	return v
+}
+
+// violationParam converts a rule parameter into the value recorded in Params
+func violationParam(violationType ViolationErrorType, param string) any {
+	if violationType == ViolationErrorTypeOneOf || violationType == ViolationErrorTypeEnum {
+		return strings.Fields(param)
+	}
+	if n, err := strconv.ParseInt(param, 10, 64); err == nil {
+		return n
+	}
+	if f, err := strconv.ParseFloat(param, 64); err == nil {
+		return f
+	}
+	return param
+}
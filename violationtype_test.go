@@ -0,0 +1,52 @@
+package errors
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestNewViolation(t *testing.T) {
+	v := NewViolation(ViolationErrorTypeGT, "quantity", "0")
+	if v.Type != ViolationErrorTypeGT || v.Field != "quantity" || v.Message != "quantity must be greater than 0" {
+		t.Errorf("Unexpected violation %+v", v)
+	}
+	if v.Params["gt"] != int64(0) {
+		t.Errorf("Expected the gt parameter, got %v", v.Params)
+	}
+	if v := NewViolation(ViolationErrorTypeEnum, "status", "open closed"); fmt.Sprint(v.Params["enum"]) != "[open closed]" {
+		t.Errorf("Expected the enum values, got %v", v.Params)
+	}
+	if v := NewViolation(ViolationErrorTypeRegexp, "slug", "^[a-z]+$"); v.Params["regexp"] != "^[a-z]+$" {
+		t.Errorf("Expected the pattern, got %v", v.Params)
+	}
+	if v := NewViolation("UNKNOWN", "x", ""); v.Message != "" || v.Params != nil {
+		t.Errorf("Unknown types should have no message, got %q", v.Message)
+	}
+}
+
+func TestRegisterViolationType(t *testing.T) {
+	const iban ViolationErrorType = "IBAN"
+	t.Cleanup(func() {
+		violationTypesMu.Lock()
+		delete(violationTypes, iban)
+		violationTypesMu.Unlock()
+	})
+
+	if _, ok := LookupViolationType(iban); ok {
+		t.Fatal("IBAN should not be registered yet")
+	}
+	RegisterViolationType(iban, "{field} must be a valid IBAN")
+
+	if template, ok := LookupViolationType(iban); !ok || template != "{field} must be a valid IBAN" {
+		t.Errorf("Unexpected template %q", template)
+	}
+	if v := NewViolation(iban, "account", ""); v.Message != "account must be a valid IBAN" {
+		t.Errorf("Unexpected message %q", v.Message)
+	}
+
+	types := ViolationTypes()
+	if !slices.Contains(types, iban) || !slices.Contains(types, ViolationErrorTypeRegexp) || !slices.IsSorted(types) {
+		t.Errorf("Expected the sorted predefined and registered types, got %v", types)
+	}
+}